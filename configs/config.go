@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +20,70 @@ type Config struct {
 	DLQTopic    string
 	Port        string
 	GRPCPort    string
+
+	// ReservationTTL là thời gian giữ chỗ mặc định nếu request không chỉ định.
+	ReservationTTL time.Duration
+	// ReservationSweepInterval là chu kỳ quét và expire các reservation quá hạn.
+	ReservationSweepInterval time.Duration
+
+	// OutboxPollInterval là chu kỳ relay quét bảng outbox khi không còn event pending.
+	OutboxPollInterval time.Duration
+	// OutboxBatchSize là số event tối đa relay publish trong một lần.
+	OutboxBatchSize int
+
+	// RetryDelays là độ trễ của các tầng retry topic, theo thứ tự (ví dụ 5s, 1m, 10m).
+	RetryDelays []time.Duration
+	// RetryMaxRetries là số lần retry tối đa theo loại event (create, update, delete) trước khi vào DLQ.
+	RetryMaxRetries map[string]int
+
+	// ProcessedEventsRetention là thời gian giữ ID của event đã xử lý để chống xử lý trùng; không được ngắn hơn
+	// thời gian Kafka giữ message của topic chính, các tầng retry và DLQ.
+	ProcessedEventsRetention time.Duration
+	// ProcessedEventsPurgeInterval là chu kỳ dọn các processed_events quá ProcessedEventsRetention.
+	ProcessedEventsPurgeInterval time.Duration
+
+	// IdempotencyTTL là thời gian lưu response của request có Idempotency-Key để replay.
+	IdempotencyTTL time.Duration
+	// IdempotencyPurgeInterval là chu kỳ dọn các idempotency key đã hết hạn.
+	IdempotencyPurgeInterval time.Duration
+
+	// CacheTTL là thời gian sống của cache thông tin tồn kho trong Redis.
+	CacheTTL time.Duration
+	// CacheNegativeTTL là thời gian cache kết quả "item không tồn tại".
+	CacheNegativeTTL time.Duration
+	// CacheTTLJitterPercent là phần trăm TTL ngẫu nhiên cộng thêm để các key không hết hạn cùng lúc.
+	CacheTTLJitterPercent int
+	// CacheLockTTL là thời gian giữ lock nạp cache; request khác chờ tối đa chừng này trước khi tự đọc DB.
+	CacheLockTTL time.Duration
+
+	// StockAlertTopic là topic Kafka nhận cảnh báo low_stock/out_of_stock/back_in_stock; rỗng = tắt cảnh báo.
+	StockAlertTopic string
+	// StockAlertHysteresisPercent là vùng đệm (% reorder point) tồn kho phải vượt qua để thoát trạng thái cảnh báo.
+	StockAlertHysteresisPercent int
+
+	// WatchResyncInterval là chu kỳ WatchInventory đọc lại toàn bộ item đang watch,
+	// phòng trường hợp thông báo pub/sub bị mất.
+	WatchResyncInterval time.Duration
+	// StreamHeartbeatInterval là chu kỳ gửi heartbeat trên các kết nối SSE/WebSocket.
+	StreamHeartbeatInterval time.Duration
+	// StreamWriteTimeout là thời gian tối đa cho một lần ghi SSE/WebSocket; client chậm hơn bị ngắt kết nối.
+	StreamWriteTimeout time.Duration
+
+	// WebhookPollInterval là chu kỳ quét webhook delivery tới hạn khi hàng đợi trống.
+	WebhookPollInterval time.Duration
+	// WebhookBatchSize là số delivery tối đa được gửi song song trong một lần.
+	WebhookBatchSize int
+	// WebhookMaxAttempts là số lần gửi tối đa trước khi delivery chuyển sang failed.
+	WebhookMaxAttempts int
+	// WebhookTimeout là thời gian chờ tối đa cho một request gửi webhook.
+	WebhookTimeout time.Duration
+
+	// TransferTopic là topic Kafka nhận event của các bước trong vòng đời lệnh chuyển kho; rỗng = không publish.
+	TransferTopic string
+
+	// CycleCountApprovalThresholdPercent là chênh lệch kiểm kê tối đa (% số lượng kỳ vọng) được post ngay khi submit;
+	// phiên có dòng vượt ngưỡng phải được duyệt.
+	CycleCountApprovalThresholdPercent int
 }
 
 func LoadConfig(path ...string) (*Config, error) {
@@ -41,5 +108,106 @@ func LoadConfig(path ...string) (*Config, error) {
 		DLQTopic:    os.Getenv("DLQ_TOPIC"),
 		Port:        os.Getenv("PORT"),
 		GRPCPort:    os.Getenv("GRPC_PORT"),
+
+		ReservationTTL:           getDurationEnv("RESERVATION_TTL", 15*time.Minute),
+		ReservationSweepInterval: getDurationEnv("RESERVATION_SWEEP_INTERVAL", 30*time.Second),
+
+		OutboxPollInterval: getDurationEnv("OUTBOX_POLL_INTERVAL", 500*time.Millisecond),
+		OutboxBatchSize:    getIntEnv("OUTBOX_BATCH_SIZE", 100),
+
+		RetryDelays:     getDurationListEnv("RETRY_TIERS", []time.Duration{5 * time.Second, time.Minute, 10 * time.Minute}),
+		RetryMaxRetries: getIntMapEnv("RETRY_MAX_RETRIES"),
+
+		ProcessedEventsRetention:     getDurationEnv("PROCESSED_EVENTS_RETENTION", 7*24*time.Hour),
+		ProcessedEventsPurgeInterval: getDurationEnv("PROCESSED_EVENTS_PURGE_INTERVAL", 10*time.Minute),
+
+		IdempotencyTTL:           getDurationEnv("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyPurgeInterval: getDurationEnv("IDEMPOTENCY_PURGE_INTERVAL", 10*time.Minute),
+
+		CacheTTL:              getDurationEnv("CACHE_TTL", 30*time.Second),
+		CacheNegativeTTL:      getDurationEnv("CACHE_NEGATIVE_TTL", 5*time.Second),
+		CacheTTLJitterPercent: getIntEnv("CACHE_TTL_JITTER_PERCENT", 10),
+		CacheLockTTL:          getDurationEnv("CACHE_LOCK_TTL", 2*time.Second),
+
+		StockAlertTopic:             os.Getenv("STOCK_ALERT_TOPIC"),
+		StockAlertHysteresisPercent: getIntEnv("STOCK_ALERT_HYSTERESIS_PERCENT", 10),
+
+		WatchResyncInterval:     getDurationEnv("WATCH_RESYNC_INTERVAL", 30*time.Second),
+		StreamHeartbeatInterval: getDurationEnv("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+		StreamWriteTimeout:      getDurationEnv("STREAM_WRITE_TIMEOUT", 10*time.Second),
+
+		WebhookPollInterval: getDurationEnv("WEBHOOK_POLL_INTERVAL", time.Second),
+		WebhookBatchSize:    getIntEnv("WEBHOOK_BATCH_SIZE", 20),
+		WebhookMaxAttempts:  getIntEnv("WEBHOOK_MAX_ATTEMPTS", 10),
+		WebhookTimeout:      getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+
+		TransferTopic: os.Getenv("TRANSFER_TOPIC"),
+
+		CycleCountApprovalThresholdPercent: getIntEnv("CYCLE_COUNT_APPROVAL_THRESHOLD_PERCENT", 5),
 	}, nil
 }
+
+// getDurationEnv đọc biến môi trường dạng duration (ví dụ "15m"), trả về def nếu thiếu hoặc sai định dạng.
+func getDurationEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Giá trị %s không hợp lệ (%s), dùng mặc định %s", key, v, def)
+		return def
+	}
+	return d
+}
+
+// getIntEnv đọc biến môi trường dạng số nguyên, trả về def nếu thiếu hoặc sai định dạng.
+func getIntEnv(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Giá trị %s không hợp lệ (%s), dùng mặc định %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// getDurationListEnv đọc danh sách duration phân tách bởi dấu phẩy (ví dụ "5s,1m,10m").
+func getDurationListEnv(key string, def []time.Duration) []time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var result []time.Duration
+	for _, part := range strings.Split(v, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || d <= 0 {
+			log.Printf("Giá trị %s không hợp lệ (%s), dùng mặc định %v", key, v, def)
+			return def
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// getIntMapEnv đọc map dạng "key:số" phân tách bởi dấu phẩy (ví dụ "create:1,update:3").
+func getIntMapEnv(key string) map[string]int {
+	result := make(map[string]int)
+	v := os.Getenv(key)
+	if v == "" {
+		return result
+	}
+	for _, part := range strings.Split(v, ",") {
+		name, numStr, ok := strings.Cut(strings.TrimSpace(part), ":")
+		n, err := strconv.Atoi(strings.TrimSpace(numStr))
+		if !ok || err != nil {
+			log.Printf("Giá trị %s không hợp lệ (%s), bỏ qua", key, part)
+			continue
+		}
+		result[strings.TrimSpace(name)] = n
+	}
+	return result
+}
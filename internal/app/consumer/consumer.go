@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"log"
+	"sync"
 	"time"
 
+	"inventory-service.com/m/internal/cache"
 	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
 	kafkaUtils "inventory-service.com/m/internal/utils/kafka"
 	redisUtils "inventory-service.com/m/internal/utils/redis"
 
@@ -20,13 +24,31 @@ import (
 // InventoryConsumer xử lý các sự kiện từ Kafka và cập nhật inventory.
 type InventoryConsumer struct {
 	db           *sql.DB
+	repo         *repository.InventoryRepository
+	deadLetters  *repository.DeadLetterRepository
 	redisClient  *redis.Client
 	kafkaReader  *kafka.Reader
 	dlqWriter    *kafka.Writer
+	retryWriter  *kafka.Writer // writer không gắn topic, dùng cho các tầng retry
+	retryPolicy  RetryPolicy
 	workerCount  int
-	workerQueues []chan model.InventoryEvent // mảng channel cho mỗi worker
+	workerQueues []chan consumedEvent // mảng channel cho mỗi worker
+	offsets      *offsetTracker
 }
 
+// consumedEvent là event đã giải mã kèm message Kafka gốc để commit offset sau khi xử lý.
+type consumedEvent struct {
+	event model.InventoryEvent
+	msg   kafka.Message
+}
+
+var (
+	// errDeadLettered cho biết event xử lý thất bại nhưng đã được đưa vào DLQ, offset có thể commit.
+	errDeadLettered = errors.New("event đã được đưa vào DLQ")
+	// errUnknownEventType cho biết event không thuộc loại consumer xử lý, được bỏ qua.
+	errUnknownEventType = errors.New("loại event không xác định")
+)
+
 // getWorkerIndex tính chỉ số worker dựa trên giá trị string key (ví dụ: ItemID)
 func getWorkerIndex(key string, workerCount int) int {
 	h := fnv.New32a()
@@ -35,48 +57,50 @@ func getWorkerIndex(key string, workerCount int) int {
 }
 
 // NewInventoryConsumer tạo mới một InventoryConsumer với số lượng worker mong muốn.
-func NewInventoryConsumer(db *sql.DB, redisClient *redis.Client, kafkaReader *kafka.Reader, dlqWriter *kafka.Writer, workerCount int) *InventoryConsumer {
-	queues := make([]chan model.InventoryEvent, workerCount)
+func NewInventoryConsumer(db *sql.DB, repo *repository.InventoryRepository, redisClient *redis.Client, kafkaReader *kafka.Reader, dlqWriter, retryWriter *kafka.Writer, retryPolicy RetryPolicy, workerCount int) *InventoryConsumer {
+	queues := make([]chan consumedEvent, workerCount)
 	for i := 0; i < workerCount; i++ {
-		queues[i] = make(chan model.InventoryEvent, 100) // mỗi channel có bộ đệm 100 event
+		queues[i] = make(chan consumedEvent, 100) // mỗi channel có bộ đệm 100 event
 	}
 	return &InventoryConsumer{
 		db:           db,
+		repo:         repo,
+		deadLetters:  repository.NewDeadLetterRepository(db),
 		redisClient:  redisClient,
 		kafkaReader:  kafkaReader,
 		dlqWriter:    dlqWriter,
+		retryWriter:  retryWriter,
+		retryPolicy:  retryPolicy,
 		workerCount:  workerCount,
 		workerQueues: queues,
-	}
-}
-
-// pushToDLQ đưa event vào DLQ thông qua kafkaUtils.
-func (c *InventoryConsumer) pushToDLQ(ctx context.Context, event model.InventoryEvent) {
-	eventBytes, err := json.Marshal(event)
-	if err != nil {
-		log.Printf("Lỗi mã hóa event cho DLQ: %v", err)
-		return
-	}
-	// Sử dụng event.ItemID làm key (có thể thay đổi nếu cần)
-	err = kafkaUtils.WriteMessageWrapper(ctx, c.dlqWriter, []byte(event.Id), eventBytes)
-	if err != nil {
-		log.Printf("Lỗi gửi event vào DLQ: %v", err)
-	} else {
-		log.Printf("Event được đưa vào DLQ: itemID %s", event.Id)
+		offsets:      newOffsetTracker(),
 	}
 }
 
 // Start bắt đầu vòng lặp đọc message từ Kafka và phân phối event vào worker pool.
+// Message được đọc bằng FetchMessage; offset chỉ được commit sau khi event và mọi event
+// trước nó trên cùng partition đã xử lý xong hoặc đã vào DLQ.
 func (c *InventoryConsumer) Start(ctx context.Context) {
+	committerDone := make(chan struct{})
+	go func() {
+		c.offsets.runCommitter(c.kafkaReader)
+		close(committerDone)
+	}()
+
 	// Khởi chạy worker pool: mỗi worker lắng nghe một channel riêng.
+	var wg sync.WaitGroup
 	for i := 0; i < c.workerCount; i++ {
-		go c.worker(ctx, c.workerQueues[i], i)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.worker(ctx, c.workerQueues[i], i)
+		}(i)
 	}
 
 	// Vòng lặp đọc message từ Kafka.
 readLoop:
 	for {
-		msg, err := kafkaUtils.ReadMessageWrapper(ctx, c.kafkaReader)
+		msg, err := kafkaUtils.FetchMessageWrapper(ctx, c.kafkaReader)
 		if err != nil {
 			select {
 			case <-ctx.Done():
@@ -88,9 +112,15 @@ readLoop:
 			continue
 		}
 
+		c.offsets.track(msg)
+
 		var event model.InventoryEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
 			log.Printf("Lỗi giải mã message: %v", err)
+			// Chỉ commit khi đã lưu được dead letter; storeDeadLetterUntilDone chỉ thất bại khi đang shutdown.
+			if c.storeDeadLetterUntilDone(ctx, msg, event, fmt.Errorf("lỗi giải mã message: %w", err)) == nil {
+				c.offsets.markDone(msg)
+			}
 			continue
 		}
 
@@ -98,7 +128,7 @@ readLoop:
 		workerIndex := getWorkerIndex(event.Id, c.workerCount)
 
 		select {
-		case c.workerQueues[workerIndex] <- event:
+		case c.workerQueues[workerIndex] <- consumedEvent{event: event, msg: msg}:
 			// event được gửi thành công vào channel của worker
 		case <-ctx.Done():
 			log.Println("Context bị hủy, dừng nhận event")
@@ -110,136 +140,224 @@ readLoop:
 	for _, queue := range c.workerQueues {
 		close(queue)
 	}
+	// Chờ worker xử lý xong rồi commit nốt các offset còn lại.
+	wg.Wait()
+	close(c.offsets.commits)
+	<-committerDone
 }
 
 // worker xử lý các event nhận từ channel của nó theo thứ tự FIFO.
-func (c *InventoryConsumer) worker(ctx context.Context, eventChan <-chan model.InventoryEvent, workerID int) {
-	for event := range eventChan {
-		if err := c.processEvent(ctx, event); err != nil {
-			log.Printf("Worker %d: lỗi xử lý event cho item %s: %v", workerID, event.Id, err)
-		} else {
+// Offset chỉ được đánh dấu xong khi event xử lý thành công, bị bỏ qua, đã chuyển sang topic retry
+// hoặc đã vào DLQ. handleMessage thử chuyển tiếp tới khi thành công, nên offset chỉ bị bỏ lại khi
+// đang shutdown; event đó được giao lại sau khi restart.
+func (c *InventoryConsumer) worker(ctx context.Context, eventChan <-chan consumedEvent, workerID int) {
+	for item := range eventChan {
+		event := item.event
+		err := c.handleMessage(ctx, event, item.msg)
+		switch {
+		case err == nil:
 			log.Printf("Worker %d: xử lý event %s cho item %s thành công", workerID, event.Type, event.Id)
+			c.offsets.markDone(item.msg)
+		case handled(err):
+			log.Printf("Worker %d: lỗi xử lý event cho item %s: %v", workerID, event.Id, err)
+			c.offsets.markDone(item.msg)
+		default:
+			log.Printf("Worker %d: dừng khi chưa chuyển tiếp event cho item %s, offset %d/%d không được commit: %v",
+				workerID, event.Id, item.msg.Partition, item.msg.Offset, err)
 		}
 	}
 }
 
-// processEvent phân loại và xử lý event theo loại, có cơ chế retry 3 lần.
+// processEvent phân loại và xử lý event theo loại, đúng một lần.
+// Việc retry do pipeline retry topic đảm nhiệm (xem handleMessage) để worker không bị chặn.
 func (c *InventoryConsumer) processEvent(ctx context.Context, event model.InventoryEvent) error {
-	var err error
-	attempts := 3
 	switch event.Type {
 	case model.EventTypeCreate:
-		for i := 0; i < attempts; i++ {
-			err = c.attemptProcessCreate(ctx, event)
-			if err == nil {
-				return nil
-			}
-			log.Printf("Lỗi processCreate attempt %d cho item %s: %v", i+1, event.Id, err)
-			time.Sleep(1 * time.Second)
-		}
+		return c.attemptProcessCreate(ctx, event)
 	case model.EventTypeUpdate:
-		for i := 0; i < attempts; i++ {
-			err = c.attemptProcessUpdate(ctx, event)
-			if err == nil {
-				return nil
-			}
-			log.Printf("Lỗi processUpdate attempt %d cho item %s: %v", i+1, event.Id, err)
-			time.Sleep(1 * time.Second)
-		}
+		return c.attemptProcessUpdate(ctx, event)
 	case model.EventTypeDelete:
-		for i := 0; i < attempts; i++ {
-			err = c.attemptProcessDelete(ctx, event)
-			if err == nil {
-				return nil
-			}
-			log.Printf("Lỗi processDelete attempt %d cho item %s: %v", i+1, event.Id, err)
-			time.Sleep(1 * time.Second)
-		}
+		return c.attemptProcessDelete(ctx, event)
 	default:
-		return fmt.Errorf("loại event không xác định: %s", event.Type)
+		return fmt.Errorf("%w: %s", errUnknownEventType, event.Type)
+	}
+}
+
+// stockChangeFromEvent chuyển event Kafka thành StockChange, ghi nguồn là kafka.
+// Nếu event không mang correlation ID thì dùng event ID để truy vết.
+func stockChangeFromEvent(event model.InventoryEvent) repository.StockChange {
+	correlationID := event.CorrelationID
+	if correlationID == "" {
+		correlationID = event.EventID
+	}
+	return repository.StockChange{
+		ItemID:          event.Id,
+		LocationID:      event.Location,
+		Delta:           event.Quantity,
+		Reason:          model.MovementReason(event.Reason),
+		Source:          model.MovementSourceKafka,
+		CorrelationID:   correlationID,
+		ExpectedVersion: event.ExpectedVersion,
+	}
+}
+
+// applyOnce chạy apply trong một transaction cùng với việc ghi nhận event.EventID vào processed_events.
+// Nếu event đã được xử lý trước đó thì bỏ qua, nhờ vậy consumer chính và DLQ consumer có thể replay an toàn.
+// Event không có EventID (producer cũ) vẫn được xử lý nhưng không được chống trùng.
+// Event được xử lý cũng được đưa vào hàng đợi webhook của các subscription quan tâm.
+func (c *InventoryConsumer) applyOnce(ctx context.Context, event model.InventoryEvent, apply func(tx *sql.Tx) error) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if event.EventID == "" {
+		log.Printf("Event %s cho item %s không có event_id, không thể chống xử lý trùng", event.Type, event.Id)
+	} else {
+		fresh, err := repository.MarkEventProcessedTx(ctx, tx, event.EventID, string(event.Type), event.Id)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			log.Printf("Bỏ qua event trùng %s cho item %s", event.EventID, event.Id)
+			return nil
+		}
+	}
+
+	if err := apply(tx); err != nil {
+		return err
 	}
-	// Nếu sau 3 lần vẫn thất bại, đưa event vào DLQ.
-	c.pushToDLQ(ctx, event)
-	return fmt.Errorf("xử lý event %s cho item %s thất bại sau %d lần: %v", event.Type, event.Id, attempts, err)
+
+	// Webhook delivery được ghi cùng transaction nên chỉ event đã áp dụng thành công mới được gửi đi, đúng một lần.
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := repository.EnqueueWebhookDeliveriesTx(ctx, tx, event.EventID, event.Type, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (c *InventoryConsumer) attemptProcessCreate(ctx context.Context, event model.InventoryEvent) error {
-	_, err := c.db.ExecContext(ctx, "INSERT INTO inventory (id, quantity) VALUES ($1, $2)", event.Id, event.Quantity)
+	err := c.applyOnce(ctx, event, func(tx *sql.Tx) error {
+		return c.repo.CreateInventoryTx(ctx, tx, stockChangeFromEvent(event))
+	})
 	if err != nil {
 		return fmt.Errorf("lỗi insert database: %v", err)
 	}
-	cacheKey := fmt.Sprintf("inventory:%s", event.Id)
-	if err := redisUtils.InvalidateCache(ctx, c.redisClient, cacheKey); err != nil {
-		log.Printf("Lỗi xoá cache cho item %s: %v", event.Id, err)
+	if err := cache.InvalidateItem(ctx, c.redisClient, event.Id); err != nil {
+		log.Printf("Lỗi invalidate cache cho item %s: %v", event.Id, err)
 	}
 	return nil
 }
 
 func (c *InventoryConsumer) attemptProcessUpdate(ctx context.Context, event model.InventoryEvent) error {
-	lockKey := fmt.Sprintf("lock:inventory:%s", event.Id)
-	lockExpiration := 10 * time.Second
+	err := c.withItemLock(ctx, event.Id, func(ctx context.Context, fence func(tx *sql.Tx) error) error {
+		return c.applyOnce(ctx, event, func(tx *sql.Tx) error {
+			if err := fence(tx); err != nil {
+				return err
+			}
+			_, err := c.repo.AdjustStockTx(ctx, tx, stockChangeFromEvent(event))
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("lỗi cập nhật database: %v", err)
+	}
 
-	locked, err := redisUtils.AcquireLock(ctx, c.redisClient, lockKey, lockExpiration)
-	if err != nil || !locked {
-		if err != nil {
-			log.Printf("Lỗi đặt khóa cho item %s: %v", event.Id, err)
-		}
-		return fmt.Errorf("không thể lấy lock cho item %s", event.Id)
+	if err := cache.InvalidateItem(ctx, c.redisClient, event.Id); err != nil {
+		log.Printf("Lỗi invalidate cache cho item %s: %v", event.Id, err)
 	}
-	defer func() {
-		if err := redisUtils.ReleaseLock(ctx, c.redisClient, lockKey); err != nil {
-			log.Printf("Lỗi giải phóng lock %s: %v", lockKey, err)
-		}
-	}()
 
-	_, err = c.db.ExecContext(ctx, "UPDATE inventory SET quantity = quantity + $1 WHERE id = $2", event.Quantity, event.Id)
+	return nil
+}
+
+func (c *InventoryConsumer) attemptProcessDelete(ctx context.Context, event model.InventoryEvent) error {
+	err := c.withItemLock(ctx, event.Id, func(ctx context.Context, fence func(tx *sql.Tx) error) error {
+		return c.applyOnce(ctx, event, func(tx *sql.Tx) error {
+			if err := fence(tx); err != nil {
+				return err
+			}
+			_, err := c.repo.DeleteInventoryTx(ctx, tx, stockChangeFromEvent(event))
+			return err
+		})
+	})
 	if err != nil {
-		return fmt.Errorf("lỗi cập nhật database: %v", err)
+		return fmt.Errorf("lỗi xóa database: %v", err)
 	}
 
-	cacheKey := fmt.Sprintf("inventory:%s", event.Id)
-	if err := redisUtils.InvalidateCache(ctx, c.redisClient, cacheKey); err != nil {
-		log.Printf("Lỗi xoá cache cho item %s: %v", event.Id, err)
+	if err := cache.InvalidateItem(ctx, c.redisClient, event.Id); err != nil {
+		log.Printf("Lỗi invalidate cache cho item %s: %v", event.Id, err)
 	}
 
 	return nil
 }
 
-func (c *InventoryConsumer) attemptProcessDelete(ctx context.Context, event model.InventoryEvent) error {
-	lockKey := fmt.Sprintf("lock:inventory:%s", event.Id)
+// withItemLock chạy fn khi giữ lock Redis của item. ctx truyền cho fn bị hủy nếu lock bị mất giữa chừng;
+// fence phải được gọi trong transaction ghi để Postgres từ chối ghi của owner có lock đã hết hạn.
+func (c *InventoryConsumer) withItemLock(ctx context.Context, itemID string, fn func(ctx context.Context, fence func(tx *sql.Tx) error) error) error {
+	lockKey := fmt.Sprintf("lock:inventory:%s", itemID)
 	lockExpiration := 10 * time.Second
 
-	locked, err := redisUtils.AcquireLock(ctx, c.redisClient, lockKey, lockExpiration)
-	if err != nil || !locked {
-		if err != nil {
-			log.Printf("Lỗi đặt khóa cho item %s: %v", event.Id, err)
+	lock, err := redisUtils.ObtainLock(ctx, c.redisClient, lockKey, lockExpiration)
+	if err != nil {
+		if !errors.Is(err, redisUtils.ErrLockNotObtained) {
+			log.Printf("Lỗi đặt khóa cho item %s: %v", itemID, err)
 		}
-		return fmt.Errorf("không thể lấy lock cho item %s", event.Id)
+		return fmt.Errorf("không thể lấy lock cho item %s", itemID)
 	}
 	defer func() {
-		if err := redisUtils.ReleaseLock(ctx, c.redisClient, lockKey); err != nil {
+		if err := lock.Release(ctx); err != nil {
 			log.Printf("Lỗi giải phóng lock %s: %v", lockKey, err)
 		}
 	}()
 
-	_, err = c.db.ExecContext(ctx, "DELETE FROM inventory WHERE id = $1", event.Id)
-	if err != nil {
-		return fmt.Errorf("lỗi xóa database: %v", err)
-	}
+	return fn(lock.Context(), func(tx *sql.Tx) error {
+		return repository.CheckFenceTx(lock.Context(), tx, lock.Key(), lock.Fence())
+	})
+}
 
-	cacheKey := fmt.Sprintf("inventory:%s", event.Id)
-	if err := redisUtils.InvalidateCache(ctx, c.redisClient, cacheKey); err != nil {
-		log.Printf("Lỗi xoá cache cho item %s: %v", event.Id, err)
-	}
+// processedEventsPurgeBatch là số dòng processed_events tối đa bị xoá trong một câu lệnh, để mỗi lần xoá
+// chỉ giữ lock trong thời gian ngắn trên bảng mà consumer ghi liên tục.
+const processedEventsPurgeBatch = 1000
 
-	return nil
+// StartProcessedEventsPurger định kỳ xoá các processed_events cũ hơn retention cho tới khi ctx bị hủy.
+func (c *InventoryConsumer) StartProcessedEventsPurger(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context bị hủy, dừng processed events purger")
+			return
+		case <-ticker.C:
+			var total int64
+			for {
+				n, err := repository.PurgeProcessedEvents(ctx, c.db, retention, processedEventsPurgeBatch)
+				if err != nil {
+					log.Printf("Lỗi dọn processed events: %v", err)
+					break
+				}
+				total += n
+				if n < processedEventsPurgeBatch {
+					break
+				}
+			}
+			if total > 0 {
+				log.Printf("Đã dọn %d processed events", total)
+			}
+		}
+	}
 }
 
-// StartDLQConsumer đọc các event từ DLQ và cố gắng reprocess chúng.
-// Nếu reprocess không thành công, bạn có thể lưu trữ hoặc gửi cảnh báo.
+// StartDLQConsumer đọc các event từ DLQ (tầng cuối của pipeline retry) và thử reprocess
+// đúng một lần. Event thất bại được lưu vào bảng dead_letters để ops xem và redrive qua REST API,
+// không được đưa lại vào DLQ để tránh vòng lặp retry vô hạn.
 func (c *InventoryConsumer) StartDLQConsumer(ctx context.Context, dlqReader *kafka.Reader) {
 	for {
-		msg, err := kafkaUtils.ReadMessageWrapper(ctx, dlqReader)
+		msg, err := kafkaUtils.FetchMessageWrapper(ctx, dlqReader)
 		if err != nil {
 			select {
 			case <-ctx.Done():
@@ -254,17 +372,22 @@ func (c *InventoryConsumer) StartDLQConsumer(ctx context.Context, dlqReader *kaf
 		var event model.InventoryEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
 			log.Printf("Lỗi giải mã DLQ event: %v", err)
-			// Ở đây có thể chuyển event sang một hệ thống lưu trữ lỗi khác để xử lý thủ công.
+			if c.storeDeadLetterUntilDone(ctx, msg, event, fmt.Errorf("lỗi giải mã message: %w", err)) == nil {
+				commitMessage(dlqReader, msg)
+			}
 			continue
 		}
 
-		log.Printf("Đang cố gắng reprocess event từ DLQ cho item %s", event.Id)
-		// Cố gắng reprocess event từ DLQ.
+		log.Printf("Đang cố gắng reprocess event từ DLQ cho item %s (sau %s lần thử)", event.Id, headerValue(msg, HeaderRetryAttempt))
 		if err := c.processEvent(ctx, event); err != nil {
 			log.Printf("Reprocess DLQ event thất bại cho item %s: %v", event.Id, err)
-			// Nếu reprocess không thành công, bạn có thể lưu trữ event này vào database hoặc hệ thống giám sát để xử lý sau.
+			if c.storeDeadLetterUntilDone(ctx, msg, event, err) != nil {
+				// Đang shutdown khi chưa lưu được: không commit để message được giao lại.
+				continue
+			}
 		} else {
 			log.Printf("Reprocess DLQ event thành công cho item %s", event.Id)
 		}
+		commitMessage(dlqReader, msg)
 	}
 }
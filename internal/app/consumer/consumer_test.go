@@ -0,0 +1,74 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestApplyOnce(t *testing.T) {
+	errApply := errors.New("apply failed")
+	tests := []struct {
+		name        string
+		eventID     string
+		fresh       bool
+		applyErr    error
+		wantApplied bool
+		wantErr     error
+	}{
+		{name: "first delivery is applied", eventID: "evt-1", fresh: true, wantApplied: true},
+		{name: "redelivery is skipped", eventID: "evt-1", fresh: false},
+		{name: "event without id is applied", wantApplied: true},
+		{name: "apply error rolls back the mark", eventID: "evt-1", fresh: true, applyErr: errApply, wantApplied: true, wantErr: errApply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tt.eventID != "" {
+				var affected int64
+				if tt.fresh {
+					affected = 1
+				}
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_events")).
+					WithArgs(tt.eventID, string(model.EventTypeUpdate), "sku-1").
+					WillReturnResult(sqlmock.NewResult(0, affected))
+			}
+			if tt.wantApplied && tt.applyErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+					WithArgs(tt.eventID, model.EventTypeUpdate, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := &InventoryConsumer{db: db}
+			event := model.InventoryEvent{EventID: tt.eventID, Type: model.EventTypeUpdate, Id: "sku-1"}
+			applied := false
+			err = c.applyOnce(context.Background(), event, func(*sql.Tx) error {
+				applied = true
+				return tt.applyErr
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyOnce() error = %v, want %v", err, tt.wantErr)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
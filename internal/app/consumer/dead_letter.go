@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"context"
+	"log"
+
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/model"
+)
+
+// storeDeadLetter lưu message không thể xử lý vào Postgres kèm payload gốc, chuỗi lỗi
+// và lịch sử retry lấy từ header (topic ban đầu nằm trong lịch sử retry).
+// Offset chỉ nên được commit khi hàm trả về nil.
+func (c *InventoryConsumer) storeDeadLetter(ctx context.Context, msg kafka.Message, event model.InventoryEvent, cause error) error {
+	history := retryHistory(msg)
+	errorChain := make([]string, 0, len(history)+1)
+	for _, attempt := range history {
+		errorChain = append(errorChain, attempt.Error)
+	}
+	errorChain = append(errorChain, cause.Error())
+
+	err := c.deadLetters.InsertDeadLetter(ctx, &model.DeadLetter{
+		EventID:         event.EventID,
+		EventType:       string(event.Type),
+		ItemID:          event.Id,
+		MessageKey:      string(msg.Key),
+		Payload:         string(msg.Value),
+		ErrorChain:      errorChain,
+		AttemptHistory:  history,
+		SourceTopic:     msg.Topic,
+		SourcePartition: msg.Partition,
+		SourceOffset:    msg.Offset,
+	})
+	if err != nil {
+		log.Printf("Lỗi lưu dead letter %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		return err
+	}
+	log.Printf("Đã lưu dead letter cho message %s/%d/%d: %v", msg.Topic, msg.Partition, msg.Offset, cause)
+	return nil
+}
+
+// storeDeadLetterUntilDone gọi storeDeadLetter tới khi lưu được hoặc ctx bị huỷ.
+func (c *InventoryConsumer) storeDeadLetterUntilDone(ctx context.Context, msg kafka.Message, event model.InventoryEvent, cause error) error {
+	return retryUntilDone(ctx, "lưu dead letter", func() error {
+		return c.storeDeadLetter(ctx, msg, event, cause)
+	})
+}
@@ -0,0 +1,56 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestStoreDeadLetter(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        []kafka.Header
+		wantErrorChain string
+	}{
+		{
+			name:           "decode failure without retry history",
+			wantErrorChain: `["boom"]`,
+		},
+		{
+			name: "error chain follows the retry history",
+			headers: []kafka.Header{{Key: HeaderRetryHistory, Value: []byte(
+				`[{"attempt":1,"topic":"inventory-updates","error":"first"},{"attempt":2,"topic":"inventory-updates-retry-5s","error":"second"}]`)}},
+			wantErrorChain: `["first","second","boom"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			msg := kafka.Message{Topic: "inventory-updates-dlq", Partition: 2, Offset: 42, Key: []byte("sku-1"), Value: []byte(`{"id":"sku-1"}`), Headers: tt.headers}
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO dead_letters")).
+				WithArgs("evt-1", "update", "sku-1", "sku-1", []byte(`{"id":"sku-1"}`),
+					tt.wantErrorChain, sqlmock.AnyArg(), "inventory-updates-dlq", 2, int64(42)).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			c := &InventoryConsumer{deadLetters: repository.NewDeadLetterRepository(db)}
+			event := model.InventoryEvent{EventID: "evt-1", Type: model.EventTypeUpdate, Id: "sku-1"}
+			if err := c.storeDeadLetter(context.Background(), msg, event, errors.New("boom")); err != nil {
+				t.Fatalf("storeDeadLetter() error = %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package consumer
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+	kafkaUtils "inventory-service.com/m/internal/utils/kafka"
+)
+
+// partitionOffsets theo dõi các offset đã fetch nhưng chưa commit của một partition.
+type partitionOffsets struct {
+	inFlight []int64                 // offset theo thứ tự fetch (tăng dần)
+	done     map[int64]kafka.Message // offset đã xử lý xong nhưng còn offset nhỏ hơn đang xử lý
+}
+
+// offsetTracker đảm bảo offset chỉ được commit khi event đó và mọi event trước nó
+// trên cùng partition đã được xử lý xong (hoặc đã đưa vào DLQ).
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+	commits    chan kafka.Message
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[int]*partitionOffsets),
+		commits:    make(chan kafka.Message, 1000),
+	}
+}
+
+// track đăng ký message vừa fetch, phải được gọi theo đúng thứ tự fetch.
+func (t *offsetTracker) track(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[msg.Partition]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]kafka.Message)}
+		t.partitions[msg.Partition] = p
+	}
+	p.inFlight = append(p.inFlight, msg.Offset)
+}
+
+// markDone đánh dấu message đã xử lý xong. Nếu có một dãy offset liên tục từ đầu partition
+// đã xong, message cuối của dãy được gửi cho committer.
+func (t *offsetTracker) markDone(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[msg.Partition]
+	if !ok {
+		return
+	}
+	p.done[msg.Offset] = msg
+
+	var (
+		commit kafka.Message
+		found  bool
+	)
+	for len(p.inFlight) > 0 {
+		head, ok := p.done[p.inFlight[0]]
+		if !ok {
+			break
+		}
+		delete(p.done, p.inFlight[0])
+		p.inFlight = p.inFlight[1:]
+		commit, found = head, true
+	}
+	if found {
+		// Gửi trong lúc giữ lock để committer nhận offset theo đúng thứ tự tăng dần.
+		t.commits <- commit
+	}
+}
+
+// runCommitter commit offset cho tới khi channel commits bị đóng.
+// Các offset đang chờ được gộp lại, chỉ commit offset lớn nhất của mỗi partition.
+func (t *offsetTracker) runCommitter(reader *kafka.Reader) {
+	for first := range t.commits {
+		latest := map[int]kafka.Message{first.Partition: first}
+	drain:
+		for {
+			select {
+			case msg, ok := <-t.commits:
+				if !ok {
+					break drain
+				}
+				latest[msg.Partition] = msg
+			default:
+				break drain
+			}
+		}
+
+		msgs := make([]kafka.Message, 0, len(latest))
+		for _, msg := range latest {
+			msgs = append(msgs, msg)
+		}
+		// Dùng context riêng để các offset cuối cùng vẫn được commit khi đang shutdown.
+		if err := kafkaUtils.CommitMessagesWrapper(context.Background(), reader, msgs...); err != nil {
+			log.Printf("Lỗi commit offset Kafka: %v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestOffsetTrackerMarkDone(t *testing.T) {
+	type step struct {
+		partition int
+		offset    int64
+		commit    int64 // offset được gửi cho committer sau bước này; -1 = không gửi
+	}
+	tests := []struct {
+		name    string
+		tracked map[int][]int64
+		steps   []step
+	}{
+		{
+			name:    "in order",
+			tracked: map[int][]int64{0: {10, 11, 12}},
+			steps:   []step{{0, 10, 10}, {0, 11, 11}, {0, 12, 12}},
+		},
+		{
+			name:    "out of order waits for the head",
+			tracked: map[int][]int64{0: {10, 11, 12}},
+			steps:   []step{{0, 12, -1}, {0, 11, -1}, {0, 10, 12}},
+		},
+		{
+			name:    "gap in the middle",
+			tracked: map[int][]int64{0: {10, 11, 12, 13}},
+			steps:   []step{{0, 10, 10}, {0, 12, -1}, {0, 13, -1}, {0, 11, 13}},
+		},
+		{
+			name:    "partitions are independent",
+			tracked: map[int][]int64{0: {10, 11}, 1: {20, 21}},
+			steps:   []step{{0, 11, -1}, {1, 20, 20}, {0, 10, 11}, {1, 21, 21}},
+		},
+		{
+			name:    "untracked partition is ignored",
+			tracked: map[int][]int64{0: {10}},
+			steps:   []step{{1, 10, -1}, {0, 10, 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for partition, offsets := range tt.tracked {
+				for _, offset := range offsets {
+					tracker.track(kafka.Message{Partition: partition, Offset: offset})
+				}
+			}
+			for i, s := range tt.steps {
+				tracker.markDone(kafka.Message{Partition: s.partition, Offset: s.offset})
+				select {
+				case msg := <-tracker.commits:
+					if s.commit < 0 {
+						t.Fatalf("step %d: unexpected commit of partition %d offset %d", i, msg.Partition, msg.Offset)
+					}
+					if msg.Partition != s.partition || msg.Offset != s.commit {
+						t.Fatalf("step %d: commit = partition %d offset %d, want partition %d offset %d",
+							i, msg.Partition, msg.Offset, s.partition, s.commit)
+					}
+				default:
+					if s.commit >= 0 {
+						t.Fatalf("step %d: no commit, want offset %d", i, s.commit)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/model"
+	kafkaUtils "inventory-service.com/m/internal/utils/kafka"
+)
+
+// Các header Kafka mang trạng thái retry của một event.
+const (
+	HeaderRetryAttempt       = "x-retry-attempt"         // số lần đã xử lý thất bại
+	HeaderRetryNextAttemptAt = "x-retry-next-attempt-at" // thời điểm được xử lý lại (unix milli)
+	HeaderRetryOriginalTopic = "x-retry-original-topic"  // topic ban đầu của event
+	HeaderRetryLastError     = "x-retry-last-error"      // lỗi của lần xử lý gần nhất
+	HeaderRetryHistory       = "x-retry-history"         // JSON []model.RetryAttempt của mọi lần thất bại
+)
+
+// RetryTier là một tầng retry: event được publish vào Topic và chỉ được xử lý lại sau Delay.
+type RetryTier struct {
+	Topic string
+	Delay time.Duration
+}
+
+// RetryPolicy cấu hình pipeline retry. Một event thất bại lần thứ n được chuyển sang Tiers[n-1];
+// khi đã vượt quá số lần retry cho phép của loại event thì chuyển vào DLQ.
+type RetryPolicy struct {
+	Tiers []RetryTier
+	// MaxRetries theo loại event; loại không có trong map được retry qua tất cả các tầng.
+	MaxRetries map[model.InventoryEventType]int
+}
+
+// maxRetries trả về số lần retry tối đa cho loại event, không vượt quá số tầng.
+func (p RetryPolicy) maxRetries(eventType model.InventoryEventType) int {
+	n, ok := p.MaxRetries[eventType]
+	if !ok || n > len(p.Tiers) {
+		return len(p.Tiers)
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// RetryTopicName đặt tên topic cho tầng retry, ví dụ "inventory-updates-retry-5s".
+func RetryTopicName(baseTopic string, delay time.Duration) string {
+	var suffix string
+	switch {
+	case delay%time.Hour == 0:
+		suffix = fmt.Sprintf("%dh", delay/time.Hour)
+	case delay%time.Minute == 0:
+		suffix = fmt.Sprintf("%dm", delay/time.Minute)
+	default:
+		suffix = fmt.Sprintf("%ds", delay/time.Second)
+	}
+	return baseTopic + "-retry-" + suffix
+}
+
+// errRetryScheduled cho biết event xử lý thất bại và đã được chuyển sang tầng retry kế tiếp.
+var errRetryScheduled = errors.New("event đã được chuyển sang topic retry")
+
+// handled cho biết lỗi trả về từ handleMessage có cho phép commit offset hay không.
+func handled(err error) bool {
+	return err == nil ||
+		errors.Is(err, errRetryScheduled) ||
+		errors.Is(err, errDeadLettered) ||
+		errors.Is(err, errUnknownEventType)
+}
+
+// handleMessage xử lý event đúng một lần. Nếu thất bại, event được chuyển sang tầng retry
+// kế tiếp theo chính sách của loại event, hoặc vào DLQ khi đã hết số lần retry. Việc chuyển tiếp
+// được thử lại tới khi thành công, nên lỗi không thuộc handled chỉ xảy ra khi ctx bị huỷ.
+func (c *InventoryConsumer) handleMessage(ctx context.Context, event model.InventoryEvent, msg kafka.Message) error {
+	err := c.processEvent(ctx, event)
+	if err == nil || errors.Is(err, errUnknownEventType) {
+		return err
+	}
+
+	attempt := headerInt(msg, HeaderRetryAttempt) + 1
+	if attempt > c.retryPolicy.maxRetries(event.Type) {
+		dlqErr := retryUntilDone(ctx, "gửi event vào DLQ", func() error {
+			return c.deadLetter(ctx, msg, attempt, err)
+		})
+		if dlqErr != nil {
+			return fmt.Errorf("xử lý event %s cho item %s thất bại sau %d lần: %v (không thể đưa vào DLQ: %v)", event.Type, event.Id, attempt, err, dlqErr)
+		}
+		return fmt.Errorf("%w: xử lý event %s cho item %s thất bại sau %d lần: %v", errDeadLettered, event.Type, event.Id, attempt, err)
+	}
+
+	tier := c.retryPolicy.Tiers[attempt-1]
+	retryMsg := kafka.Message{
+		Topic:   tier.Topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: retryHeaders(msg, attempt, time.Now().Add(tier.Delay), err),
+	}
+	pubErr := retryUntilDone(ctx, "chuyển event sang "+tier.Topic, func() error {
+		return kafkaUtils.WriteKafkaMessageWrapper(ctx, c.retryWriter, retryMsg)
+	})
+	if pubErr != nil {
+		return fmt.Errorf("không thể chuyển event %s cho item %s sang %s: %v (lỗi gốc: %v)", event.Type, event.Id, tier.Topic, pubErr, err)
+	}
+	log.Printf("Event %s cho item %s thất bại lần %d, retry qua %s sau %s: %v", event.Type, event.Id, attempt, tier.Topic, tier.Delay, err)
+	return fmt.Errorf("%w: %s", errRetryScheduled, tier.Topic)
+}
+
+// Backoff khi thử lại việc chuyển event sang topic retry, DLQ hoặc bảng dead_letters.
+const (
+	forwardRetryInitialBackoff = 200 * time.Millisecond
+	forwardRetryMaxBackoff     = 30 * time.Second
+)
+
+// retryUntilDone gọi fn tới khi thành công, với backoff tăng gấp đôi tới forwardRetryMaxBackoff.
+// Chỉ trả về lỗi khi ctx bị huỷ: khi đó offset không được commit và message được giao lại sau khi restart.
+// Nhờ vậy một lần chuyển tiếp thất bại không để lại khoảng trống chặn việc commit các offset phía sau.
+func retryUntilDone(ctx context.Context, what string, fn func() error) error {
+	backoff := forwardRetryInitialBackoff
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		log.Printf("Lỗi %s, thử lại sau %s: %v", what, backoff, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v (dừng thử lại: %w)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, forwardRetryMaxBackoff)
+	}
+}
+
+// deadLetter đưa message gốc vào DLQ kèm các header retry.
+func (c *InventoryConsumer) deadLetter(ctx context.Context, msg kafka.Message, attempt int, cause error) error {
+	dlqMsg := kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: retryHeaders(msg, attempt, time.Time{}, cause),
+	}
+	if err := kafkaUtils.WriteKafkaMessageWrapper(ctx, c.dlqWriter, dlqMsg); err != nil {
+		log.Printf("Lỗi gửi event vào DLQ: %v", err)
+		return err
+	}
+	log.Printf("Event được đưa vào DLQ: key %s", msg.Key)
+	return nil
+}
+
+// StartRetryConsumer đọc một tầng retry. Message chỉ được xử lý khi tới thời điểm trong header
+// x-retry-next-attempt-at; vì mọi message trong một tầng có cùng độ trễ nên chờ message đầu
+// không làm chậm các message phía sau hơn mức cần thiết.
+func (c *InventoryConsumer) StartRetryConsumer(ctx context.Context, reader *kafka.Reader, tier RetryTier) {
+	for {
+		msg, err := kafkaUtils.FetchMessageWrapper(ctx, reader)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				log.Printf("Context bị hủy, dừng retry consumer %s", tier.Topic)
+				return
+			default:
+				log.Printf("Lỗi đọc message retry %s: %v", tier.Topic, err)
+				continue
+			}
+		}
+
+		if wait := time.Until(time.UnixMilli(headerInt64(msg, HeaderRetryNextAttemptAt))); wait > 0 {
+			select {
+			case <-ctx.Done():
+				log.Printf("Context bị hủy, dừng retry consumer %s", tier.Topic)
+				return
+			case <-time.After(wait):
+			}
+		}
+
+		var event model.InventoryEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			log.Printf("Lỗi giải mã retry event: %v", err)
+			if c.storeDeadLetterUntilDone(ctx, msg, event, fmt.Errorf("lỗi giải mã message: %w", err)) == nil {
+				commitMessage(reader, msg)
+			}
+			continue
+		}
+
+		if err := c.handleMessage(ctx, event, msg); !handled(err) {
+			// Đang shutdown khi chưa chuyển tiếp được: không commit để message được giao lại sau khi restart.
+			log.Printf("Retry event cho item %s thất bại, offset %d/%d không được commit: %v", event.Id, msg.Partition, msg.Offset, err)
+			continue
+		} else if err != nil {
+			log.Printf("Retry event cho item %s: %v", event.Id, err)
+		} else {
+			log.Printf("Retry event %s cho item %s thành công", event.Type, event.Id)
+		}
+		commitMessage(reader, msg)
+	}
+}
+
+func commitMessage(reader *kafka.Reader, msg kafka.Message) {
+	if err := kafkaUtils.CommitMessagesWrapper(context.Background(), reader, msg); err != nil {
+		log.Printf("Lỗi commit offset %s: %v", msg.Topic, err)
+	}
+}
+
+// retryHeaders sao chép header của msg, cập nhật số lần thử, thời điểm thử lại và lỗi gần nhất.
+func retryHeaders(msg kafka.Message, attempt int, nextAttemptAt time.Time, cause error) []kafka.Header {
+	originalTopic := headerValue(msg, HeaderRetryOriginalTopic)
+	if originalTopic == "" {
+		originalTopic = msg.Topic
+	}
+
+	history := append(retryHistory(msg), model.RetryAttempt{
+		Attempt: attempt,
+		Topic:   msg.Topic,
+		Error:   cause.Error(),
+		At:      time.Now(),
+	})
+	historyBytes, _ := json.Marshal(history)
+
+	headers := make([]kafka.Header, 0, len(msg.Headers)+5)
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case HeaderRetryAttempt, HeaderRetryNextAttemptAt, HeaderRetryOriginalTopic, HeaderRetryLastError, HeaderRetryHistory:
+			continue
+		}
+		headers = append(headers, h)
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderRetryAttempt, Value: []byte(strconv.Itoa(attempt))},
+		kafka.Header{Key: HeaderRetryOriginalTopic, Value: []byte(originalTopic)},
+		kafka.Header{Key: HeaderRetryLastError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderRetryHistory, Value: historyBytes},
+	)
+	if !nextAttemptAt.IsZero() {
+		headers = append(headers, kafka.Header{Key: HeaderRetryNextAttemptAt, Value: []byte(strconv.FormatInt(nextAttemptAt.UnixMilli(), 10))})
+	}
+	return headers
+}
+
+// retryHistory đọc lịch sử các lần thất bại từ header của message.
+func retryHistory(msg kafka.Message) []model.RetryAttempt {
+	var history []model.RetryAttempt
+	if v := headerValue(msg, HeaderRetryHistory); v != "" {
+		if err := json.Unmarshal([]byte(v), &history); err != nil {
+			log.Printf("Lỗi giải mã header %s: %v", HeaderRetryHistory, err)
+		}
+	}
+	return history
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func headerInt(msg kafka.Message, key string) int {
+	n, _ := strconv.Atoi(headerValue(msg, key))
+	return n
+}
+
+func headerInt64(msg kafka.Message, key string) int64 {
+	n, _ := strconv.ParseInt(headerValue(msg, key), 10, 64)
+	return n
+}
@@ -0,0 +1,180 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestRetryPolicyMaxRetries(t *testing.T) {
+	policy := RetryPolicy{
+		Tiers: []RetryTier{{Topic: "t-retry-5s", Delay: 5 * time.Second}, {Topic: "t-retry-1m", Delay: time.Minute}},
+		MaxRetries: map[model.InventoryEventType]int{
+			model.EventTypeCreate: 1,
+			model.EventTypeUpdate: 5,
+			model.EventTypeDelete: -1,
+		},
+	}
+	tests := []struct {
+		eventType model.InventoryEventType
+		want      int
+	}{
+		{eventType: model.EventTypeCreate, want: 1},
+		{eventType: model.EventTypeUpdate, want: 2}, // không vượt quá số tầng
+		{eventType: model.EventTypeDelete, want: 0},
+		{eventType: "unknown", want: 2},
+	}
+	for _, tt := range tests {
+		if got := policy.maxRetries(tt.eventType); got != tt.want {
+			t.Errorf("maxRetries(%s) = %d, want %d", tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestRetryTopicName(t *testing.T) {
+	tests := []struct {
+		delay time.Duration
+		want  string
+	}{
+		{delay: 5 * time.Second, want: "inventory-updates-retry-5s"},
+		{delay: 90 * time.Second, want: "inventory-updates-retry-90s"},
+		{delay: 10 * time.Minute, want: "inventory-updates-retry-10m"},
+		{delay: 2 * time.Hour, want: "inventory-updates-retry-2h"},
+	}
+	for _, tt := range tests {
+		if got := RetryTopicName("inventory-updates", tt.delay); got != tt.want {
+			t.Errorf("RetryTopicName(%v) = %q, want %q", tt.delay, got, tt.want)
+		}
+	}
+}
+
+func TestRetryHeaders(t *testing.T) {
+	next := time.UnixMilli(1_700_000_000_000)
+	tests := []struct {
+		name          string
+		msg           kafka.Message
+		nextAttemptAt time.Time
+		want          map[string]string
+		wantHistory   []string // topic của từng lần thất bại trong x-retry-history
+	}{
+		{
+			name:          "first failure keeps the source topic",
+			msg:           kafka.Message{Topic: "inventory-updates", Headers: []kafka.Header{{Key: "trace", Value: []byte("abc")}}},
+			nextAttemptAt: next,
+			want: map[string]string{
+				"trace":                  "abc",
+				HeaderRetryAttempt:       "1",
+				HeaderRetryOriginalTopic: "inventory-updates",
+				HeaderRetryLastError:     "boom",
+				HeaderRetryNextAttemptAt: "1700000000000",
+			},
+			wantHistory: []string{"inventory-updates"},
+		},
+		{
+			name: "later failure replaces retry headers",
+			msg: kafka.Message{Topic: "inventory-updates-retry-5s", Headers: []kafka.Header{
+				{Key: HeaderRetryAttempt, Value: []byte("1")},
+				{Key: HeaderRetryOriginalTopic, Value: []byte("inventory-updates")},
+				{Key: HeaderRetryLastError, Value: []byte("old")},
+				{Key: HeaderRetryNextAttemptAt, Value: []byte("1")},
+				{Key: HeaderRetryHistory, Value: []byte(`[{"attempt":1,"topic":"inventory-updates","error":"old"}]`)},
+			}},
+			want: map[string]string{
+				HeaderRetryAttempt:       "1",
+				HeaderRetryOriginalTopic: "inventory-updates",
+				HeaderRetryLastError:     "boom",
+			},
+			wantHistory: []string{"inventory-updates", "inventory-updates-retry-5s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := retryHeaders(tt.msg, 1, tt.nextAttemptAt, errors.New("boom"))
+			got := make(map[string]string)
+			for _, h := range headers {
+				if _, dup := got[h.Key]; dup {
+					t.Fatalf("header %s bị lặp", h.Key)
+				}
+				got[h.Key] = string(h.Value)
+			}
+			history := retryHistory(kafka.Message{Headers: headers})
+			if len(history) != len(tt.wantHistory) {
+				t.Fatalf("history = %+v, want topics %v", history, tt.wantHistory)
+			}
+			for i, topic := range tt.wantHistory {
+				if history[i].Topic != topic {
+					t.Errorf("history[%d].Topic = %q, want %q", i, history[i].Topic, topic)
+				}
+			}
+			delete(got, HeaderRetryHistory)
+			if len(got) != len(tt.want) {
+				t.Fatalf("retryHeaders() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("header %s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestHandled(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: true},
+		{err: errRetryScheduled, want: true},
+		{err: errDeadLettered, want: true},
+		{err: errUnknownEventType, want: true},
+		{err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		if got := handled(tt.err); got != tt.want {
+			t.Errorf("handled(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryUntilDone(t *testing.T) {
+	errDown := errors.New("broker down")
+	tests := []struct {
+		name      string
+		failures  int
+		cancelled bool
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "succeeds first time", wantCalls: 1},
+		{name: "retries until success", failures: 1, wantCalls: 2},
+		{name: "stops on shutdown", failures: 100, cancelled: true, wantCalls: 1, wantErr: context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+			calls := 0
+			err := retryUntilDone(ctx, "test", func() error {
+				calls++
+				if calls <= tt.failures {
+					return errDown
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("retryUntilDone() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
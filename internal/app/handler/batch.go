@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// Chế độ của batch adjust.
+const (
+	batchModeAllOrNothing = "all_or_nothing"
+	batchModeBestEffort   = "best_effort"
+)
+
+type batchAdjustLine struct {
+	ItemID          string `json:"item_id"`
+	LocationID      string `json:"location_id"` // rỗng = location mặc định
+	Change          *int   `json:"change"`
+	Reason          string `json:"reason"`
+	ExpectedVersion int64  `json:"expected_version"`
+}
+
+type batchAdjustRequest struct {
+	Lines []batchAdjustLine `json:"lines"`
+	Mode  string            `json:"mode"` // all_or_nothing (mặc định) hoặc best_effort
+}
+
+// BatchAdjustItemsHandler áp dụng nhiều thay đổi tồn kho trong một transaction.
+// Ở chế độ all_or_nothing, một dòng lỗi làm hỏng cả batch và lỗi trả về có "line" là vị trí dòng đó;
+// ở chế độ best_effort, response chứa kết quả từng dòng. Hỗ trợ Idempotency-Key.
+func (h *Handler) BatchAdjustItemsHandler(c *gin.Context) {
+	var req batchAdjustRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = batchModeAllOrNothing
+	}
+	if req.Mode != batchModeAllOrNothing && req.Mode != batchModeBestEffort {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "mode phải là all_or_nothing hoặc best_effort"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	correlationID := correlationIDFromRequest(c)
+	changes := make([]repository.StockChange, len(req.Lines))
+	for i, line := range req.Lines {
+		if line.ItemID == "" || line.Change == nil {
+			body := errorBody("invalid_argument", "mỗi dòng cần item_id và change")
+			body["line"] = i
+			c.JSON(http.StatusBadRequest, body)
+			return
+		}
+		if line.ExpectedVersion < 0 {
+			body := errorBody("invalid_argument", "expected_version không được âm")
+			body["line"] = i
+			c.JSON(http.StatusBadRequest, body)
+			return
+		}
+		changes[i] = repository.StockChange{
+			ItemID:          line.ItemID,
+			LocationID:      line.LocationID,
+			Delta:           *line.Change,
+			Reason:          model.MovementReason(line.Reason),
+			Source:          model.MovementSourceHTTP,
+			CorrelationID:   correlationID,
+			ExpectedVersion: line.ExpectedVersion,
+		}
+	}
+
+	var results []service.BatchLineResult
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		var err error
+		results, err = h.inventorySvc.BatchAdjustTx(ctx, tx, changes, req.Mode == batchModeBestEffort)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		lines := make([]gin.H, len(results))
+		applied := 0
+		for i, r := range results {
+			line := gin.H{"line": r.Index, "item_id": r.Change.ItemID, "location_id": r.Change.LocationID}
+			if r.Err != nil {
+				_, errBody := errorResponse(r.Err)
+				for k, v := range errBody {
+					line[k] = v
+				}
+				line["success"] = false
+			} else {
+				line["success"] = true
+				line["quantity"] = r.Result.Total
+				line["location_quantity"] = r.Result.Balance
+				line["version"] = r.Result.Version
+				applied++
+			}
+			lines[i] = line
+		}
+		body := gin.H{"results": lines, "applied": applied, "failed": len(results) - applied}
+		return mutationResult{status: http.StatusOK, body: body}, nil
+	})
+	if !ok {
+		return
+	}
+	h.inventorySvc.InvalidateBatchCache(ctx, results)
+	c.Header("X-Correlation-ID", correlationID)
+	writeMutationResponse(c, result)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestBatchAdjustItemsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantLine    *int
+	}{
+		{name: "unknown mode", body: `{"mode":"partial","lines":[{"item_id":"sku-1","change":1}]}`, wantMessage: "mode phải là all_or_nothing hoặc best_effort"},
+		{name: "line without change", body: `{"lines":[{"item_id":"sku-1","change":1},{"item_id":"sku-2"}]}`, wantMessage: "mỗi dòng cần item_id và change", wantLine: ptrTo(1)},
+		{name: "negative expected_version", body: `{"lines":[{"item_id":"sku-1","change":1,"expected_version":-1}]}`, wantMessage: "expected_version không được âm", wantLine: ptrTo(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			router := gin.New()
+			router.POST("/items/batch-adjust", newTestHandler(t, db).BatchAdjustItemsHandler)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items/batch-adjust", strings.NewReader(tt.body)))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400 (body %s)", w.Code, w.Body)
+			}
+			var body struct {
+				Error string
+				Line  *int
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error != tt.wantMessage {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantMessage)
+			}
+			if (body.Line == nil) != (tt.wantLine == nil) || (body.Line != nil && *body.Line != *tt.wantLine) {
+				t.Errorf("line = %v, want %v", body.Line, tt.wantLine)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func ptrTo[T any](v T) *T { return &v }
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+type createCountSessionRequest struct {
+	LocationID string   `json:"location_id"` // rỗng = location mặc định
+	Note       string   `json:"note"`
+	ItemIDs    []string `json:"item_ids"`
+}
+
+type recordCountsRequest struct {
+	Counts []model.CountEntry `json:"counts"`
+}
+
+type approveCountSessionRequest struct {
+	ApprovedBy string `json:"approved_by"`
+}
+
+// CreateCountSessionHandler tạo phiên kiểm kê và chụp on-hand hiện tại làm số lượng kỳ vọng.
+// Hỗ trợ Idempotency-Key.
+func (h *Handler) CreateCountSessionHandler(c *gin.Context) {
+	var req createCountSessionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	in := service.CountSessionInput{LocationID: req.LocationID, Note: req.Note, ItemIDs: req.ItemIDs}
+	ctx := c.Request.Context()
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		session, err := h.countSvc.CreateCountSessionTx(ctx, tx, in)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		return mutationResult{status: http.StatusCreated, body: session}, nil
+	})
+	if !ok {
+		return
+	}
+	writeMutationResponse(c, result)
+}
+
+// ListCountSessionsHandler liệt kê phiên kiểm kê, mới nhất trước. Query: status, location, limit, cursor.
+func (h *Handler) ListCountSessionsHandler(c *gin.Context) {
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+	sessions, next, err := h.countSvc.ListCountSessions(c.Request.Context(), repository.CountSessionListOptions{
+		Status:     model.CountSessionStatus(c.Query("status")),
+		LocationID: c.Query("location"),
+		Cursor:     c.Query("cursor"),
+		PageSize:   limit,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sessions, "next_cursor": next})
+}
+
+func (h *Handler) GetCountSessionHandler(c *gin.Context) {
+	session, err := h.countSvc.GetCountSession(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, session)
+}
+
+// RecordCountsHandler ghi số lượng đếm được. Body JSON {"counts": [...]}, hoặc với Content-Type text/csv là
+// CSV hai cột item_id,counted_quantity (dòng tiêu đề tuỳ chọn). Hỗ trợ Idempotency-Key.
+func (h *Handler) RecordCountsHandler(c *gin.Context) {
+	var entries []model.CountEntry
+	if c.ContentType() == "text/csv" {
+		raw, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+			return
+		}
+		// Trả lại body để runMutation tính fingerprint của Idempotency-Key.
+		c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+		if entries, err = service.ParseCountsCSV(bytes.NewReader(raw)); err != nil {
+			writeError(c, err)
+			return
+		}
+	} else {
+		var req recordCountsRequest
+		if err := bindJSON(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+			return
+		}
+		entries = req.Counts
+	}
+	ctx := c.Request.Context()
+	h.countMutation(c, func(tx *sql.Tx) (*model.CountSession, error) {
+		return h.countSvc.RecordCountsTx(ctx, tx, c.Param("id"), entries)
+	})
+}
+
+// SubmitCountSessionHandler kết thúc việc đếm: post chênh lệch ngay, hoặc chuyển phiên sang pending_approval
+// nếu có chênh lệch vượt ngưỡng. Hỗ trợ Idempotency-Key.
+func (h *Handler) SubmitCountSessionHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.countMutation(c, func(tx *sql.Tx) (*model.CountSession, error) {
+		return h.countSvc.SubmitCountSessionTx(ctx, tx, c.Param("id"), model.MovementSourceHTTP)
+	})
+}
+
+// ApproveCountSessionHandler duyệt phiên pending_approval và post các chênh lệch. Hỗ trợ Idempotency-Key.
+func (h *Handler) ApproveCountSessionHandler(c *gin.Context) {
+	var req approveCountSessionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	ctx := c.Request.Context()
+	h.countMutation(c, func(tx *sql.Tx) (*model.CountSession, error) {
+		return h.countSvc.ApproveCountSessionTx(ctx, tx, c.Param("id"), req.ApprovedBy, model.MovementSourceHTTP)
+	})
+}
+
+// CancelCountSessionHandler huỷ phiên chưa post. Hỗ trợ Idempotency-Key.
+func (h *Handler) CancelCountSessionHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.countMutation(c, func(tx *sql.Tx) (*model.CountSession, error) {
+		return h.countSvc.CancelCountSessionTx(ctx, tx, c.Param("id"))
+	})
+}
+
+// countMutation chạy một bước của phiên kiểm kê qua runMutation, rồi invalidate cache các item của phiên.
+func (h *Handler) countMutation(c *gin.Context, fn func(tx *sql.Tx) (*model.CountSession, error)) {
+	var session *model.CountSession
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		s, err := fn(tx)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		session = s
+		return mutationResult{status: http.StatusOK, body: s}, nil
+	})
+	if !ok {
+		return
+	}
+	h.countSvc.InvalidateCache(c.Request.Context(), session)
+	writeMutationResponse(c, result)
+}
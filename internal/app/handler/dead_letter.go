@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+type redriveRequest struct {
+	// Payload đã chỉnh sửa (tuỳ chọn); bỏ trống để redrive payload hiện tại.
+	Payload json.RawMessage `json:"payload"`
+}
+
+type bulkRedriveRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// ListDeadLettersHandler liệt kê dead letter, mới nhất trước.
+// Query: status (pending, redriven, discarded), limit, cursor.
+func (h *Handler) ListDeadLettersHandler(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit không hợp lệ"})
+			return
+		}
+	}
+
+	items, next, err := h.deadLetterRepo.ListDeadLetters(c.Request.Context(), c.Query("status"), c.Query("cursor"), limit)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor không hợp lệ"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi truy vấn dead letter"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": items, "next_cursor": next})
+}
+
+// GetDeadLetterHandler trả về chi tiết một dead letter, gồm payload, chuỗi lỗi và lịch sử retry.
+func (h *Handler) GetDeadLetterHandler(c *gin.Context) {
+	id, ok := deadLetterID(c)
+	if !ok {
+		return
+	}
+	dl, err := h.deadLetterRepo.GetDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		deadLetterError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dl)
+}
+
+// RedriveDeadLetterHandler đưa dead letter trở lại topic chính, có thể kèm payload đã chỉnh sửa.
+func (h *Handler) RedriveDeadLetterHandler(c *gin.Context) {
+	id, ok := deadLetterID(c)
+	if !ok {
+		return
+	}
+
+	var req redriveRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "body không hợp lệ"})
+			return
+		}
+	}
+	var payload []byte
+	if len(req.Payload) > 0 {
+		var event model.InventoryEvent
+		if err := json.Unmarshal(req.Payload, &event); err != nil || event.Id == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payload không phải inventory event hợp lệ"})
+			return
+		}
+		payload = req.Payload
+	}
+
+	dl, err := h.deadLetterRepo.RedriveDeadLetter(c.Request.Context(), id, h.kafkaProducer.Topic, payload)
+	if err != nil {
+		deadLetterError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dl)
+}
+
+// BulkRedriveDeadLettersHandler redrive nhiều dead letter; các ID không còn pending bị bỏ qua.
+func (h *Handler) BulkRedriveDeadLettersHandler(c *gin.Context) {
+	var req bulkRedriveRequest
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids không hợp lệ"})
+		return
+	}
+
+	redriven, err := h.deadLetterRepo.RedriveDeadLetters(c.Request.Context(), req.IDs, h.kafkaProducer.Topic)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi redrive dead letter"})
+		return
+	}
+	ids := make([]int64, 0, len(redriven))
+	for _, dl := range redriven {
+		ids = append(ids, dl.ID)
+	}
+	c.JSON(http.StatusOK, gin.H{"redriven": ids})
+}
+
+// DiscardDeadLetterHandler đánh dấu dead letter là discarded.
+func (h *Handler) DiscardDeadLetterHandler(c *gin.Context) {
+	id, ok := deadLetterID(c)
+	if !ok {
+		return
+	}
+	dl, err := h.deadLetterRepo.DiscardDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		deadLetterError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dl)
+}
+
+func deadLetterID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id không hợp lệ"})
+		return 0, false
+	}
+	return id, true
+}
+
+func deadLetterError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrDeadLetterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Không tìm thấy dead letter"})
+	case errors.Is(err, repository.ErrDeadLetterNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": "dead letter đã được redrive hoặc discard"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi xử lý dead letter"})
+	}
+}
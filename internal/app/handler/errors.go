@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// errorBody là envelope lỗi chung của REST API: "error" là thông báo cho người đọc,
+// "code" là mã ổn định để client xử lý.
+func errorBody(code, message string) gin.H {
+	return gin.H{"error": message, "code": code}
+}
+
+// writeError ghi lỗi dưới dạng envelope lỗi với HTTP status tương ứng.
+func writeError(c *gin.Context, err error) {
+	status, body := errorResponse(err)
+	var mismatch *repository.VersionMismatchError
+	if errors.As(err, &mismatch) {
+		c.Header("ETag", formatETag(mismatch.Current))
+	}
+	if status == http.StatusInternalServerError {
+		log.Printf("Lỗi xử lý request %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	c.JSON(status, body)
+}
+
+// errorResponse ánh xạ lỗi repository/service sang HTTP status và envelope lỗi.
+func errorResponse(err error) (int, gin.H) {
+	var (
+		mismatch     *repository.VersionMismatchError
+		insufficient *repository.InsufficientStockError
+		lineErr      *service.BatchLineError
+	)
+	if errors.As(err, &lineErr) {
+		status, body := errorResponse(lineErr.Err)
+		body["line"] = lineErr.Index
+		return status, body
+	}
+	switch {
+	case errors.As(err, &insufficient):
+		body := errorBody("insufficient_stock", "Không đủ tồn kho")
+		body["item_id"] = insufficient.ItemID
+		body["location_id"] = insufficient.LocationID
+		body["available"] = insufficient.Available
+		body["requested"] = insufficient.Requested
+		return http.StatusConflict, body
+	case errors.Is(err, repository.ErrInsufficientStock):
+		return http.StatusConflict, errorBody("insufficient_stock", "Không đủ tồn kho")
+	case errors.As(err, &mismatch):
+		body := errorBody("version_mismatch", "Version không khớp")
+		body["current_version"] = mismatch.Current
+		return http.StatusPreconditionFailed, body
+	case errors.Is(err, repository.ErrInventoryNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy item")
+	case errors.Is(err, repository.ErrLocationNotFound):
+		return http.StatusBadRequest, errorBody("location_not_found", "location không tồn tại")
+	case errors.Is(err, repository.ErrInventoryAlreadyExists):
+		return http.StatusConflict, errorBody("already_exists", "item đã tồn tại")
+	case errors.Is(err, repository.ErrInvalidPageToken):
+		return http.StatusBadRequest, errorBody("invalid_cursor", "cursor không hợp lệ")
+	case errors.Is(err, repository.ErrInvalidSort):
+		return http.StatusBadRequest, errorBody("invalid_sort", "sort không hợp lệ")
+	case errors.Is(err, service.ErrInvalidBatch), errors.Is(err, service.ErrInvalidWatch):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, service.ErrInvalidReorderPoint):
+		return http.StatusBadRequest, errorBody("invalid_argument", "reorder_point và safety_stock không được âm; safety_stock không được lớn hơn reorder_point")
+	case errors.Is(err, service.ErrInvalidLot):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, repository.ErrLotConflict):
+		return http.StatusConflict, errorBody("lot_conflict", "lô đã tồn tại với ngày sản xuất/hạn dùng khác")
+	case errors.Is(err, service.ErrInvalidSerial):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, repository.ErrSerializedItem):
+		return http.StatusConflict, errorBody("serialized_item", "item serialized chỉ thay đổi tồn kho qua các thao tác serial")
+	case errors.Is(err, repository.ErrNotSerialized):
+		return http.StatusConflict, errorBody("not_serialized", "item không theo dõi theo serial")
+	case errors.Is(err, repository.ErrSerialNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy serial")
+	case errors.Is(err, repository.ErrSerialAlreadyExists):
+		return http.StatusConflict, errorBody("already_exists", "serial đã tồn tại")
+	case errors.Is(err, repository.ErrSerialState):
+		return http.StatusConflict, errorBody("invalid_serial_state", err.Error())
+	case errors.Is(err, service.ErrInvalidTransition):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, service.ErrInvalidTransfer):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, repository.ErrTransferNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy lệnh chuyển kho")
+	case errors.Is(err, repository.ErrTransferState):
+		return http.StatusConflict, errorBody("invalid_transfer_state", err.Error())
+	case errors.Is(err, service.ErrInvalidCount):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, repository.ErrCountSessionNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy phiên kiểm kê")
+	case errors.Is(err, repository.ErrCountSessionState):
+		return http.StatusConflict, errorBody("invalid_count_session_state", err.Error())
+	case errors.Is(err, repository.ErrWebhookSubscriptionNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy webhook subscription")
+	case errors.Is(err, repository.ErrWebhookDeliveryNotFound):
+		return http.StatusNotFound, errorBody("not_found", "Không tìm thấy webhook delivery")
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return http.StatusBadRequest, errorBody("invalid_argument", err.Error())
+	case errors.Is(err, service.ErrInvalidResumeToken):
+		return http.StatusBadRequest, errorBody("invalid_resume_token", "resume token/Last-Event-ID không hợp lệ")
+	default:
+		return http.StatusInternalServerError, errorBody("internal", "Lỗi hệ thống")
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// formatETag trả về ETag (strong) cho version của item, ví dụ "3".
+func formatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseETag đọc version từ một ETag, chấp nhận cả dạng weak W/"3".
+func parseETag(tag string) (int64, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	tag = strings.Trim(tag, `"`)
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// expectedVersion lấy expected version từ header If-Match hoặc query expected_version.
+// Trả về 0 nếu client không yêu cầu kiểm tra (không gửi hoặc gửi If-Match: *).
+func expectedVersion(c *gin.Context) (int64, bool) {
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && ifMatch != "*" {
+		return parseETag(ifMatch)
+	}
+	if v := c.Query("expected_version"); v != "" {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || version <= 0 {
+			return 0, false
+		}
+		return version, true
+	}
+	return 0, true
+}
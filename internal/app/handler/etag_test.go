@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		tag         string
+		wantVersion int64
+		wantOK      bool
+	}{
+		{tag: `"3"`, wantVersion: 3, wantOK: true},
+		{tag: `W/"3"`, wantVersion: 3, wantOK: true},
+		{tag: ` "12" `, wantVersion: 12, wantOK: true},
+		{tag: `"0"`},
+		{tag: `"abc"`},
+		{tag: ``},
+	}
+	for _, tt := range tests {
+		version, ok := parseETag(tt.tag)
+		if version != tt.wantVersion || ok != tt.wantOK {
+			t.Errorf("parseETag(%q) = %d, %v, want %d, %v", tt.tag, version, ok, tt.wantVersion, tt.wantOK)
+		}
+	}
+}
+
+func TestExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		query       string
+		wantVersion int64
+		wantOK      bool
+	}{
+		{name: "no precondition", wantOK: true},
+		{name: "if-match wildcard", ifMatch: "*", wantOK: true},
+		{name: "if-match", ifMatch: `"4"`, wantVersion: 4, wantOK: true},
+		{name: "if-match wins over query", ifMatch: `"4"`, query: "expected_version=9", wantVersion: 4, wantOK: true},
+		{name: "query", query: "expected_version=9", wantVersion: 9, wantOK: true},
+		{name: "invalid if-match", ifMatch: `"x"`},
+		{name: "negative query", query: "expected_version=-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/update-inventory?"+tt.query, nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+			version, ok := expectedVersion(c)
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("expectedVersion() = %d, %v, want %d, %v", version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInventoryETagPreconditions(t *testing.T) {
+	itemColumns := []string{"id", "quantity", "version", "oversell_policy", "backorder_limit", "updated_at",
+		"reorder_point", "safety_stock", "stock_alert_state", "lot_allocation_policy", "serialized", "location_id", "location_quantity", "location_oversell_policy", "location_backorder_limit",
+		"in_transit", "quarantined", "damaged", "reservations", "reserved_serials"}
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     map[string]string
+		expect     func(mock sqlmock.Sqlmock)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name:   "get returns the current etag",
+			method: http.MethodGet,
+			target: "/inventory/sku-1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM inventory i")).
+					WillReturnRows(sqlmock.NewRows(itemColumns).AddRow("sku-1", 5, 3, "deny", 0, time.Now(), nil, nil, "ok", "fefo", false, "default", 5, "deny", 0, 0, 0, 0, 0, 0))
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"3"`,
+		},
+		{
+			name:   "get with matching if-none-match is not modified",
+			method: http.MethodGet,
+			target: "/inventory/sku-1",
+			header: map[string]string{"If-None-Match": `"3"`},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM inventory i")).
+					WillReturnRows(sqlmock.NewRows(itemColumns).AddRow("sku-1", 5, 3, "deny", 0, time.Now(), nil, nil, "ok", "fefo", false, "default", 5, "deny", 0, 0, 0, 0, 0, 0))
+			},
+			wantStatus: http.StatusNotModified,
+			wantETag:   `"3"`,
+		},
+		{
+			name:   "update with stale if-match fails the precondition",
+			method: http.MethodPut,
+			target: "/update-inventory?id=sku-1&change=1",
+			header: map[string]string{"If-Match": `"2"`},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+					WithArgs(1, "sku-1", int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM inventory WHERE id = $1")).
+					WithArgs("sku-1").
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				mock.ExpectRollback()
+			},
+			wantStatus: http.StatusPreconditionFailed,
+			wantETag:   `"3"`,
+		},
+		{
+			name:       "update with invalid if-match is rejected",
+			method:     http.MethodPut,
+			target:     "/update-inventory?id=sku-1&change=1",
+			header:     map[string]string{"If-Match": `"abc"`},
+			expect:     func(sqlmock.Sqlmock) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.expect(mock)
+
+			h := newTestHandler(t, db)
+			router := gin.New()
+			router.GET("/inventory/:id", h.GetInventoryHandler)
+			router.PUT("/update-inventory", h.UpdateInventoryHandler)
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// mutationResult là response thành công của một thay đổi. version > 0 được trả về trong header ETag
+// sau khi commit và được lưu cùng Idempotency-Key để replay.
+type mutationResult struct {
+	status  int
+	body    interface{} // nil = không có body
+	version int64
+}
+
+// mutationFunc áp dụng thay đổi trong tx và trả về response thành công.
+type mutationFunc func(tx *sql.Tx) (mutationResult, error)
+
+// idempotencyScope là phạm vi của Idempotency-Key: method và route, ví dụ "http:PATCH /items/:id/adjust".
+func idempotencyScope(c *gin.Context) string {
+	return "http:" + c.Request.Method + " " + c.FullPath()
+}
+
+// requestFingerprint hash method, path, query (đã sắp xếp), If-Match và body của request,
+// để phát hiện cùng Idempotency-Key được gửi lại với payload khác. Body được giữ nguyên cho handler đọc;
+// nếu handler đã đọc body bằng bindJSON thì dùng bản đã lưu trong context.
+func requestFingerprint(c *gin.Context) string {
+	var body []byte
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		body, _ = cached.([]byte)
+	} else if c.Request.Body != nil {
+		body, _ = io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	payload := c.Request.Method + " " + c.Request.URL.Path + "?" + c.Request.URL.Query().Encode() +
+		"\nIf-Match: " + c.GetHeader("If-Match") + "\n\n" + string(body)
+	return service.Fingerprint([]byte(payload))
+}
+
+// bindJSON đọc body JSON vào req và giữ lại body trong context để requestFingerprint vẫn tính được
+// fingerprint. Handler dùng runMutation phải đọc body bằng bindJSON thay cho ShouldBindJSON.
+func bindJSON(c *gin.Context, req interface{}) error {
+	return c.ShouldBindBodyWith(req, binding.JSON)
+}
+
+// runMutation chạy apply trong một transaction và commit. Nếu request có Idempotency-Key, request lặp lại
+// được trả response đã lưu mà không chạy apply, còn response thành công được lưu trong cùng transaction.
+// Trả về false nếu response đã được ghi (lỗi hoặc replay); ngược lại caller ghi kết quả bằng
+// writeMutationResponse sau khi làm các việc sau commit như invalidate cache.
+func (h *Handler) runMutation(c *gin.Context, apply mutationFunc) (mutationResult, bool) {
+	ctx := c.Request.Context()
+	scope := idempotencyScope(c)
+	key := c.GetHeader("Idempotency-Key")
+	fingerprint := ""
+	if key != "" {
+		fingerprint = requestFingerprint(c)
+		if h.replayIdempotent(c, scope, key, fingerprint) {
+			return mutationResult{}, false
+		}
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorBody("internal", "Lỗi khởi tạo transaction"))
+		return mutationResult{}, false
+	}
+	defer tx.Rollback()
+
+	result, err := apply(tx)
+	if err != nil {
+		writeError(c, err)
+		return mutationResult{}, false
+	}
+
+	if key != "" {
+		stored := []byte{}
+		if result.body != nil {
+			if stored, err = json.Marshal(result.body); err != nil {
+				c.JSON(http.StatusInternalServerError, errorBody("internal", "Lỗi mã hóa response"))
+				return mutationResult{}, false
+			}
+		}
+		err = h.idempotency.SaveTx(ctx, tx, &model.IdempotencyRecord{
+			Scope:          scope,
+			Key:            key,
+			Fingerprint:    fingerprint,
+			ResponseStatus: result.status,
+			ResponseBody:   stored,
+			ResponseETag:   result.etag(),
+		})
+		if errors.Is(err, repository.ErrIdempotencyKeyConflict) {
+			tx.Rollback()
+			// Request song song cùng key đã commit trước: trả lại response của request đó.
+			if !h.replayIdempotent(c, scope, key, fingerprint) {
+				c.JSON(http.StatusConflict, errorBody("idempotency_in_progress", "Request với Idempotency-Key này đang được xử lý"))
+			}
+			return mutationResult{}, false
+		}
+		if err != nil {
+			log.Printf("Lỗi lưu idempotency key %s: %v", key, err)
+			c.JSON(http.StatusInternalServerError, errorBody("internal", "Lỗi lưu idempotency key"))
+			return mutationResult{}, false
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, errorBody("internal", "Lỗi commit transaction"))
+		return mutationResult{}, false
+	}
+	return result, true
+}
+
+// etag trả về ETag của version sau thay đổi, rỗng nếu response không gắn với version.
+func (r mutationResult) etag() string {
+	if r.version <= 0 {
+		return ""
+	}
+	return formatETag(r.version)
+}
+
+// writeMutationResponse ghi response thành công của runMutation, kèm ETag nếu có.
+func writeMutationResponse(c *gin.Context, result mutationResult) {
+	if etag := result.etag(); etag != "" {
+		c.Header("ETag", etag)
+	}
+	if result.body == nil {
+		c.Status(result.status)
+		return
+	}
+	c.JSON(result.status, result.body)
+}
+
+// replayIdempotent trả lại response đã lưu cho key nếu có. Trả về true nếu response đã được ghi,
+// kể cả khi key bị dùng lại với payload khác (422) hoặc lỗi truy vấn.
+func (h *Handler) replayIdempotent(c *gin.Context, scope, key, fingerprint string) bool {
+	rec, err := h.idempotency.Lookup(c.Request.Context(), scope, key, fingerprint)
+	if errors.Is(err, service.ErrIdempotencyKeyReused) {
+		c.JSON(http.StatusUnprocessableEntity, errorBody("idempotency_key_reused", "Idempotency-Key đã được dùng cho request khác"))
+		return true
+	}
+	if err != nil {
+		log.Printf("Lỗi đọc idempotency key %s: %v", key, err)
+		c.JSON(http.StatusInternalServerError, errorBody("internal", "Lỗi truy vấn database"))
+		return true
+	}
+	if rec == nil {
+		return false
+	}
+	c.Header("Idempotent-Replayed", "true")
+	if rec.ResponseETag != "" {
+		c.Header("ETag", rec.ResponseETag)
+	}
+	if len(rec.ResponseBody) == 0 {
+		c.Status(rec.ResponseStatus)
+		return true
+	}
+	c.Data(rec.ResponseStatus, "application/json; charset=utf-8", rec.ResponseBody)
+	return true
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/cache"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+const (
+	idempotencyTestTarget = "/update-inventory?id=sku-1&change=1"
+	idempotencyTestScope  = "http:PUT /update-inventory"
+)
+
+var idempotencyColumns = []string{"scope", "idempotency_key", "fingerprint", "response_status", "response_body", "response_etag", "created_at", "expires_at"}
+
+// testFingerprint tính fingerprint của request PUT idempotencyTestTarget.
+func testFingerprint() string {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPut, idempotencyTestTarget, nil)
+	return requestFingerprint(c)
+}
+
+func expectIdempotencyLookup(mock sqlmock.Sqlmock, fingerprint string) {
+	rows := sqlmock.NewRows(idempotencyColumns)
+	if fingerprint != "" {
+		rows.AddRow(idempotencyTestScope, "key-1", fingerprint, 200, []byte(`{"version":4}`), `"4"`, time.Now(), time.Now().Add(time.Hour))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM idempotency_keys")).
+		WithArgs(idempotencyTestScope, "key-1").
+		WillReturnRows(rows)
+}
+
+// expectStockUpdate mong đợi thay đổi tồn kho +1 cho sku-1 và event trong outbox, tới version 4.
+func expectStockUpdate(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+		WithArgs(1, "sku-1", int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}).
+			AddRow(6, 4, "deny", 0, "fefo", false))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(6))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// newTestHandler tạo Handler trên db giả, cache tồn kho dùng Redis trong bộ nhớ.
+func newTestHandler(t *testing.T, db *sql.DB) *Handler {
+	t.Helper()
+	redisClient := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+	idempotency := service.NewIdempotencyService(repository.NewIdempotencyRepository(db), time.Hour)
+	inventoryCache := cache.NewInventoryCache(redisClient, cache.InventoryCacheConfig{TTL: time.Minute, NegativeTTL: time.Second, LockTTL: time.Second})
+	inventory := service.NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), inventoryCache, "inventory-events")
+	return NewHandler(db, redisClient, &kafka.Writer{Topic: "inventory-events"}, inventory, idempotency, service.NewInventoryWatcher(redisClient, inventory, 0),
+		service.NewWebhookService(repository.NewWebhookRepository(db)),
+		service.NewTransferService(repository.NewTransferRepository(db), inventory, "inventory-transfers"),
+		service.NewCountService(repository.NewCountRepository(db), inventory, 10), StreamConfig{})
+}
+
+func TestUpdateInventoryIdempotency(t *testing.T) {
+	fingerprint := testFingerprint()
+	tests := []struct {
+		name         string
+		expect       func(mock sqlmock.Sqlmock)
+		wantStatus   int
+		wantETag     string
+		wantReplayed bool
+	}{
+		{
+			name:         "replay returns the stored response and etag",
+			expect:       func(mock sqlmock.Sqlmock) { expectIdempotencyLookup(mock, fingerprint) },
+			wantStatus:   http.StatusOK,
+			wantETag:     `"4"`,
+			wantReplayed: true,
+		},
+		{
+			name:       "key reused with another payload",
+			expect:     func(mock sqlmock.Sqlmock) { expectIdempotencyLookup(mock, "other") },
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "first request stores the response with its etag",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectIdempotencyLookup(mock, "")
+				expectStockUpdate(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+					WithArgs(idempotencyTestScope, "key-1", fingerprint, 200, sqlmock.AnyArg(), `"4"`, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+		{
+			name: "concurrent request committed first is replayed",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectIdempotencyLookup(mock, "")
+				expectStockUpdate(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				expectIdempotencyLookup(mock, fingerprint)
+			},
+			wantStatus:   http.StatusOK,
+			wantETag:     `"4"`,
+			wantReplayed: true,
+		},
+		{
+			name: "concurrent request still running",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectIdempotencyLookup(mock, "")
+				expectStockUpdate(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				expectIdempotencyLookup(mock, "")
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "save failure is an internal error",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectIdempotencyLookup(mock, "")
+				expectStockUpdate(mock)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+					WillReturnError(errors.New("connection reset"))
+				mock.ExpectRollback()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.expect(mock)
+
+			h := newTestHandler(t, db)
+			router := gin.New()
+			router.PUT("/update-inventory", h.UpdateInventoryHandler)
+
+			req := httptest.NewRequest(http.MethodPut, idempotencyTestTarget, nil)
+			req.Header.Set("Idempotency-Key", "key-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if got := w.Header().Get("Idempotent-Replayed") == "true"; got != tt.wantReplayed {
+				t.Errorf("replayed = %v, want %v", got, tt.wantReplayed)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -2,88 +2,120 @@ package handler
 
 import (
 	"database/sql"
-	"encoding/json"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/segmentio/kafka-go"
 	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+	idUtils "inventory-service.com/m/internal/utils/id"
 )
 
 type Handler struct {
-	db            *sql.DB
-	redisClient   *redis.Client
-	kafkaProducer *kafka.Writer
+	db             *sql.DB
+	redisClient    *redis.Client
+	kafkaProducer  *kafka.Writer
+	locationRepo   *repository.LocationRepository
+	movementRepo   *repository.MovementRepository
+	deadLetterRepo *repository.DeadLetterRepository
+	inventorySvc   *service.InventoryService
+	idempotency    *service.IdempotencyService
+	watcher        *service.InventoryWatcher
+	webhookSvc     *service.WebhookService
+	transferSvc    *service.TransferService
+	countSvc       *service.CountService
+	stream         StreamConfig
 }
 
-func NewHandler(db *sql.DB, redisClient *redis.Client, kafkaProducer *kafka.Writer) *Handler {
+func NewHandler(db *sql.DB, redisClient *redis.Client, kafkaProducer *kafka.Writer, inventorySvc *service.InventoryService, idempotency *service.IdempotencyService, watcher *service.InventoryWatcher, webhookSvc *service.WebhookService, transferSvc *service.TransferService, countSvc *service.CountService, stream StreamConfig) *Handler {
 	return &Handler{
-		db:            db,
-		redisClient:   redisClient,
-		kafkaProducer: kafkaProducer,
+		db:             db,
+		redisClient:    redisClient,
+		kafkaProducer:  kafkaProducer,
+		locationRepo:   repository.NewLocationRepository(db),
+		movementRepo:   repository.NewMovementRepository(db),
+		deadLetterRepo: repository.NewDeadLetterRepository(db),
+		inventorySvc:   inventorySvc,
+		idempotency:    idempotency,
+		watcher:        watcher,
+		webhookSvc:     webhookSvc,
+		transferSvc:    transferSvc,
+		countSvc:       countSvc,
+		stream:         stream,
 	}
 }
 
+// correlationIDFromRequest lấy correlation ID từ header X-Correlation-ID, sinh mới nếu client không gửi.
+func correlationIDFromRequest(c *gin.Context) string {
+	if id := c.GetHeader("X-Correlation-ID"); id != "" {
+		return id
+	}
+	return idUtils.NewID()
+}
+
 func (h *Handler) UpdateInventoryHandler(c *gin.Context) {
 	ctx := c.Request.Context() // dùng context từ request
 	idStr := c.Query("id")
 	changeStr := c.Query("change")
+	locationID := c.Query("location") // tuỳ chọn, rỗng = location mặc định
+	reason := c.Query("reason")       // tuỳ chọn, mặc định "adjustment"
+	correlationID := correlationIDFromRequest(c)
 
 	change, err := strconv.Atoi(changeStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "change không hợp lệ"})
-		return
-	}
-
-	// Có thể cân nhắc sử dụng transaction nếu có nhiều thao tác liên quan
-	tx, err := h.db.BeginTx(ctx, nil)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi khởi tạo transaction"})
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "change không hợp lệ"))
 		return
 	}
-	// Cập nhật PostgreSQL trong transaction
-	_, err = tx.ExecContext(ctx, "UPDATE inventory SET quantity = quantity + $1 WHERE id = $2", change, idStr)
-	if err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi cập nhật database"})
+	version, ok := expectedVersion(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "If-Match/expected_version không hợp lệ"))
 		return
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi commit transaction"})
+	// Cập nhật PostgreSQL và ghi sự kiện vào outbox trong cùng transaction; relay sẽ publish lên Kafka.
+	// Request retry với cùng Idempotency-Key được trả lại response cũ, không áp dụng thay đổi lần nữa.
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		stock, err := h.inventorySvc.UpdateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:          idStr,
+			LocationID:      locationID,
+			Delta:           change,
+			Reason:          model.MovementReason(reason),
+			Source:          model.MovementSourceHTTP,
+			CorrelationID:   correlationID,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return mutationResult{}, err
+		}
+		body := gin.H{"message": "Inventory updated", "correlation_id": correlationID, "version": stock.Version}
+		return mutationResult{status: http.StatusOK, body: body, version: stock.Version}, nil
+	})
+	if !ok {
 		return
 	}
 
 	// Invalidate cache Redis
-	redisKey := "inventory:" + idStr
-	if err := h.redisClient.Del(ctx, redisKey).Err(); err != nil {
-		// Log lỗi, không nhất thiết trả về cho client
-		// log.Printf("Lỗi xóa key Redis %s: %v", redisKey, err)
-	}
+	h.inventorySvc.InvalidateCache(ctx, idStr)
 
-	// Gửi sự kiện cập nhật qua Kafka
-	event := model.InventoryUpdateEvent{
-		Id:       idStr,
-		Change:   change,
-		DateTime: time.Now(),
-	}
-	eventBytes, err := json.Marshal(event)
+	writeMutationResponse(c, result)
+}
+
+// GetInventoryHandler trả về tổng số lượng và số lượng theo từng location của một item.
+// Hỗ trợ ETag/If-None-Match: trả về 304 nếu item chưa thay đổi.
+func (h *Handler) GetInventoryHandler(c *gin.Context) {
+	item, err := h.inventorySvc.GetInventory(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi mã hóa sự kiện"})
+		writeError(c, err)
 		return
 	}
-	err = h.kafkaProducer.WriteMessages(ctx, kafka.Message{
-		Key:   []byte(idStr),
-		Value: eventBytes,
-	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi gửi sự kiện Kafka"})
+	etag := formatETag(item.Version)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
 		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Inventory updated"})
+	c.JSON(http.StatusOK, item)
 }
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+type createItemRequest struct {
+	ID         string `json:"id"`
+	LocationID string `json:"location_id"` // rỗng = location mặc định
+	Quantity   int    `json:"quantity"`
+	Reason     string `json:"reason"`
+	// Serialized tạo item theo dõi theo serial: quantity phải là 0, hàng được nhập qua /serials/receive.
+	Serialized bool `json:"serialized"`
+}
+
+type adjustItemRequest struct {
+	LocationID      string `json:"location_id"` // rỗng = location mặc định
+	Change          *int   `json:"change"`
+	Reason          string `json:"reason"`
+	ExpectedVersion int64  `json:"expected_version"` // thay cho If-Match, 0 = không kiểm tra
+	// Lot ghi số lượng nhập (change > 0) vào một lô. Thay đổi giảm tồn kho tự lấy từ các lô
+	// theo lot allocation policy của item.
+	Lot *lotRequest `json:"lot"`
+}
+
+// CreateItemHandler tạo item mới với số lượng ban đầu tại một location.
+func (h *Handler) CreateItemHandler(c *gin.Context) {
+	var req createItemRequest
+	if err := bindJSON(c, &req); err != nil || req.ID == "" || req.Quantity < 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "id bắt buộc và quantity không được âm"))
+		return
+	}
+	ctx := c.Request.Context()
+	correlationID := correlationIDFromRequest(c)
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		err := h.inventorySvc.CreateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:        req.ID,
+			LocationID:    req.LocationID,
+			Delta:         req.Quantity,
+			Reason:        model.MovementReason(req.Reason),
+			Source:        model.MovementSourceHTTP,
+			CorrelationID: correlationID,
+			Serialized:    req.Serialized,
+		})
+		if err != nil {
+			return mutationResult{}, err
+		}
+		item, err := h.inventorySvc.GetInventoryTx(ctx, tx, req.ID)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		return mutationResult{status: http.StatusCreated, body: item, version: item.Version}, nil
+	})
+	if !ok {
+		return
+	}
+	h.inventorySvc.InvalidateCache(ctx, req.ID)
+	c.Header("Location", "/items/"+req.ID)
+	c.Header("X-Correlation-ID", correlationID)
+	writeMutationResponse(c, result)
+}
+
+// ListItemsHandler liệt kê item, phân trang theo cursor.
+// Query: limit, cursor (lấy từ next_cursor), sort (id, quantity, updated_at; tiền tố "-" để giảm dần),
+// min_quantity, max_quantity, updated_after, updated_before (RFC 3339), location.
+func (h *Handler) ListItemsHandler(c *gin.Context) {
+	opts := repository.InventoryListOptions{
+		Cursor: c.Query("cursor"),
+		Filter: repository.InventoryFilter{LocationID: c.Query("location")},
+	}
+	if sort := c.Query("sort"); sort != "" {
+		opts.Desc = strings.HasPrefix(sort, "-")
+		opts.SortBy = strings.TrimPrefix(sort, "-")
+	}
+
+	var ok bool
+	if opts.PageSize, ok = intQuery(c, "limit"); !ok {
+		return
+	}
+	if opts.Filter.MinQuantity, ok = optionalIntQuery(c, "min_quantity"); !ok {
+		return
+	}
+	if opts.Filter.MaxQuantity, ok = optionalIntQuery(c, "max_quantity"); !ok {
+		return
+	}
+	if opts.Filter.UpdatedAfter, ok = optionalTimeQuery(c, "updated_after"); !ok {
+		return
+	}
+	if opts.Filter.UpdatedBefore, ok = optionalTimeQuery(c, "updated_before"); !ok {
+		return
+	}
+
+	items, next, err := h.inventorySvc.ListInventories(c.Request.Context(), opts)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	if items == nil {
+		items = []*model.InventoryItem{}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": items, "next_cursor": next})
+}
+
+// AdjustItemHandler cộng change vào tồn kho của item tại một location và trả về item sau thay đổi.
+// Hỗ trợ If-Match (hoặc expected_version trong body) và Idempotency-Key.
+func (h *Handler) AdjustItemHandler(c *gin.Context) {
+	var req adjustItemRequest
+	if err := bindJSON(c, &req); err != nil || req.Change == nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "change bắt buộc"))
+		return
+	}
+	if req.ExpectedVersion < 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "expected_version không được âm"))
+		return
+	}
+	version, ok := expectedVersion(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "If-Match không hợp lệ"))
+		return
+	}
+	if version == 0 {
+		version = req.ExpectedVersion
+	}
+	lot, err := req.Lot.receipt()
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	ctx := c.Request.Context()
+	itemID := c.Param("id")
+	correlationID := correlationIDFromRequest(c)
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		_, err := h.inventorySvc.UpdateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:          itemID,
+			LocationID:      req.LocationID,
+			Delta:           *req.Change,
+			Reason:          model.MovementReason(req.Reason),
+			Source:          model.MovementSourceHTTP,
+			CorrelationID:   correlationID,
+			ExpectedVersion: version,
+			Lot:             lot,
+		})
+		if err != nil {
+			return mutationResult{}, err
+		}
+		item, err := h.inventorySvc.GetInventoryTx(ctx, tx, itemID)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		return mutationResult{status: http.StatusOK, body: item, version: item.Version}, nil
+	})
+	if !ok {
+		return
+	}
+	h.inventorySvc.InvalidateCache(ctx, itemID)
+	c.Header("X-Correlation-ID", correlationID)
+	writeMutationResponse(c, result)
+}
+
+// DeleteItemHandler xoá item, hoặc chỉ tồn kho tại location nếu có query location.
+// Hỗ trợ If-Match và Idempotency-Key.
+func (h *Handler) DeleteItemHandler(c *gin.Context) {
+	version, ok := expectedVersion(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "If-Match/expected_version không hợp lệ"))
+		return
+	}
+	ctx := c.Request.Context()
+	itemID := c.Param("id")
+	correlationID := correlationIDFromRequest(c)
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		err := h.inventorySvc.DeleteInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:          itemID,
+			LocationID:      c.Query("location"),
+			Source:          model.MovementSourceHTTP,
+			CorrelationID:   correlationID,
+			ExpectedVersion: version,
+		})
+		return mutationResult{status: http.StatusNoContent}, err
+	})
+	if !ok {
+		return
+	}
+	h.inventorySvc.InvalidateCache(ctx, itemID)
+	c.Header("X-Correlation-ID", correlationID)
+	writeMutationResponse(c, result)
+}
+
+// intQuery đọc query số nguyên không âm, 0 nếu không có. Ghi 400 và trả về false nếu sai định dạng.
+func intQuery(c *gin.Context, name string) (int, bool) {
+	v, ok := optionalIntQuery(c, name)
+	if !ok || v == nil {
+		return 0, ok
+	}
+	if *v < 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", name+" không hợp lệ"))
+		return 0, false
+	}
+	return *v, true
+}
+
+// optionalIntQuery đọc query số nguyên, nil nếu không có. Ghi 400 và trả về false nếu sai định dạng.
+func optionalIntQuery(c *gin.Context, name string) (*int, bool) {
+	s := c.Query(name)
+	if s == "" {
+		return nil, true
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", name+" không hợp lệ"))
+		return nil, false
+	}
+	return &v, true
+}
+
+// optionalTimeQuery đọc query thời gian RFC 3339, nil nếu không có. Ghi 400 và trả về false nếu sai định dạng.
+func optionalTimeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	s := c.Query(name)
+	if s == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", name+" phải theo định dạng RFC 3339"))
+		return nil, false
+	}
+	t = t.UTC()
+	return &t, true
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+// expectAdjustItem mong đợi +1 cho sku-1 trong transaction và item được đọc lại ở version 4.
+func expectAdjustItem(mock sqlmock.Sqlmock) {
+	expectStockUpdate(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory i")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "quantity", "version", "oversell_policy", "backorder_limit", "updated_at",
+			"reorder_point", "safety_stock", "stock_alert_state", "lot_allocation_policy", "serialized", "location_id", "location_quantity", "location_oversell_policy", "location_backorder_limit",
+			"in_transit", "quarantined", "damaged", "reservations", "reserved_serials"}).
+			AddRow("sku-1", 6, 4, "deny", 0, time.Now(), nil, nil, "ok", "fefo", false, "default", 6, "deny", 0, 0, 0, 0, 0, 0))
+}
+
+// expectAdjustLookup mong đợi tra Idempotency-Key "key-1" đã lưu cho PATCH /items/sku-1/adjust với storedBody.
+func expectAdjustLookup(mock sqlmock.Sqlmock, storedBody string) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPatch, "/items/sku-1/adjust", strings.NewReader(storedBody))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM idempotency_keys")).
+		WithArgs("http:PATCH /items/:id/adjust", "key-1").
+		WillReturnRows(sqlmock.NewRows(idempotencyColumns).
+			AddRow("http:PATCH /items/:id/adjust", "key-1", requestFingerprint(c), 200, []byte(`{"id":"sku-1"}`), `"4"`, time.Now(), time.Now().Add(time.Hour)))
+}
+
+func TestAdjustItemHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		header         map[string]string
+		expect         func(mock sqlmock.Sqlmock)
+		wantStatus     int
+		wantETag       string
+		wantErrCode    string
+		wantErrMessage string
+	}{
+		{
+			name:           "change is required",
+			body:           `{"location_id":"default"}`,
+			expect:         func(sqlmock.Sqlmock) {},
+			wantStatus:     http.StatusBadRequest,
+			wantErrCode:    "invalid_argument",
+			wantErrMessage: "change bắt buộc",
+		},
+		{
+			name:           "negative expected_version",
+			body:           `{"change":1,"expected_version":-1}`,
+			expect:         func(sqlmock.Sqlmock) {},
+			wantStatus:     http.StatusBadRequest,
+			wantErrCode:    "invalid_argument",
+			wantErrMessage: "expected_version không được âm",
+		},
+		{
+			name:        "invalid if-match",
+			body:        `{"change":1}`,
+			header:      map[string]string{"If-Match": `"x"`},
+			expect:      func(sqlmock.Sqlmock) {},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: "invalid_argument",
+		},
+		{
+			name: "etag is sent after commit",
+			body: `{"change":1}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectAdjustItem(mock)
+				mock.ExpectCommit()
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+		{
+			name: "failed commit sends no etag",
+			body: `{"change":1}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectAdjustItem(mock)
+				mock.ExpectCommit().WillReturnError(errors.New("connection reset"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErrCode: "internal",
+		},
+		{
+			name:   "replay returns the stored etag",
+			body:   `{"change":1}`,
+			header: map[string]string{"Idempotency-Key": "key-1"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectAdjustLookup(mock, `{"change":1}`)
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"4"`,
+		},
+		{
+			name:   "same key with another body",
+			body:   `{"change":2}`,
+			header: map[string]string{"Idempotency-Key": "key-1"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectAdjustLookup(mock, `{"change":1}`)
+			},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantErrCode: "idempotency_key_reused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.expect(mock)
+
+			router := gin.New()
+			router.PATCH("/items/:id/adjust", newTestHandler(t, db).AdjustItemHandler)
+			req := httptest.NewRequest(http.MethodPatch, "/items/sku-1/adjust", strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if tt.wantErrCode != "" {
+				var body struct{ Error, Code string }
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatal(err)
+				}
+				if body.Code != tt.wantErrCode || (tt.wantErrMessage != "" && body.Error != tt.wantErrMessage) {
+					t.Errorf("error = %+v, want code %q message %q", body, tt.wantErrCode, tt.wantErrMessage)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/repository"
+)
+
+type locationRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateLocationHandler tạo mới một kho/location.
+func (h *Handler) CreateLocationHandler(c *gin.Context) {
+	var req locationRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id không hợp lệ"})
+		return
+	}
+
+	loc, err := h.locationRepo.CreateLocation(c.Request.Context(), req.ID, req.Name)
+	if errors.Is(err, repository.ErrLocationAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "location đã tồn tại"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi tạo location"})
+		return
+	}
+	c.JSON(http.StatusCreated, loc)
+}
+
+// UpdateLocationHandler đổi tên một location.
+func (h *Handler) UpdateLocationHandler(c *gin.Context) {
+	var req locationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body không hợp lệ"})
+		return
+	}
+
+	loc, err := h.locationRepo.UpdateLocation(c.Request.Context(), c.Param("id"), req.Name)
+	if errors.Is(err, repository.ErrLocationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Không tìm thấy location"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi cập nhật location"})
+		return
+	}
+	c.JSON(http.StatusOK, loc)
+}
+
+// ListLocationsHandler trả về tất cả location.
+func (h *Handler) ListLocationsHandler(c *gin.Context) {
+	locs, err := h.locationRepo.ListLocations(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi truy vấn location"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": locs})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// defaultExpiringWithinDays là khoảng ngày mặc định của /lots/expiring khi không có within_days.
+const defaultExpiringWithinDays = 30
+
+// lotRequest là lô của một lần nhập hàng; ngày có dạng YYYY-MM-DD.
+type lotRequest struct {
+	LotNumber      string `json:"lot_number"`
+	ManufacturedAt string `json:"manufactured_at"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+func (r *lotRequest) receipt() (*repository.LotReceipt, error) {
+	if r == nil {
+		return nil, nil
+	}
+	manufacturedAt, err := service.ParseLotDate(r.ManufacturedAt)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := service.ParseLotDate(r.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.LotReceipt{LotNumber: r.LotNumber, ManufacturedAt: manufacturedAt, ExpiresAt: expiresAt}, nil
+}
+
+type lotAllocationPolicyRequest struct {
+	Policy model.LotAllocationPolicy `json:"policy"` // fefo, fifo
+}
+
+// ListItemLotsHandler liệt kê các lô của item theo thứ tự sẽ được lấy hàng.
+// Query: location, include_empty (true để gồm cả lô đã hết hàng).
+func (h *Handler) ListItemLotsHandler(c *gin.Context) {
+	lots, err := h.inventorySvc.ListLots(c.Request.Context(), c.Param("id"), c.Query("location"), c.Query("include_empty") == "true")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": lots})
+}
+
+// SetLotAllocationPolicyHandler đặt thứ tự lấy hàng từ các lô của item: fefo (mặc định) hoặc fifo.
+func (h *Handler) SetLotAllocationPolicyHandler(c *gin.Context) {
+	var req lotAllocationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+
+	item, err := h.inventorySvc.SetLotAllocationPolicy(c.Request.Context(), c.Param("id"), req.Policy)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Header("ETag", formatETag(item.Version))
+	c.JSON(http.StatusOK, item)
+}
+
+// ListExpiringLotsHandler liệt kê các lô còn hàng hết hạn trong within_days ngày tới (mặc định 30),
+// kể cả lô đã hết hạn, hạn dùng sớm nhất trước. Query: within_days, location, limit, cursor.
+func (h *Handler) ListExpiringLotsHandler(c *gin.Context) {
+	withinDays := defaultExpiringWithinDays
+	if days, ok := optionalIntQuery(c, "within_days"); !ok {
+		return
+	} else if days != nil {
+		withinDays = *days
+	}
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+
+	lots, next, err := h.inventorySvc.ListExpiringLots(c.Request.Context(), withinDays, c.Query("location"), c.Query("cursor"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": lots, "next_cursor": next})
+}
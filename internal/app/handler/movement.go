@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/repository"
+)
+
+// ListMovementsHandler trả về lịch sử thay đổi tồn kho của item, phân trang theo cursor.
+// Query: location (tuỳ chọn), limit (mặc định 50), cursor (lấy từ next_cursor của trang trước).
+func (h *Handler) ListMovementsHandler(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit không hợp lệ"})
+			return
+		}
+	}
+
+	movements, nextCursor, err := h.movementRepo.ListMovements(c.Request.Context(), c.Param("id"), c.Query("location"), c.Query("cursor"), limit)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor không hợp lệ"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Lỗi truy vấn movement"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": movements, "next_cursor": nextCursor})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+type oversellPolicyRequest struct {
+	LocationID     string               `json:"location_id"` // rỗng = mặc định của item và mọi location
+	Policy         model.OversellPolicy `json:"policy"`
+	BackorderLimit int                  `json:"backorder_limit"`
+}
+
+// SetOversellPolicyHandler đặt chính sách oversell (deny, backorder, unlimited) cho item hoặc một location của item.
+func (h *Handler) SetOversellPolicyHandler(c *gin.Context) {
+	var req oversellPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || !req.Policy.Valid() || req.BackorderLimit < 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "policy phải là deny, backorder hoặc unlimited; backorder_limit không được âm"))
+		return
+	}
+
+	item, err := h.inventorySvc.SetOversellPolicy(c.Request.Context(), c.Param("id"), req.LocationID, req.Policy, req.BackorderLimit)
+	switch {
+	case errors.Is(err, repository.ErrInsufficientStock):
+		c.JSON(http.StatusConflict, errorBody("insufficient_stock", "Tồn kho hiện tại thấp hơn mức policy mới cho phép"))
+		return
+	case err != nil:
+		writeError(c, err)
+		return
+	}
+
+	c.Header("ETag", formatETag(item.Version))
+	c.JSON(http.StatusOK, item)
+}
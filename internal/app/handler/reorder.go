@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type reorderPointRequest struct {
+	ReorderPoint *int `json:"reorder_point"` // null = tắt cảnh báo tồn kho thấp
+	SafetyStock  *int `json:"safety_stock"`
+}
+
+// SetReorderPointHandler đặt reorder point và safety stock của item. Khi tồn kho chạm các ngưỡng này,
+// cảnh báo low_stock/out_of_stock/back_in_stock được publish lên topic cảnh báo.
+func (h *Handler) SetReorderPointHandler(c *gin.Context) {
+	var req reorderPointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+
+	item, err := h.inventorySvc.SetReorderPoint(c.Request.Context(), c.Param("id"), req.ReorderPoint, req.SafetyStock)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("ETag", formatETag(item.Version))
+	c.JSON(http.StatusOK, item)
+}
@@ -6,15 +6,90 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/service"
 )
 
 // SetupRouter đăng ký các route cho ứng dụng
-func SetupRouter(db *sql.DB, redisClient *redis.Client, kafkaProducer *kafka.Writer) *gin.Engine {
+func SetupRouter(db *sql.DB, redisClient *redis.Client, kafkaProducer *kafka.Writer, inventorySvc *service.InventoryService, idempotency *service.IdempotencyService, watcher *service.InventoryWatcher, webhookSvc *service.WebhookService, transferSvc *service.TransferService, countSvc *service.CountService, stream StreamConfig) *gin.Engine {
 	router := gin.Default()
 
-	handler := NewHandler(db, redisClient, kafkaProducer)
+	handler := NewHandler(db, redisClient, kafkaProducer, inventorySvc, idempotency, watcher, webhookSvc, transferSvc, countSvc, stream)
 	// Đăng ký route cho việc cập nhật inventory với method của struct Handler
 	router.PUT("/update-inventory", handler.UpdateInventoryHandler)
+	router.GET("/inventory/:id", handler.GetInventoryHandler)
+	router.GET("/inventory/:id/movements", handler.ListMovementsHandler)
+	router.PUT("/inventory/:id/oversell-policy", handler.SetOversellPolicyHandler)
+	router.PUT("/inventory/:id/reorder-point", handler.SetReorderPointHandler)
+
+	// REST API theo resource cho item, tương đương các method của gRPC service.
+	items := router.Group("/items")
+	items.POST("", handler.CreateItemHandler)
+	items.GET("", handler.ListItemsHandler)
+	items.POST("/batch-adjust", handler.BatchAdjustItemsHandler)
+	// Stream thay đổi tồn kho cho dashboard trên trình duyệt, cùng nguồn với gRPC WatchInventory.
+	items.GET("/stream", handler.StreamItemsHandler)
+	items.GET("/ws", handler.StreamItemsWebSocketHandler)
+	items.GET("/:id", handler.GetInventoryHandler)
+	items.PATCH("/:id/adjust", handler.AdjustItemHandler)
+	items.DELETE("/:id", handler.DeleteItemHandler)
+	items.GET("/:id/movements", handler.ListMovementsHandler)
+	items.PUT("/:id/oversell-policy", handler.SetOversellPolicyHandler)
+	items.PUT("/:id/reorder-point", handler.SetReorderPointHandler)
+	items.GET("/:id/lots", handler.ListItemLotsHandler)
+	items.PUT("/:id/lot-allocation-policy", handler.SetLotAllocationPolicyHandler)
+	items.GET("/:id/serials", handler.ListSerialsHandler)
+	items.POST("/:id/serials/receive", handler.SerialOperationHandler(service.SerialReceive))
+	items.POST("/:id/serials/reserve", handler.SerialOperationHandler(service.SerialReserve))
+	items.POST("/:id/serials/ship", handler.SerialOperationHandler(service.SerialShip))
+	items.POST("/:id/serials/return", handler.SerialOperationHandler(service.SerialReturn))
+	items.GET("/:id/serials/:serial/trace", handler.TraceSerialHandler)
+	items.POST("/:id/transitions", handler.TransitionStockHandler)
+
+	// Lô sắp hết hạn trên mọi item.
+	router.GET("/lots/expiring", handler.ListExpiringLotsHandler)
+
+	// Lệnh chuyển kho giữa các location: draft → shipped → partially_received → received, hoặc cancelled.
+	transfers := router.Group("/transfers")
+	transfers.POST("", handler.CreateTransferHandler)
+	transfers.GET("", handler.ListTransfersHandler)
+	transfers.GET("/:id", handler.GetTransferHandler)
+	transfers.POST("/:id/ship", handler.ShipTransferHandler)
+	transfers.POST("/:id/receive", handler.ReceiveTransferHandler)
+	transfers.POST("/:id/cancel", handler.CancelTransferHandler)
+
+	// Kiểm kê: open → posted, hoặc open → pending_approval → posted khi chênh lệch vượt ngưỡng; hoặc cancelled.
+	counts := router.Group("/count-sessions")
+	counts.POST("", handler.CreateCountSessionHandler)
+	counts.GET("", handler.ListCountSessionsHandler)
+	counts.GET("/:id", handler.GetCountSessionHandler)
+	counts.POST("/:id/counts", handler.RecordCountsHandler)
+	counts.POST("/:id/submit", handler.SubmitCountSessionHandler)
+	counts.POST("/:id/approve", handler.ApproveCountSessionHandler)
+	counts.POST("/:id/cancel", handler.CancelCountSessionHandler)
+
+	// Quản lý kho/location
+	router.POST("/locations", handler.CreateLocationHandler)
+	router.GET("/locations", handler.ListLocationsHandler)
+	router.PUT("/locations/:id", handler.UpdateLocationHandler)
+
+	// Quản trị dead letter: xem, sửa payload và redrive, hoặc discard.
+	admin := router.Group("/admin/dlq")
+	admin.GET("", handler.ListDeadLettersHandler)
+	admin.POST("/bulk-redrive", handler.BulkRedriveDeadLettersHandler)
+	admin.GET("/:id", handler.GetDeadLetterHandler)
+	admin.POST("/:id/redrive", handler.RedriveDeadLetterHandler)
+	admin.POST("/:id/discard", handler.DiscardDeadLetterHandler)
+
+	// Webhook cho đối tác không dùng Kafka: quản lý subscription, xem nhật ký gửi và gửi lại.
+	webhooks := router.Group("/webhooks")
+	webhooks.POST("", handler.CreateWebhookHandler)
+	webhooks.GET("", handler.ListWebhooksHandler)
+	webhooks.GET("/:id", handler.GetWebhookHandler)
+	webhooks.PUT("/:id", handler.UpdateWebhookHandler)
+	webhooks.DELETE("/:id", handler.DeleteWebhookHandler)
+	webhooks.GET("/:id/deliveries", handler.ListWebhookDeliveriesHandler)
+	webhooks.GET("/:id/deliveries/:deliveryId", handler.GetWebhookDeliveryHandler)
+	webhooks.POST("/:id/deliveries/:deliveryId/redeliver", handler.RedeliverWebhookHandler)
 
 	// Các route khác có thể đăng ký thêm tại đây...
 
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/service"
+)
+
+type serialOperationRequest struct {
+	SerialNumbers []string `json:"serial_numbers"`
+	LocationID    string   `json:"location_id"` // receive: nơi nhập; return: nơi nhận lại, rỗng = location cũ
+	OrderID       string   `json:"order_id"`    // reserve/ship
+}
+
+// SerialOperationHandler trả về handler thực hiện op (receive, reserve, ship, return) trên các serial của item.
+// Các serial được xử lý trong một transaction: hoặc tất cả thành công, hoặc không serial nào thay đổi.
+// Hỗ trợ Idempotency-Key.
+func (h *Handler) SerialOperationHandler(op service.SerialOperation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req serialOperationRequest
+		if err := bindJSON(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+			return
+		}
+		ctx := c.Request.Context()
+		itemID := c.Param("id")
+		correlationID := correlationIDFromRequest(c)
+
+		result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+			serials, err := h.inventorySvc.ApplySerialsTx(ctx, tx, op, service.SerialRequest{
+				ItemID:        itemID,
+				LocationID:    req.LocationID,
+				SerialNumbers: req.SerialNumbers,
+				OrderID:       req.OrderID,
+				Source:        model.MovementSourceHTTP,
+				CorrelationID: correlationID,
+			})
+			if err != nil {
+				return mutationResult{}, err
+			}
+			return mutationResult{status: http.StatusOK, body: gin.H{"data": serials}}, nil
+		})
+		if !ok {
+			return
+		}
+		h.inventorySvc.InvalidateCache(ctx, itemID)
+		c.Header("X-Correlation-ID", correlationID)
+		writeMutationResponse(c, result)
+	}
+}
+
+// ListSerialsHandler liệt kê serial của item theo serial_number.
+// Query: status (available, reserved, shipped), location, limit, cursor.
+func (h *Handler) ListSerialsHandler(c *gin.Context) {
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+	serials, next, err := h.inventorySvc.ListSerials(c.Request.Context(), c.Param("id"),
+		model.SerialStatus(c.Query("status")), c.Query("location"), c.Query("cursor"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": serials, "next_cursor": next})
+}
+
+// TraceSerialHandler trả về trạng thái hiện tại và toàn bộ vòng đời của serial từ sổ cái movement.
+func (h *Handler) TraceSerialHandler(c *gin.Context) {
+	trace, err := h.inventorySvc.TraceSerial(c.Request.Context(), c.Param("id"), c.Param("serial"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, trace)
+}
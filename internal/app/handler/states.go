@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+type transitionStockRequest struct {
+	LocationID      string `json:"location_id"`
+	From            string `json:"from"` // on_hand, quarantined, damaged
+	To              string `json:"to"`
+	Quantity        int    `json:"quantity"`
+	Reason          string `json:"reason"` // tuỳ chọn, mặc định "state_transition"
+	ExpectedVersion int64  `json:"expected_version"`
+}
+
+// TransitionStockHandler chuyển hàng của item giữa các trạng thái tại một location (ví dụ quarantined → on_hand
+// sau khi QA đạt) và trả về item sau thay đổi. Hỗ trợ If-Match (hoặc expected_version trong body) và Idempotency-Key.
+func (h *Handler) TransitionStockHandler(c *gin.Context) {
+	var req transitionStockRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	if req.ExpectedVersion < 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "expected_version không được âm"))
+		return
+	}
+	version, ok := expectedVersion(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "If-Match không hợp lệ"))
+		return
+	}
+	if version == 0 {
+		version = req.ExpectedVersion
+	}
+	ctx := c.Request.Context()
+	itemID := c.Param("id")
+	correlationID := correlationIDFromRequest(c)
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		_, err := h.inventorySvc.TransitionStockTx(ctx, tx, repository.StockTransition{
+			ItemID:          itemID,
+			LocationID:      req.LocationID,
+			From:            model.StockState(req.From),
+			To:              model.StockState(req.To),
+			Quantity:        req.Quantity,
+			Reason:          model.MovementReason(req.Reason),
+			Source:          model.MovementSourceHTTP,
+			CorrelationID:   correlationID,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return mutationResult{}, err
+		}
+		item, err := h.inventorySvc.GetInventoryTx(ctx, tx, itemID)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		return mutationResult{status: http.StatusOK, body: item, version: item.Version}, nil
+	})
+	if !ok {
+		return
+	}
+	h.inventorySvc.InvalidateCache(ctx, itemID)
+	c.Header("X-Correlation-ID", correlationID)
+	writeMutationResponse(c, result)
+}
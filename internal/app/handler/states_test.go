@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestTransitionStockHandlerValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		ifMatch     string
+		wantMessage string
+	}{
+		{name: "malformed body", body: `{"quantity":"two"}`, wantMessage: "body không hợp lệ"},
+		{name: "negative expected_version", body: `{"from":"quarantined","to":"on_hand","quantity":1,"expected_version":-1}`, wantMessage: "expected_version không được âm"},
+		{name: "malformed if-match", body: `{"from":"quarantined","to":"on_hand","quantity":1}`, ifMatch: `"abc"`, wantMessage: "If-Match không hợp lệ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			router := gin.New()
+			router.POST("/items/:id/transitions", newTestHandler(t, db).TransitionStockHandler)
+			req := httptest.NewRequest(http.MethodPost, "/items/sku-1/transitions", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400 (body %s)", w.Code, w.Body)
+			}
+			var body struct{ Error string }
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error != tt.wantMessage {
+				t.Errorf("error = %q, want %q", body.Error, tt.wantMessage)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/service"
+)
+
+// StreamConfig cấu hình các endpoint SSE và WebSocket stream thay đổi tồn kho.
+type StreamConfig struct {
+	// HeartbeatInterval là chu kỳ gửi heartbeat để proxy không đóng kết nối rảnh và để phát hiện client đã mất.
+	HeartbeatInterval time.Duration
+	// WriteTimeout là thời gian tối đa cho một lần ghi; client đọc không kịp bị ngắt kết nối
+	// để server không phải giữ buffer cho nó, client kết nối lại bằng Last-Event-ID.
+	WriteTimeout time.Duration
+}
+
+// writeDeadline là deadline cho lần ghi tiếp theo; zero = không giới hạn.
+func (cfg StreamConfig) writeDeadline() time.Time {
+	if cfg.WriteTimeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(cfg.WriteTimeout)
+}
+
+// upgrader dùng kiểm tra Origin mặc định: chỉ chấp nhận trang cùng origin với service.
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// watchRequestFromQuery đọc item ID và prefix từ query "ids" và "prefixes" (phân tách bằng dấu phẩy
+// hoặc lặp lại tham số), resume token từ header Last-Event-ID hoặc query last_event_id.
+func watchRequestFromQuery(c *gin.Context) service.WatchRequest {
+	resumeToken := c.GetHeader("Last-Event-ID")
+	if resumeToken == "" {
+		resumeToken = c.Query("last_event_id")
+	}
+	return service.WatchRequest{
+		ItemIDs:     splitQueryList(c.QueryArray("ids")),
+		Prefixes:    splitQueryList(c.QueryArray("prefixes")),
+		ResumeToken: resumeToken,
+	}
+}
+
+func splitQueryList(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// runHeartbeat gọi beat mỗi interval cho tới khi ctx bị hủy; beat lỗi thì gọi cancel để kết thúc stream.
+func runHeartbeat(ctx context.Context, interval time.Duration, cancel context.CancelFunc, beat func() error) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := beat(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// StreamItemsHandler stream thay đổi tồn kho dưới dạng Server-Sent Events. Mỗi event có id là resume token,
+// nên EventSource của trình duyệt tự resume bằng Last-Event-ID khi kết nối lại.
+func (h *Handler) StreamItemsHandler(c *gin.Context) {
+	req := watchRequestFromQuery(c)
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // tắt buffer của nginx
+	c.Status(http.StatusOK)
+
+	rc := http.NewResponseController(c.Writer)
+	var mu sync.Mutex
+	write := func(p []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := rc.SetWriteDeadline(h.stream.writeDeadline()); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := c.Writer.Write(p); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write([]byte("retry: 3000\n\n")); err != nil {
+		return
+	}
+	go runHeartbeat(ctx, h.stream.HeartbeatInterval, cancel, func() error {
+		return write([]byte(": heartbeat\n\n"))
+	})
+
+	err := h.watcher.Watch(ctx, req, func(change model.InventoryChange) error {
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		buf.WriteString("id: " + change.ResumeToken + "\n")
+		buf.WriteString("event: " + string(change.Type) + "\n")
+		buf.WriteString("data: ")
+		buf.Write(data)
+		buf.WriteString("\n\n")
+		return write(buf.Bytes())
+	})
+	if ctx.Err() != nil {
+		return // client ngắt kết nối hoặc ghi không kịp
+	}
+	if err != nil {
+		if !errors.Is(err, service.ErrWatcherStopped) {
+			log.Printf("Lỗi stream SSE: %v", err)
+		}
+		_, body := errorResponse(err)
+		data, _ := json.Marshal(body)
+		_ = write([]byte("event: error\ndata: " + string(data) + "\n\n"))
+	}
+}
+
+// StreamItemsWebSocketHandler stream thay đổi tồn kho qua WebSocket, mỗi message là một InventoryChange dạng JSON.
+// Query giống StreamItemsHandler; resume bằng query last_event_id là resume_token của message cuối cùng đã nhận.
+// Server gửi ping mỗi HeartbeatInterval và đóng kết nối nếu không nhận được pong.
+func (h *Handler) StreamItemsWebSocketHandler(c *gin.Context) {
+	req := watchRequestFromQuery(c)
+	if err := req.Validate(); err != nil {
+		writeError(c, err)
+		return
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // Upgrade đã ghi response lỗi
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// Client không cần gửi gì; vòng đọc chỉ để xử lý pong/close và phát hiện kết nối chết.
+	readTimeout := 2 * h.stream.HeartbeatInterval
+	conn.SetReadLimit(512)
+	if readTimeout > 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(readTimeout))
+		})
+	}
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	go runHeartbeat(ctx, h.stream.HeartbeatInterval, cancel, func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, h.stream.writeDeadline())
+	})
+
+	err = h.watcher.Watch(ctx, req, func(change model.InventoryChange) error {
+		if err := conn.SetWriteDeadline(h.stream.writeDeadline()); err != nil {
+			return err
+		}
+		return conn.WriteJSON(change)
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	closeCode, reason := websocket.CloseInternalServerErr, "internal error"
+	if errors.Is(err, service.ErrWatcherStopped) {
+		closeCode, reason = websocket.CloseServiceRestart, "server is shutting down"
+	} else if err != nil {
+		log.Printf("Lỗi stream WebSocket: %v", err)
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, reason), time.Now().Add(time.Second))
+}
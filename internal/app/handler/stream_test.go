@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/service"
+)
+
+func TestWatchRequestFromQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   service.WatchRequest
+	}{
+		{
+			name:   "comma separated and repeated params",
+			target: "/items/stream?ids=sku-1,%20sku-2&ids=sku-3&prefixes=bin-,",
+			want:   service.WatchRequest{ItemIDs: []string{"sku-1", "sku-2", "sku-3"}, Prefixes: []string{"bin-"}},
+		},
+		{
+			name:   "Last-Event-ID header wins over query",
+			target: "/items/stream?ids=sku-1&last_event_id=from-query",
+			header: "from-header",
+			want:   service.WatchRequest{ItemIDs: []string{"sku-1"}, ResumeToken: "from-header"},
+		},
+		{
+			name:   "query resume token for clients that cannot set headers",
+			target: "/items/stream?prefixes=sku-&last_event_id=from-query",
+			want:   service.WatchRequest{Prefixes: []string{"sku-"}, ResumeToken: "from-query"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Last-Event-ID", tt.header)
+			}
+			if got := watchRequestFromQuery(c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("watchRequestFromQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+type transferLineRequest struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+type createTransferRequest struct {
+	SourceLocationID      string                `json:"source_location_id"`      // rỗng = location mặc định
+	DestinationLocationID string                `json:"destination_location_id"` // rỗng = location mặc định
+	Note                  string                `json:"note"`
+	Lines                 []transferLineRequest `json:"lines"`
+}
+
+type receiveTransferRequest struct {
+	Lines []transferLineRequest `json:"lines"`
+	// Close đóng lệnh sau lần nhận này; phần chưa nhận được ghi là thiếu.
+	Close bool `json:"close"`
+}
+
+// CreateTransferHandler tạo lệnh chuyển kho ở trạng thái draft. Hỗ trợ Idempotency-Key.
+func (h *Handler) CreateTransferHandler(c *gin.Context) {
+	var req createTransferRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	in := service.TransferInput{
+		SourceLocationID:      req.SourceLocationID,
+		DestinationLocationID: req.DestinationLocationID,
+		Note:                  req.Note,
+	}
+	for _, line := range req.Lines {
+		in.Lines = append(in.Lines, model.TransferLine{ItemID: line.ItemID, Quantity: line.Quantity})
+	}
+	ctx := c.Request.Context()
+
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		t, err := h.transferSvc.CreateTransferTx(ctx, tx, in)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		return mutationResult{status: http.StatusCreated, body: t}, nil
+	})
+	if !ok {
+		return
+	}
+	writeMutationResponse(c, result)
+}
+
+// ListTransfersHandler liệt kê lệnh chuyển kho, mới nhất trước.
+// Query: status, location (nguồn hoặc đích), limit, cursor.
+func (h *Handler) ListTransfersHandler(c *gin.Context) {
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+	transfers, next, err := h.transferSvc.ListTransfers(c.Request.Context(), repository.TransferListOptions{
+		Status:     model.TransferStatus(c.Query("status")),
+		LocationID: c.Query("location"),
+		Cursor:     c.Query("cursor"),
+		PageSize:   limit,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": transfers, "next_cursor": next})
+}
+
+func (h *Handler) GetTransferHandler(c *gin.Context) {
+	t, err := h.transferSvc.GetTransfer(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// ShipTransferHandler xuất lệnh draft: trừ tồn kho ở nguồn và ghi hàng vào in_transit của đích.
+// Hỗ trợ Idempotency-Key.
+func (h *Handler) ShipTransferHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.transferMutation(c, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return h.transferSvc.ShipTransferTx(ctx, tx, c.Param("id"), model.MovementSourceHTTP)
+	})
+}
+
+// ReceiveTransferHandler ghi một lần nhận hàng (có thể một phần) của lệnh đã xuất. Hỗ trợ Idempotency-Key.
+func (h *Handler) ReceiveTransferHandler(c *gin.Context) {
+	var req receiveTransferRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	receipts := make([]model.TransferReceipt, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		receipts = append(receipts, model.TransferReceipt{ItemID: line.ItemID, Quantity: line.Quantity})
+	}
+	ctx := c.Request.Context()
+	h.transferMutation(c, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return h.transferSvc.ReceiveTransferTx(ctx, tx, c.Param("id"), receipts, req.Close, model.MovementSourceHTTP)
+	})
+}
+
+// CancelTransferHandler huỷ lệnh draft, hoặc lệnh đã xuất nhưng chưa nhận (hàng trả về nguồn).
+// Hỗ trợ Idempotency-Key.
+func (h *Handler) CancelTransferHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.transferMutation(c, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return h.transferSvc.CancelTransferTx(ctx, tx, c.Param("id"), model.MovementSourceHTTP)
+	})
+}
+
+// transferMutation chạy một bước của lệnh chuyển kho qua runMutation, rồi invalidate cache các item của lệnh.
+func (h *Handler) transferMutation(c *gin.Context, fn func(tx *sql.Tx) (*model.TransferOrder, error)) {
+	var transfer *model.TransferOrder
+	result, ok := h.runMutation(c, func(tx *sql.Tx) (mutationResult, error) {
+		t, err := fn(tx)
+		if err != nil {
+			return mutationResult{}, err
+		}
+		transfer = t
+		return mutationResult{status: http.StatusOK, body: t}, nil
+	})
+	if !ok {
+		return
+	}
+	h.transferSvc.InvalidateCache(c.Request.Context(), transfer)
+	writeMutationResponse(c, result)
+}
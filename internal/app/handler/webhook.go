@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/service"
+)
+
+type webhookSubscriptionRequest struct {
+	URL        string                     `json:"url"`
+	EventTypes []model.InventoryEventType `json:"event_types"` // rỗng = mọi loại event
+	Secret     string                     `json:"secret"`      // rỗng = sinh ngẫu nhiên khi tạo, giữ nguyên khi cập nhật
+	Active     *bool                      `json:"active"`      // mặc định true
+	// RotateSecret sinh secret mới khi cập nhật mà không truyền secret.
+	RotateSecret bool `json:"rotate_secret"`
+}
+
+func (r webhookSubscriptionRequest) input() service.WebhookSubscriptionInput {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return service.WebhookSubscriptionInput{
+		URL:          r.URL,
+		EventTypes:   r.EventTypes,
+		Secret:       r.Secret,
+		Active:       active,
+		RotateSecret: r.RotateSecret,
+	}
+}
+
+// CreateWebhookHandler tạo webhook subscription. Response chứa secret dùng để xác thực chữ ký;
+// secret không được trả về ở các API đọc sau này.
+func (h *Handler) CreateWebhookHandler(c *gin.Context) {
+	var req webhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	sub, err := h.webhookSvc.Create(c.Request.Context(), req.input())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *Handler) ListWebhooksHandler(c *gin.Context) {
+	subs, err := h.webhookSvc.List(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": subs})
+}
+
+func (h *Handler) GetWebhookHandler(c *gin.Context) {
+	sub, err := h.webhookSvc.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateWebhookHandler thay toàn bộ cấu hình của subscription; secret chỉ xuất hiện trong response nếu bị đổi.
+func (h *Handler) UpdateWebhookHandler(c *gin.Context) {
+	var req webhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "body không hợp lệ"))
+		return
+	}
+	sub, err := h.webhookSvc.Update(c.Request.Context(), c.Param("id"), req.input())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *Handler) DeleteWebhookHandler(c *gin.Context) {
+	if err := h.webhookSvc.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveriesHandler liệt kê nhật ký gửi của subscription, mới nhất trước.
+// Query: status (pending, delivered, failed), limit, cursor.
+func (h *Handler) ListWebhookDeliveriesHandler(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "limit không hợp lệ"))
+			return
+		}
+	}
+
+	deliveries, next, err := h.webhookSvc.ListDeliveries(c.Request.Context(), c.Param("id"),
+		model.WebhookDeliveryStatus(c.Query("status")), c.Query("cursor"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": deliveries, "next_cursor": next})
+}
+
+func (h *Handler) GetWebhookDeliveryHandler(c *gin.Context) {
+	id, ok := webhookDeliveryID(c)
+	if !ok {
+		return
+	}
+	delivery, err := h.webhookSvc.GetDelivery(c.Request.Context(), c.Param("id"), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// RedeliverWebhookHandler đưa delivery về hàng đợi để gửi lại ngay, với số lần thử đếm lại từ đầu.
+func (h *Handler) RedeliverWebhookHandler(c *gin.Context) {
+	id, ok := webhookDeliveryID(c)
+	if !ok {
+		return
+	}
+	delivery, err := h.webhookSvc.Redeliver(c.Request.Context(), c.Param("id"), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func webhookDeliveryID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, errorBody("invalid_argument", "delivery id không hợp lệ"))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,314 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+	"inventory-service.com/m/internal/model"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// negativeValue được lưu cho item không tồn tại (negative caching).
+const negativeValue = "null"
+
+// lockPollInterval là chu kỳ chờ cache được request giữ lock nạp xong.
+const lockPollInterval = 25 * time.Millisecond
+
+// loadTimeout giới hạn một lần nạp cache dùng chung bởi singleflight; lần nạp không phụ thuộc vào
+// context của request đầu tiên để request bị huỷ không làm lỗi các request đang chờ cùng key.
+const loadTimeout = 5 * time.Second
+
+// tombstonePrefix đánh dấu giá trị do Invalidate ghi thay cho việc xoá key. Tombstone được đọc như cache miss,
+// nhưng cho loader biết key đã bị invalidate kể từ lúc nó bắt đầu đọc DB (xem setIfUnchangedScript).
+const tombstonePrefix = "tombstone:"
+
+// tombstoneTTL là thời gian sống của tombstone, phải dài hơn lần nạp cache chậm nhất.
+const tombstoneTTL = time.Minute
+
+// setIfUnchangedScript chỉ ghi cache khi key vẫn giữ giá trị loader thấy lúc cache miss (rỗng hoặc cùng tombstone).
+// Nếu Invalidate chạy trong lúc loader đọc DB thì tombstone mới khác giá trị đó và bản có thể đã cũ bị bỏ.
+var setIfUnchangedScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if (current or '') ~= ARGV[1] then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// releaseFillLockScript chỉ xoá lock nạp cache khi key vẫn giữ token của người gọi, để request có lock
+// đã hết hạn không xoá lock của request khác.
+var releaseFillLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// InventoryCacheConfig cấu hình thời gian sống của cache và lock chống stampede.
+type InventoryCacheConfig struct {
+	TTL         time.Duration // TTL của item có trong DB
+	NegativeTTL time.Duration // TTL của item không tồn tại
+	Jitter      float64       // tỷ lệ TTL ngẫu nhiên cộng thêm (0.1 = tới +10%), tránh nhiều key hết hạn cùng lúc
+	LockTTL     time.Duration // thời gian giữ lock nạp cache; request khác chờ tối đa chừng này rồi tự đọc DB
+}
+
+// InventoryCache là cache-aside trong Redis cho thông tin tồn kho của item.
+// Khi cache miss, các request trong cùng process được gộp bằng singleflight, còn giữa các instance
+// chỉ request giữ được lock Redis mới đọc Postgres; các request khác chờ cache được nạp.
+type InventoryCache struct {
+	client *redis.Client
+	cfg    InventoryCacheConfig
+	group  singleflight.Group
+}
+
+func NewInventoryCache(client *redis.Client, cfg InventoryCacheConfig) *InventoryCache {
+	return &InventoryCache{client: client, cfg: cfg}
+}
+
+// InventoryKey là key Redis cache thông tin tồn kho của item.
+func InventoryKey(itemID string) string {
+	return "inventory:" + itemID
+}
+
+func inventoryLockKey(itemID string) string {
+	return "lock:cache:inventory:" + itemID
+}
+
+// Get trả về item từ cache, hoặc gọi load khi cache miss và lưu kết quả.
+// load trả về (nil, nil) nếu item không tồn tại; kết quả nil được cache với NegativeTTL.
+func (c *InventoryCache) Get(ctx context.Context, itemID string, load func(ctx context.Context) (*model.InventoryItem, error)) (*model.InventoryItem, error) {
+	item, hit, observed := c.lookup(ctx, itemID)
+	if hit {
+		return item, nil
+	}
+
+	v, err := c.do(ctx, itemID, func(ctx context.Context) (interface{}, error) {
+		return c.loadLocked(ctx, itemID, observed, load)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*model.InventoryItem), nil
+}
+
+// GetMany trả về các item theo thứ tự itemIDs, bỏ qua item không tồn tại. Các item cache miss
+// được nạp bằng một lần gọi loadMany; item thiếu trong kết quả của loadMany được negative-cache.
+func (c *InventoryCache) GetMany(ctx context.Context, itemIDs []string, loadMany func(ctx context.Context, ids []string) ([]*model.InventoryItem, error)) ([]*model.InventoryItem, error) {
+	found := make(map[string]*model.InventoryItem, len(itemIDs))
+	observed := make(map[string]string)
+	var misses []string
+
+	keys := make([]string, len(itemIDs))
+	for i, id := range itemIDs {
+		keys[i] = InventoryKey(id)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Printf("Lỗi đọc cache inventory: %v", err)
+		values = make([]interface{}, len(itemIDs))
+	}
+	for i, id := range itemIDs {
+		s, _ := values[i].(string)
+		item, err := decodeItem(s)
+		if s == "" || isTombstone(s) || err != nil {
+			if _, dup := observed[id]; !dup {
+				misses = append(misses, id)
+			}
+			observed[id] = s
+			continue
+		}
+		found[id] = item
+	}
+
+	if len(misses) > 0 {
+		sort.Strings(misses)
+		v, err := c.do(ctx, "many:"+strings.Join(misses, ","), func(ctx context.Context) (interface{}, error) {
+			items, err := loadMany(ctx, misses)
+			if err != nil {
+				return nil, err
+			}
+			loaded := make(map[string]*model.InventoryItem, len(misses))
+			for _, item := range items {
+				loaded[item.ID] = item
+			}
+			pipe := c.client.Pipeline()
+			for _, id := range misses {
+				c.setIfUnchanged(ctx, pipe, id, observed[id], loaded[id])
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				log.Printf("Lỗi ghi cache inventory: %v", err)
+			}
+			return loaded, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range misses {
+			found[id] = v.(map[string]*model.InventoryItem)[id]
+		}
+	}
+
+	result := make([]*model.InventoryItem, 0, len(itemIDs))
+	seen := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		if item := found[id]; item != nil && !seen[id] {
+			seen[id] = true
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// Invalidate đánh dấu cache của item là cũ sau khi tồn kho thay đổi.
+func (c *InventoryCache) Invalidate(ctx context.Context, itemID string) error {
+	return InvalidateItem(ctx, c.client, itemID)
+}
+
+// InvalidateItem thay cache của item bằng một tombstone mới thay vì xoá key, để loader đã đọc DB trước thay đổi
+// không ghi đè bản cũ lên cache. Cache được invalidate trước khi publish để watcher đọc lại item không nhận bản cũ.
+func InvalidateItem(ctx context.Context, client *redis.Client, itemID string) error {
+	tombstone := tombstonePrefix + strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(rand.Int63(), 36)
+	if err := client.Set(ctx, InventoryKey(itemID), tombstone, tombstoneTTL).Err(); err != nil {
+		return err
+	}
+	return PublishInventoryChange(ctx, client, itemID)
+}
+
+// do gộp các lần nạp cùng key trong process bằng singleflight. Lần nạp chạy với context tách khỏi request
+// (giữ value, bỏ cancel) và có timeout riêng; mỗi caller vẫn dừng chờ khi context của chính nó bị huỷ.
+func (c *InventoryCache) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return fn(loadCtx)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.Val, res.Err
+	}
+}
+
+// lookup đọc cache; hit = true kể cả với negative cache (item nil). Lỗi Redis và tombstone được coi là miss.
+// observed là giá trị thô đang có trong key ("" nếu không có), dùng làm điều kiện khi ghi cache sau đó.
+func (c *InventoryCache) lookup(ctx context.Context, itemID string) (item *model.InventoryItem, hit bool, observed string) {
+	s, err := c.client.Get(ctx, InventoryKey(itemID)).Result()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("Lỗi đọc cache %s: %v", InventoryKey(itemID), err)
+		}
+		return nil, false, ""
+	}
+	if isTombstone(s) {
+		return nil, false, s
+	}
+	item, err = decodeItem(s)
+	if err != nil {
+		return nil, false, s
+	}
+	return item, true, s
+}
+
+// loadLocked nạp item khi cache miss. Chỉ request giữ được lock Redis đọc DB và ghi cache;
+// request khác chờ cache được nạp trong tối đa LockTTL, sau đó tự đọc DB.
+// Cache chỉ được ghi nếu key vẫn giữ giá trị observed lúc miss.
+func (c *InventoryCache) loadLocked(ctx context.Context, itemID, observed string, load func(ctx context.Context) (*model.InventoryItem, error)) (*model.InventoryItem, error) {
+	lockKey := inventoryLockKey(itemID)
+	token := idUtils.NewID()
+	locked, err := c.client.SetNX(ctx, lockKey, token, c.cfg.LockTTL).Result()
+	if err != nil {
+		log.Printf("Lỗi đặt lock cache %s: %v", lockKey, err)
+	}
+	if locked {
+		defer func() {
+			if err := releaseFillLockScript.Run(ctx, c.client, []string{lockKey}, token).Err(); err != nil {
+				log.Printf("Lỗi giải phóng lock cache %s: %v", lockKey, err)
+			}
+		}()
+	} else if err == nil {
+		if item, hit := c.waitForFill(ctx, itemID); hit {
+			return item, nil
+		}
+	}
+
+	item, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pipe := c.client.Pipeline()
+	c.setIfUnchanged(ctx, pipe, itemID, observed, item)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Lỗi ghi cache %s: %v", InventoryKey(itemID), err)
+	}
+	return item, nil
+}
+
+// setIfUnchanged thêm vào pipe lệnh ghi item vào cache nếu key vẫn giữ giá trị observed.
+func (c *InventoryCache) setIfUnchanged(ctx context.Context, pipe redis.Pipeliner, itemID, observed string, item *model.InventoryItem) {
+	setIfUnchangedScript.Eval(ctx, pipe, []string{InventoryKey(itemID)},
+		observed, encodeItem(item), c.ttlFor(item).Milliseconds())
+}
+
+// waitForFill chờ request đang giữ lock ghi cache, tối đa LockTTL.
+func (c *InventoryCache) waitForFill(ctx context.Context, itemID string) (*model.InventoryItem, bool) {
+	deadline := time.Now().Add(c.cfg.LockTTL)
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-ticker.C:
+			if item, hit, _ := c.lookup(ctx, itemID); hit {
+				return item, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ttlFor trả về TTL có jitter cho item (NegativeTTL nếu item nil).
+func (c *InventoryCache) ttlFor(item *model.InventoryItem) time.Duration {
+	ttl := c.cfg.TTL
+	if item == nil {
+		ttl = c.cfg.NegativeTTL
+	}
+	if c.cfg.Jitter > 0 {
+		ttl += time.Duration(rand.Float64() * c.cfg.Jitter * float64(ttl))
+	}
+	return ttl
+}
+
+// encodeItem mã hoá item để lưu vào cache; item nil được lưu là negativeValue.
+func encodeItem(item *model.InventoryItem) string {
+	if item == nil {
+		return negativeValue
+	}
+	b, _ := json.Marshal(item) // InventoryItem chỉ gồm kiểu cơ bản, Marshal không thể lỗi
+	return string(b)
+}
+
+func isTombstone(s string) bool {
+	return strings.HasPrefix(s, tombstonePrefix)
+}
+
+func decodeItem(s string) (*model.InventoryItem, error) {
+	if s == negativeValue {
+		return nil, nil
+	}
+	var item model.InventoryItem
+	if err := json.Unmarshal([]byte(s), &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
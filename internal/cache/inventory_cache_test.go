@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"inventory-service.com/m/internal/model"
+)
+
+var testCacheConfig = InventoryCacheConfig{TTL: time.Minute, NegativeTTL: 10 * time.Second, LockTTL: 200 * time.Millisecond}
+
+func newTestCache(t *testing.T) (*InventoryCache, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewInventoryCache(client, testCacheConfig), mr
+}
+
+// countingLoader trả về item (nil = không tồn tại) và đếm số lần được gọi.
+func countingLoader(item *model.InventoryItem, err error, calls *int) func(context.Context) (*model.InventoryItem, error) {
+	return func(context.Context) (*model.InventoryItem, error) {
+		*calls++
+		return item, err
+	}
+}
+
+func TestInventoryCacheGet(t *testing.T) {
+	errDB := errors.New("db down")
+	tests := []struct {
+		name      string
+		item      *model.InventoryItem
+		loadErr   error
+		wantTTL   time.Duration
+		wantCalls int // số lần load sau hai lần Get
+	}{
+		{name: "miss is loaded once and cached", item: &model.InventoryItem{ID: "sku-1", Quantity: 5, Version: 2}, wantTTL: testCacheConfig.TTL, wantCalls: 1},
+		{name: "missing item is negative cached", wantTTL: testCacheConfig.NegativeTTL, wantCalls: 1},
+		{name: "load errors are not cached", loadErr: errDB, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mr := newTestCache(t)
+			calls := 0
+			load := countingLoader(tt.item, tt.loadErr, &calls)
+
+			for i := 0; i < 2; i++ {
+				got, err := c.Get(context.Background(), "sku-1", load)
+				if !errors.Is(err, tt.loadErr) {
+					t.Fatalf("Get() error = %v, want %v", err, tt.loadErr)
+				}
+				if !reflect.DeepEqual(got, tt.item) {
+					t.Errorf("Get() = %+v, want %+v", got, tt.item)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("load calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if ttl := mr.TTL(InventoryKey("sku-1")); ttl != tt.wantTTL {
+				t.Errorf("cache TTL = %s, want %s", ttl, tt.wantTTL)
+			}
+			if mr.Exists(inventoryLockKey("sku-1")) {
+				t.Error("fill lock was not released")
+			}
+		})
+	}
+}
+
+func TestInventoryCacheTombstone(t *testing.T) {
+	stale := &model.InventoryItem{ID: "sku-1", Quantity: 5, Version: 2}
+	fresh := &model.InventoryItem{ID: "sku-1", Quantity: 7, Version: 3}
+	tests := []struct {
+		name string
+		// invalidateDuringLoad mô phỏng một thay đổi commit trong lúc loader đang đọc DB.
+		invalidateDuringLoad bool
+		wantCached           bool
+	}{
+		{name: "fill after invalidation is kept", wantCached: true},
+		{name: "fill racing an invalidation is dropped", invalidateDuringLoad: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mr := newTestCache(t)
+			ctx := context.Background()
+			if err := c.Invalidate(ctx, "sku-1"); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := c.Get(ctx, "sku-1", func(ctx context.Context) (*model.InventoryItem, error) {
+				if tt.invalidateDuringLoad {
+					if err := c.Invalidate(ctx, "sku-1"); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return stale, nil
+			})
+			if err != nil || !reflect.DeepEqual(got, stale) {
+				t.Fatalf("Get() = %+v, %v; the caller still gets what it loaded", got, err)
+			}
+
+			raw, _ := mr.Get(InventoryKey("sku-1"))
+			if cached := !isTombstone(raw); cached != tt.wantCached {
+				t.Fatalf("cached = %v (raw %q), want %v", cached, raw, tt.wantCached)
+			}
+			if tt.wantCached {
+				return
+			}
+			// Tombstone được đọc như miss: lần Get tiếp theo nạp lại bản mới.
+			got, err = c.Get(ctx, "sku-1", func(context.Context) (*model.InventoryItem, error) { return fresh, nil })
+			if err != nil || !reflect.DeepEqual(got, fresh) {
+				t.Errorf("Get() after tombstone = %+v, %v, want %+v", got, err, fresh)
+			}
+		})
+	}
+}
+
+func TestInventoryCacheFillLock(t *testing.T) {
+	item := &model.InventoryItem{ID: "sku-1", Quantity: 5, Version: 2}
+	t.Run("waits for the lock holder to fill the cache", func(t *testing.T) {
+		c, mr := newTestCache(t)
+		mr.Set(inventoryLockKey("sku-1"), "other-instance")
+		go func() {
+			time.Sleep(3 * lockPollInterval)
+			mr.Set(InventoryKey("sku-1"), encodeItem(item))
+		}()
+
+		calls := 0
+		got, err := c.Get(context.Background(), "sku-1", countingLoader(nil, nil, &calls))
+		if err != nil || !reflect.DeepEqual(got, item) {
+			t.Fatalf("Get() = %+v, %v, want %+v", got, err, item)
+		}
+		if calls != 0 {
+			t.Errorf("load calls = %d, want 0 while another instance fills the cache", calls)
+		}
+	})
+	t.Run("loads from the db when the holder never fills", func(t *testing.T) {
+		c, mr := newTestCache(t)
+		mr.Set(inventoryLockKey("sku-1"), "other-instance")
+
+		calls := 0
+		if _, err := c.Get(context.Background(), "sku-1", countingLoader(item, nil, &calls)); err != nil {
+			t.Fatal(err)
+		}
+		if calls != 1 {
+			t.Errorf("load calls = %d, want 1 after LockTTL", calls)
+		}
+		if v, _ := mr.Get(inventoryLockKey("sku-1")); v != "other-instance" {
+			t.Errorf("lock = %q, the other instance's lock must be left alone", v)
+		}
+	})
+	t.Run("expired lock taken over by another instance is not released", func(t *testing.T) {
+		c, mr := newTestCache(t)
+		_, err := c.Get(context.Background(), "sku-1", func(context.Context) (*model.InventoryItem, error) {
+			// Lock của request này hết hạn và instance khác lấy được lock trong lúc đọc DB.
+			mr.Set(inventoryLockKey("sku-1"), "other-instance")
+			return item, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, _ := mr.Get(inventoryLockKey("sku-1")); v != "other-instance" {
+			t.Errorf("lock = %q, want the other instance's token kept", v)
+		}
+	})
+}
+
+func TestInventoryCacheSharedLoadOutlivesCaller(t *testing.T) {
+	c, mr := newTestCache(t)
+	item := &model.InventoryItem{ID: "sku-1", Quantity: 5, Version: 2}
+	release := make(chan struct{})
+	done := make(chan struct{})
+	load := func(ctx context.Context) (*model.InventoryItem, error) {
+		defer close(done)
+		<-release
+		return item, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := c.Get(ctx, "sku-1", load); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get() error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	<-done
+	deadline := time.Now().Add(time.Second)
+	for !mr.Exists(InventoryKey("sku-1")) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	raw, _ := mr.Get(InventoryKey("sku-1"))
+	if raw != encodeItem(item) {
+		t.Errorf("cache = %q, the shared load must finish after its first caller left", raw)
+	}
+}
+
+func TestInventoryCacheGetMany(t *testing.T) {
+	c, mr := newTestCache(t)
+	cachedItem := &model.InventoryItem{ID: "a", Quantity: 1, Version: 1}
+	loadedItem := &model.InventoryItem{ID: "b", Quantity: 2, Version: 1}
+	mr.Set(InventoryKey("a"), encodeItem(cachedItem))
+	if err := c.Invalidate(context.Background(), "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	var requested []string
+	got, err := c.GetMany(context.Background(), []string{"b", "a", "c", "b"}, func(_ context.Context, ids []string) ([]*model.InventoryItem, error) {
+		requested = ids
+		return []*model.InventoryItem{loadedItem}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*model.InventoryItem{loadedItem, cachedItem}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMany() = %+v, want %+v", got, want)
+	}
+	if want := []string{"b", "c"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("loadMany ids = %v, want %v (cache hits and duplicates skipped, tombstone reloaded)", requested, want)
+	}
+	if raw, _ := mr.Get(InventoryKey("c")); raw != negativeValue {
+		t.Errorf("missing item cache = %q, want negative value", raw)
+	}
+	if raw, _ := mr.Get(InventoryKey("b")); raw != encodeItem(loadedItem) {
+		t.Errorf("loaded item cache = %q", raw)
+	}
+}
+
+func TestInvalidateItemPublishesAfterTombstone(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+	pubsub := SubscribeInventoryChanges(ctx, c.client)
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InvalidateItem(ctx, c.client, "sku-1"); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := pubsub.ReceiveMessage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Payload != "sku-1" {
+		t.Errorf("published %q, want sku-1", msg.Payload)
+	}
+	if raw, _ := mr.Get(InventoryKey("sku-1")); !isTombstone(raw) {
+		t.Errorf("cache = %q, want a tombstone before watchers re-read the item", raw)
+	}
+}
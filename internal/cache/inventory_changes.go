@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// InventoryChangesChannel là kênh Redis pub/sub nhận ID của item mỗi khi tồn kho của item thay đổi.
+// Payload chỉ là ID; subscriber tự đọc trạng thái mới nên message bị mất hoặc trùng không làm sai dữ liệu.
+const InventoryChangesChannel = "inventory:changes"
+
+// PublishInventoryChange báo cho các instance đang watch rằng item đã thay đổi.
+// Chỉ gọi sau khi thay đổi đã commit.
+func PublishInventoryChange(ctx context.Context, client *redis.Client, itemID string) error {
+	return client.Publish(ctx, InventoryChangesChannel, itemID).Err()
+}
+
+// SubscribeInventoryChanges đăng ký nhận ID của các item thay đổi trên InventoryChangesChannel.
+func SubscribeInventoryChanges(ctx context.Context, client *redis.Client) *redis.PubSub {
+	return client.Subscribe(ctx, InventoryChangesChannel)
+}
@@ -11,7 +11,7 @@ func InitKafkaProducer(broker, topic string) (*kafka.Writer, error) {
 	writer := kafka.NewWriter(kafka.WriterConfig{
 		Brokers:      []string{broker},
 		Topic:        topic,
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{}, // cùng key (item ID) luôn vào cùng partition để giữ thứ tự
 		RequiredAcks: int(kafka.RequireOne),
 		// Có thể cấu hình thêm timeout, retry nếu cần
 		WriteTimeout: 10 * time.Second,
@@ -20,6 +20,18 @@ func InitKafkaProducer(broker, topic string) (*kafka.Writer, error) {
 	return writer, nil
 }
 
+// InitKafkaWriter khởi tạo một Kafka Writer không gắn topic; mỗi message tự chỉ định Topic.
+// Dùng cho outbox relay vì các event trong outbox có thể thuộc nhiều topic khác nhau.
+func InitKafkaWriter(broker string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(broker),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireOne,
+		WriteTimeout: 10 * time.Second,
+		BatchTimeout: 50 * time.Millisecond,
+	}
+}
+
 // InitKafkaReader khởi tạo một Kafka Reader để nhận message từ topic chỉ định.
 func InitKafkaReader(broker, topic string) *kafka.Reader {
 	// Sử dụng một GroupID để đảm bảo tính đồng bộ của consumer group.
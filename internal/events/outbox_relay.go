@@ -0,0 +1,118 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+const (
+	outboxRetention     = 7 * 24 * time.Hour
+	outboxPurgeInterval = time.Hour
+	outboxMaxBackoff    = 5 * time.Minute
+)
+
+// OutboxRelay định kỳ đọc outbox_events và publish các event pending lên Kafka.
+type OutboxRelay struct {
+	repo         *repository.OutboxRepository
+	writer       *kafka.Writer
+	batchSize    int
+	pollInterval time.Duration
+}
+
+// NewOutboxRelay tạo relay. writer không được cấu hình Topic vì mỗi event mang topic riêng.
+func NewOutboxRelay(repo *repository.OutboxRepository, writer *kafka.Writer, batchSize int, pollInterval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		repo:         repo,
+		writer:       writer,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+	}
+}
+
+// Start chạy vòng lặp relay cho tới khi ctx bị hủy.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	lastPurge := time.Now()
+
+	for {
+		n, err := r.repo.ProcessPending(ctx, r.batchSize, outboxBackoff, r.publish)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Lỗi relay outbox: %v", err)
+		}
+
+		if time.Since(lastPurge) > outboxPurgeInterval {
+			lastPurge = time.Now()
+			if purged, err := r.repo.PurgeSent(ctx, outboxRetention); err != nil {
+				log.Printf("Lỗi dọn outbox: %v", err)
+			} else if purged > 0 {
+				log.Printf("Đã dọn %d outbox event", purged)
+			}
+		}
+
+		// Còn event pending thì xử lý tiếp ngay, không chờ tick.
+		if err == nil && n > 0 {
+			select {
+			case <-ctx.Done():
+				log.Println("Context bị hủy, dừng outbox relay")
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Context bị hủy, dừng outbox relay")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish gửi cả batch trong một lần gọi WriteMessages và trả về lỗi theo từng event.
+func (r *OutboxRelay) publish(ctx context.Context, events []*model.OutboxEvent) []error {
+	msgs := make([]kafka.Message, len(events))
+	for i, e := range events {
+		msgs[i] = kafka.Message{
+			Topic: e.Topic,
+			Key:   []byte(e.Key),
+			Value: e.Payload,
+		}
+	}
+
+	errs := make([]error, len(events))
+	err := r.writer.WriteMessages(ctx, msgs...)
+	if err == nil {
+		return errs
+	}
+
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) && len(writeErrs) == len(events) {
+		copy(errs, writeErrs)
+	} else {
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+	log.Printf("Lỗi publish outbox batch (%d event): %v", len(events), err)
+	return errs
+}
+
+// outboxBackoff tính thời gian chờ trước lần thử tiếp theo: 1s, 2s, 4s, ... tối đa outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	if attempts > 20 {
+		return outboxMaxBackoff
+	}
+	d := time.Second << (attempts - 1)
+	if d > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+const (
+	webhookMaxBackoff = time.Hour
+	// webhookMaxErrorBody là số byte tối đa của response lỗi được ghi vào last_error.
+	webhookMaxErrorBody = 512
+	// webhookLeaseMargin cộng thêm vào timeout khi claim delivery để lease không hết trước khi request xong.
+	webhookLeaseMargin = 30 * time.Second
+)
+
+// Header của mỗi webhook request. Người nhận xác thực bằng cách tính
+// hex(HMAC-SHA256(secret, "<t>.<body>")) và so sánh với v1 trong X-Webhook-Signature: t=<unix>,v1=<hex>.
+const (
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookEventID   = "X-Webhook-Event-Id"
+)
+
+// WebhookDispatcher định kỳ gửi các webhook delivery pending tới subscription tương ứng.
+type WebhookDispatcher struct {
+	repo         *repository.WebhookRepository
+	client       *http.Client
+	batchSize    int
+	maxAttempts  int
+	pollInterval time.Duration
+	lease        time.Duration
+}
+
+// NewWebhookDispatcher tạo dispatcher; timeout áp dụng cho từng request gửi webhook.
+// Client không đi theo redirect để payload đã ký không bị chuyển tới địa chỉ khác; 3xx được coi là thất bại.
+func NewWebhookDispatcher(repo *repository.WebhookRepository, batchSize, maxAttempts int, pollInterval, timeout time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo: repo,
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		pollInterval: pollInterval,
+		lease:        timeout + webhookLeaseMargin,
+	}
+}
+
+// Start chạy vòng lặp gửi webhook cho tới khi ctx bị hủy.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.repo.ProcessDueDeliveries(ctx, d.batchSize, d.maxAttempts, d.lease, webhookBackoff, d.deliverBatch)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Lỗi gửi webhook: %v", err)
+		}
+
+		// Còn delivery tới hạn thì xử lý tiếp ngay, không chờ tick.
+		if err == nil && n > 0 {
+			select {
+			case <-ctx.Done():
+				log.Println("Context bị hủy, dừng webhook dispatcher")
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Context bị hủy, dừng webhook dispatcher")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverBatch gửi song song các delivery trong batch, mỗi endpoint chậm chỉ làm chậm chính nó.
+func (d *WebhookDispatcher) deliverBatch(ctx context.Context, deliveries []*model.WebhookDelivery) []repository.WebhookAttempt {
+	results := make([]repository.WebhookAttempt, len(deliveries))
+	var wg sync.WaitGroup
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func(i int, delivery *model.WebhookDelivery) {
+			defer wg.Done()
+			results[i] = d.deliver(ctx, delivery)
+			if results[i].Err != nil {
+				log.Printf("Lỗi gửi webhook %d tới subscription %s (lần %d): %v",
+					delivery.ID, delivery.SubscriptionID, delivery.Attempts+1, results[i].Err)
+			}
+		}(i, delivery)
+	}
+	wg.Wait()
+	return results
+}
+
+// deliver gửi một delivery; mọi response 2xx được coi là thành công.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) repository.WebhookAttempt {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return repository.WebhookAttempt{Err: err}
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(HeaderWebhookEventID, delivery.EventID)
+	req.Header.Set(HeaderWebhookSignature, "t="+timestamp+",v1="+SignWebhook(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return repository.WebhookAttempt{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return repository.WebhookAttempt{StatusCode: resp.StatusCode}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxErrorBody))
+	return repository.WebhookAttempt{
+		StatusCode: resp.StatusCode,
+		Err:        fmt.Errorf("endpoint trả về %s: %s", resp.Status, bytes.TrimSpace(body)),
+	}
+}
+
+// SignWebhook tính chữ ký hex(HMAC-SHA256(secret, timestamp + "." + payload)).
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff tính thời gian chờ trước lần gửi tiếp theo: 1s, 2s, 4s, ... tối đa webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	if attempts > 20 {
+		return webhookMaxBackoff
+	}
+	d := time.Second << (attempts - 1)
+	if d > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return d
+}
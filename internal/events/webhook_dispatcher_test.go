@@ -0,0 +1,73 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{12, 2048 * time.Second},
+		{13, webhookMaxBackoff},
+		{20, webhookMaxBackoff},
+		{21, webhookMaxBackoff},
+		{1000, webhookMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.attempts); got != tt.want {
+			t.Errorf("webhookBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   string
+		want      string
+	}{
+		{
+			name:      "json payload",
+			secret:    "whsec_test",
+			timestamp: "1700000000",
+			payload:   `{"event":"inventory.updated"}`,
+			want:      "c0ad9d6bbe71d9265234909d2afe4bf55544bcc2170964ee4d55b0d94e7305be",
+		},
+		{
+			name:      "empty payload",
+			secret:    "whsec_test",
+			timestamp: "1700000000",
+			payload:   "",
+			want:      "5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc",
+		},
+		{
+			name:      "timestamp is signed",
+			secret:    "whsec_test",
+			timestamp: "1700000001",
+			payload:   `{"event":"inventory.updated"}`,
+			want:      "071fa804864d80eb8e509489b54c9c5223b325594a85bccf5774bbfc8785fe4d",
+		},
+		{
+			name:      "different secret",
+			secret:    "other",
+			timestamp: "1700000000",
+			payload:   `{"event":"inventory.updated"}`,
+			want:      "a40c8362ca48b2e2940e8fa59bde6442fed71857adcbad7e8c5e38552db4deea",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignWebhook(tt.secret, tt.timestamp, []byte(tt.payload)); got != tt.want {
+				t.Errorf("SignWebhook() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// BatchAdjustInventory áp dụng nhiều thay đổi tồn kho trong một transaction.
+// Ở chế độ all-or-nothing, lỗi của một dòng được trả về cho cả request với message "line N: ...";
+// ở chế độ best-effort, lỗi từng dòng nằm trong results.
+func (s *inventoryGRPCServer) BatchAdjustInventory(ctx context.Context, req *inventorypb.BatchAdjustInventoryRequest) (*inventorypb.BatchAdjustInventoryResponse, error) {
+	log.Printf("gRPC BatchAdjustInventory: lines=%d, best_effort=%t", len(req.GetLines()), req.GetBestEffort())
+
+	correlationID := correlationIDFromContext(ctx)
+	changes := make([]repository.StockChange, len(req.GetLines()))
+	for i, line := range req.GetLines() {
+		if line.GetItemId() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "line %d: item_id is required", i)
+		}
+		changes[i] = repository.StockChange{
+			ItemID:          line.GetItemId(),
+			LocationID:      line.GetLocationId(),
+			Delta:           int(line.GetQuantityChange()),
+			Reason:          model.MovementReason(line.GetReason()),
+			Source:          model.MovementSourceGRPC,
+			CorrelationID:   correlationID,
+			ExpectedVersion: line.GetExpectedVersion(),
+		}
+	}
+
+	var results []service.BatchLineResult
+	resp := &inventorypb.BatchAdjustInventoryResponse{}
+	err := s.runIdempotent(ctx, "grpc:BatchAdjustInventory", req, resp, func(tx *sql.Tx) error {
+		var err error
+		results, err = s.inventorySvc.BatchAdjustTx(ctx, tx, changes, req.GetBestEffort())
+		if err != nil {
+			return err
+		}
+		resp.Results = make([]*inventorypb.BatchAdjustLineResult, len(results))
+		for i, r := range results {
+			line := &inventorypb.BatchAdjustLineResult{
+				Index:      int32(r.Index),
+				ItemId:     r.Change.ItemID,
+				LocationId: r.Change.LocationID,
+			}
+			if r.Err != nil {
+				st := status.Convert(inventoryError(r.Err))
+				line.ErrorCode = st.Code().String()
+				line.ErrorMessage = st.Message()
+			} else {
+				line.Success = true
+				line.Quantity = int32(r.Result.Total)
+				line.LocationQuantity = int32(r.Result.Balance)
+				line.Version = r.Result.Version
+			}
+			resp.Results[i] = line
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	s.inventorySvc.InvalidateBatchCache(ctx, results)
+	return resp, nil
+}
@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// CreateCountSession tạo phiên kiểm kê và chụp on-hand hiện tại làm số lượng kỳ vọng.
+func (s *inventoryGRPCServer) CreateCountSession(ctx context.Context, req *inventorypb.CreateCountSessionRequest) (*inventorypb.CountSessionResponse, error) {
+	in := service.CountSessionInput{LocationID: req.GetLocationId(), Note: req.GetNote(), ItemIDs: req.GetItemIds()}
+	return s.countMutation(ctx, "grpc:CreateCountSession", req, func(tx *sql.Tx) (*model.CountSession, error) {
+		return s.countSvc.CreateCountSessionTx(ctx, tx, in)
+	})
+}
+
+func (s *inventoryGRPCServer) GetCountSession(ctx context.Context, req *inventorypb.GetCountSessionRequest) (*inventorypb.CountSessionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	session, err := s.countSvc.GetCountSession(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.CountSessionResponse{Session: toCountSessionPB(session)}, nil
+}
+
+// ListCountSessions trả về các phiên kiểm kê, mới nhất trước.
+func (s *inventoryGRPCServer) ListCountSessions(ctx context.Context, req *inventorypb.ListCountSessionsRequest) (*inventorypb.ListCountSessionsResponse, error) {
+	sessions, next, err := s.countSvc.ListCountSessions(ctx, repository.CountSessionListOptions{
+		Status:     model.CountSessionStatus(req.GetStatus()),
+		LocationID: req.GetLocationId(),
+		Cursor:     req.GetPageToken(),
+		PageSize:   int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	resp := &inventorypb.ListCountSessionsResponse{NextPageToken: next}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, toCountSessionPB(session))
+	}
+	return resp, nil
+}
+
+// RecordCounts ghi số lượng đếm từ counts, hoặc từ nội dung CSV nếu csv khác rỗng.
+func (s *inventoryGRPCServer) RecordCounts(ctx context.Context, req *inventorypb.RecordCountsRequest) (*inventorypb.CountSessionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if len(req.GetCsv()) > 0 && len(req.GetCounts()) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "chỉ dùng một trong counts hoặc csv")
+	}
+	entries := make([]model.CountEntry, 0, len(req.GetCounts()))
+	for _, e := range req.GetCounts() {
+		entries = append(entries, model.CountEntry{ItemID: e.GetItemId(), CountedQuantity: int(e.GetCountedQuantity())})
+	}
+	if len(req.GetCsv()) > 0 {
+		parsed, err := service.ParseCountsCSV(bytes.NewReader(req.GetCsv()))
+		if err != nil {
+			return nil, inventoryError(err)
+		}
+		entries = parsed
+	}
+	return s.countMutation(ctx, "grpc:RecordCounts", req, func(tx *sql.Tx) (*model.CountSession, error) {
+		return s.countSvc.RecordCountsTx(ctx, tx, req.GetId(), entries)
+	})
+}
+
+// SubmitCountSession post chênh lệch ngay, hoặc chuyển phiên sang pending_approval nếu có dòng vượt ngưỡng.
+func (s *inventoryGRPCServer) SubmitCountSession(ctx context.Context, req *inventorypb.SubmitCountSessionRequest) (*inventorypb.CountSessionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.countMutation(ctx, "grpc:SubmitCountSession", req, func(tx *sql.Tx) (*model.CountSession, error) {
+		return s.countSvc.SubmitCountSessionTx(ctx, tx, req.GetId(), model.MovementSourceGRPC)
+	})
+}
+
+// ApproveCountSession duyệt phiên pending_approval và post các chênh lệch.
+func (s *inventoryGRPCServer) ApproveCountSession(ctx context.Context, req *inventorypb.ApproveCountSessionRequest) (*inventorypb.CountSessionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.countMutation(ctx, "grpc:ApproveCountSession", req, func(tx *sql.Tx) (*model.CountSession, error) {
+		return s.countSvc.ApproveCountSessionTx(ctx, tx, req.GetId(), req.GetApprovedBy(), model.MovementSourceGRPC)
+	})
+}
+
+// CancelCountSession huỷ phiên chưa post.
+func (s *inventoryGRPCServer) CancelCountSession(ctx context.Context, req *inventorypb.CancelCountSessionRequest) (*inventorypb.CountSessionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.countMutation(ctx, "grpc:CancelCountSession", req, func(tx *sql.Tx) (*model.CountSession, error) {
+		return s.countSvc.CancelCountSessionTx(ctx, tx, req.GetId())
+	})
+}
+
+func (s *inventoryGRPCServer) countMutation(ctx context.Context, scope string, req proto.Message, fn func(tx *sql.Tx) (*model.CountSession, error)) (*inventorypb.CountSessionResponse, error) {
+	resp := &inventorypb.CountSessionResponse{}
+	var session *model.CountSession
+	err := s.runIdempotent(ctx, scope, req, resp, func(tx *sql.Tx) error {
+		cs, err := fn(tx)
+		if err != nil {
+			return err
+		}
+		session = cs
+		resp.Session = toCountSessionPB(cs)
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	// Response replay từ idempotency key không chạy fn, nên không có gì mới cần invalidate.
+	if session != nil {
+		s.countSvc.InvalidateCache(ctx, session)
+	}
+	return resp, nil
+}
+
+func toCountSessionPB(cs *model.CountSession) *inventorypb.CountSession {
+	pb := &inventorypb.CountSession{
+		Id:          cs.ID,
+		LocationId:  cs.LocationID,
+		Status:      string(cs.Status),
+		Note:        cs.Note,
+		ApprovedBy:  cs.ApprovedBy,
+		CreatedAt:   cs.CreatedAt.Unix(),
+		UpdatedAt:   cs.UpdatedAt.Unix(),
+		SubmittedAt: unixOrZero(cs.SubmittedAt),
+		ClosedAt:    unixOrZero(cs.ClosedAt),
+	}
+	for _, line := range cs.Lines {
+		pb.Lines = append(pb.Lines, &inventorypb.CountLine{
+			ItemId:           line.ItemID,
+			ExpectedQuantity: int32(line.ExpectedQuantity),
+			CountedQuantity:  optionalInt32(line.CountedQuantity),
+			Variance:         optionalInt32(line.Variance),
+			RequiresApproval: line.RequiresApproval,
+			CountedAt:        unixOrZero(line.CountedAt),
+		})
+	}
+	return pb
+}
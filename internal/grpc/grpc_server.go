@@ -3,96 +3,235 @@ package grpc
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"inventory-service.com/m/internal/grpc/inventorypb" // Đảm bảo đường dẫn này đúng với go_package trong proto.
+	"inventory-service.com/m/internal/model"
 	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
 )
 
 // inventoryGRPCServer triển khai interface InventoryServiceServer được sinh ra từ proto.
 type inventoryGRPCServer struct {
 	inventorypb.UnimplementedInventoryServiceServer
-	db   *sql.DB
-	repo *repository.InventoryRepository
+	db             *sql.DB
+	inventorySvc   *service.InventoryService
+	watcher        *service.InventoryWatcher
+	locationRepo   *repository.LocationRepository
+	movementRepo   *repository.MovementRepository
+	reservationSvc *service.ReservationService
+	transferSvc    *service.TransferService
+	countSvc       *service.CountService
+	idempotency    *service.IdempotencyService
 }
 
 // CreateInventory thực hiện logic tạo mới tồn kho.
 func (s *inventoryGRPCServer) CreateInventory(ctx context.Context, req *inventorypb.CreateInventoryRequest) (*inventorypb.CreateInventoryResponse, error) {
-	log.Printf("Raw request received: %s - %s - %d", req.Id, req.Quantity)
-	if req.Id == "" || req.Quantity < 0 {
-		return &inventorypb.CreateInventoryResponse{
-			Success: false,
-			Message: "No item data received",
-		}, nil
+	log.Printf("gRPC CreateInventory: id=%s, quantity=%d, location_id=%s", req.GetId(), req.GetQuantity(), req.GetLocationId())
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetQuantity() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must not be negative")
 	}
 
-	err := s.repo.CreateInventory(req.Id, req.Quantity)
-
+	resp := &inventorypb.CreateInventoryResponse{}
+	err := s.runIdempotent(ctx, "grpc:CreateInventory", req, resp, func(tx *sql.Tx) error {
+		err := s.inventorySvc.CreateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:        req.GetId(),
+			LocationID:    req.GetLocationId(),
+			Delta:         int(req.GetQuantity()),
+			Source:        model.MovementSourceGRPC,
+			CorrelationID: correlationIDFromContext(ctx),
+			Serialized:    req.GetSerialized(),
+		})
+		if err != nil {
+			return err
+		}
+		resp.Success = true
+		resp.Message = "Inventory created successfully"
+		return nil
+	})
 	if err != nil {
-		fmt.Println("Error creating inventory: ", err.Error())
-		return &inventorypb.CreateInventoryResponse{
-			Success: false,
-			Message: err.Error(),
-		}, nil
-	}
-	fmt.Println("Inventory created successfully")
-	return &inventorypb.CreateInventoryResponse{
-		Success: true,
-		Message: "Inventory created successfully",
-	}, nil
+		return nil, inventoryError(err)
+	}
+	s.inventorySvc.InvalidateCache(ctx, req.GetId())
+	return resp, nil
 }
 
 // UpdateInventory thực hiện logic cập nhật tồn kho.
 func (s *inventoryGRPCServer) UpdateInventory(ctx context.Context, req *inventorypb.UpdateInventoryRequest) (*inventorypb.UpdateInventoryResponse, error) {
-	log.Printf("gRPC UpdateInventory: id=%s, quantity_change=%d", req.GetId(), req.GetQuantityChange())
-	// Ví dụ: Thực hiện cập nhật trong DB.
-	return &inventorypb.UpdateInventoryResponse{
-		Success: true,
-		Message: "Inventory updated successfully",
-	}, nil
+	log.Printf("gRPC UpdateInventory: id=%s, quantity_change=%d, location_id=%s", req.GetId(), req.GetQuantityChange(), req.GetLocationId())
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	lot, err := lotReceiptFromPB(req.GetLot())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+
+	resp := &inventorypb.UpdateInventoryResponse{}
+	err = s.runIdempotent(ctx, "grpc:UpdateInventory", req, resp, func(tx *sql.Tx) error {
+		result, err := s.inventorySvc.UpdateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:          req.GetId(),
+			LocationID:      req.GetLocationId(),
+			Delta:           int(req.GetQuantityChange()),
+			Reason:          model.MovementReason(req.GetReason()),
+			Source:          model.MovementSourceGRPC,
+			CorrelationID:   correlationIDFromContext(ctx),
+			ExpectedVersion: req.GetExpectedVersion(),
+			Lot:             lot,
+		})
+		if err != nil {
+			return err
+		}
+		resp.Success = true
+		resp.Message = "Inventory updated successfully"
+		resp.Version = result.Version
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	s.inventorySvc.InvalidateCache(ctx, req.GetId())
+	return resp, nil
 }
 
 // GetInventory thực hiện truy vấn thông tin tồn kho.
 func (s *inventoryGRPCServer) GetInventory(ctx context.Context, req *inventorypb.GetInventoryRequest) (*inventorypb.GetInventoryResponse, error) {
 	log.Printf("gRPC GetInventory: id=%s", req.GetId())
-	// Ví dụ: Truy vấn DB để lấy số lượng tồn kho, dưới đây là giá trị mẫu.
-	item := &inventorypb.InventoryItem{
-		Id:       req.GetId(),
-		Quantity: 100,
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	item, err := s.inventorySvc.GetInventory(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryError(err)
 	}
 	return &inventorypb.GetInventoryResponse{
-		Item: item,
+		Item: toInventoryItemPB(item),
 	}, nil
 }
 
 func (s *inventoryGRPCServer) GetInventories(ctx context.Context, req *inventorypb.GetInventoriesRequest) (*inventorypb.GetInventoriesResponse, error) {
-	ids := req.Id
+	ids := req.GetId()
 
 	if len(ids) == 0 {
 		return &inventorypb.GetInventoriesResponse{Data: []*inventorypb.InventoryItem{}}, nil
 	}
 
-	items, err := s.repo.GetInventories(ids)
-
+	items, err := s.inventorySvc.GetInventories(ctx, ids)
 	if err != nil {
-		return nil, err
+		return nil, inventoryError(err)
+	}
+
+	data := make([]*inventorypb.InventoryItem, 0, len(items))
+	for _, item := range items {
+		data = append(data, toInventoryItemPB(item))
+	}
+	return &inventorypb.GetInventoriesResponse{Data: data}, nil
+}
+
+// inventoryError ánh xạ lỗi repository sang gRPC status code. Lỗi đã là status error được giữ nguyên.
+func inventoryError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var lineErr *service.BatchLineError
+	if errors.As(err, &lineErr) {
+		// Giữ code và details của lỗi gốc, chỉ thêm vị trí dòng vào message.
+		st := status.Convert(inventoryError(lineErr.Err)).Proto()
+		st.Message = fmt.Sprintf("line %d: %s", lineErr.Index, st.Message)
+		return status.ErrorProto(st)
+	}
+	if st, ok := insufficientStockStatus(err); ok {
+		return st
+	}
+	switch {
+	case errors.Is(err, repository.ErrInventoryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrLocationNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrInventoryAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrVersionMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidBatch), errors.Is(err, service.ErrInvalidReorderPoint),
+		errors.Is(err, service.ErrInvalidLot), errors.Is(err, service.ErrInvalidSerial),
+		errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrInvalidTransfer),
+		errors.Is(err, service.ErrInvalidCount), errors.Is(err, repository.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrLotConflict), errors.Is(err, repository.ErrSerializedItem),
+		errors.Is(err, repository.ErrNotSerialized), errors.Is(err, repository.ErrSerialState),
+		errors.Is(err, repository.ErrTransferState), errors.Is(err, repository.ErrCountSessionState):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrSerialNotFound), errors.Is(err, repository.ErrTransferNotFound),
+		errors.Is(err, repository.ErrCountSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrSerialAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		log.Printf("Inventory error: %v", err)
+		return status.Error(codes.Internal, "internal error")
 	}
+}
 
-	return items, nil
+// toInventoryItemPB chuyển model.InventoryItem sang message proto, kèm số lượng theo location.
+func toInventoryItemPB(item *model.InventoryItem) *inventorypb.InventoryItem {
+	locations := make([]*inventorypb.LocationStock, 0, len(item.Locations))
+	for _, loc := range item.Locations {
+		locations = append(locations, &inventorypb.LocationStock{
+			LocationId:     loc.LocationID,
+			Quantity:       int32(loc.Quantity),
+			OversellPolicy: string(loc.OversellPolicy),
+			BackorderLimit: int32(loc.BackorderLimit),
+			Stock:          toStockBreakdownPB(loc.Stock),
+		})
+	}
+	return &inventorypb.InventoryItem{
+		Id:        item.ID,
+		Quantity:  int32(item.Quantity),
+		Locations: locations,
+		Version:   item.Version,
+
+		OversellPolicy: string(item.OversellPolicy),
+		BackorderLimit: int32(item.BackorderLimit),
+
+		ReorderPoint:    optionalInt32(item.ReorderPoint),
+		SafetyStock:     optionalInt32(item.SafetyStock),
+		StockAlertState: string(item.StockAlertState),
+
+		LotAllocationPolicy: string(item.LotAllocationPolicy),
+		Serialized:          item.Serialized,
+		Stock:               toStockBreakdownPB(item.Stock),
+	}
 }
 
 // StartGRPCServer khởi chạy gRPC server trên cổng cấu hình.
 // Hàm này chạy trong một goroutine và chờ tín hiệu dừng thông qua kênh grpcStop.
-func StartGRPCServer(db *sql.DB, port string, grpcStop chan struct{}) {
+func StartGRPCServer(db *sql.DB, inventorySvc *service.InventoryService, watcher *service.InventoryWatcher, reservationSvc *service.ReservationService, transferSvc *service.TransferService, countSvc *service.CountService, idempotency *service.IdempotencyService, port string, grpcStop chan struct{}) {
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 	grpcServer := grpc.NewServer()
-	inventorypb.RegisterInventoryServiceServer(grpcServer, &inventoryGRPCServer{db: db, repo: repository.NewInventoryRepository(db)})
+	inventorypb.RegisterInventoryServiceServer(grpcServer, &inventoryGRPCServer{
+		db:             db,
+		inventorySvc:   inventorySvc,
+		watcher:        watcher,
+		locationRepo:   repository.NewLocationRepository(db),
+		movementRepo:   repository.NewMovementRepository(db),
+		reservationSvc: reservationSvc,
+		transferSvc:    transferSvc,
+		countSvc:       countSvc,
+		idempotency:    idempotency,
+	})
 	log.Printf("gRPC Inventory Service is running on %s", port)
 
 	// Chạy server trong một goroutine.
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestInventoryError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "not found", err: fmt.Errorf("update: %w", repository.ErrInventoryNotFound), want: codes.NotFound},
+		{name: "unknown location", err: repository.ErrLocationNotFound, want: codes.InvalidArgument},
+		{name: "duplicate item", err: repository.ErrInventoryAlreadyExists, want: codes.AlreadyExists},
+		{name: "stale version", err: &repository.VersionMismatchError{Expected: 2, Current: 3}, want: codes.FailedPrecondition},
+		{name: "insufficient stock", err: &repository.InsufficientStockError{ItemID: "sku-1", Requested: 1}, want: codes.ResourceExhausted},
+		{name: "status error kept", err: status.Error(codes.Aborted, "conflict"), want: codes.Aborted},
+		{name: "unexpected error hidden", err: errors.New("connection reset"), want: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(inventoryError(tt.err))
+			if st.Code() != tt.want {
+				t.Errorf("code = %s, want %s", st.Code(), tt.want)
+			}
+			if tt.want == codes.Internal && st.Message() != "internal error" {
+				t.Errorf("message = %q, internal details must not leak", st.Message())
+			}
+		})
+	}
+}
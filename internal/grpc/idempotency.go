@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// idempotencyKeyFromContext lấy Idempotency-Key từ metadata "idempotency-key", rỗng nếu client không gửi.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("idempotency-key"); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// runIdempotent chạy apply trong một transaction. Nếu request có idempotency key, response resp
+// được lưu cùng transaction; request lặp lại với cùng key nhận lại response đã lưu mà không chạy apply,
+// còn key bị dùng lại với payload khác bị từ chối với InvalidArgument.
+// Lỗi của apply được trả về nguyên vẹn, các lỗi khác là gRPC status error.
+func (s *inventoryGRPCServer) runIdempotent(ctx context.Context, scope string, req, resp proto.Message, apply func(tx *sql.Tx) error) error {
+	key := idempotencyKeyFromContext(ctx)
+	fingerprint := ""
+	if key != "" {
+		payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		fingerprint = service.Fingerprint(payload)
+		if replayed, err := s.replayIdempotent(ctx, scope, key, fingerprint, resp); replayed || err != nil {
+			return err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer tx.Rollback()
+
+	if err := apply(tx); err != nil {
+		return err
+	}
+
+	if key != "" {
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		err = s.idempotency.SaveTx(ctx, tx, &model.IdempotencyRecord{
+			Scope:          scope,
+			Key:            key,
+			Fingerprint:    fingerprint,
+			ResponseStatus: int(codes.OK),
+			ResponseBody:   body,
+		})
+		if errors.Is(err, repository.ErrIdempotencyKeyConflict) {
+			// Request song song cùng key đã commit trước: trả lại response của request đó.
+			tx.Rollback()
+			if replayed, err := s.replayIdempotent(ctx, scope, key, fingerprint, resp); replayed || err != nil {
+				return err
+			}
+			return status.Error(codes.Aborted, "request with this idempotency key is in progress")
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// replayIdempotent nạp response đã lưu của key vào resp. Trả về false nếu key chưa được dùng.
+func (s *inventoryGRPCServer) replayIdempotent(ctx context.Context, scope, key, fingerprint string, resp proto.Message) (bool, error) {
+	rec, err := s.idempotency.Lookup(ctx, scope, key, fingerprint)
+	if errors.Is(err, service.ErrIdempotencyKeyReused) {
+		return false, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	if rec == nil {
+		return false, nil
+	}
+	if err := proto.Unmarshal(rec.ResponseBody, resp); err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	return true, nil
+}
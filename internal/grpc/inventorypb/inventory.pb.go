@@ -22,28 +22,4357 @@ const (
 )
 
 type InventoryItem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Quantity            int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // tổng on-hand trên tất cả location
+	Locations           []*LocationStock       `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	Version             int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                                    // tăng mỗi lần thay đổi, dùng cho optimistic concurrency
+	OversellPolicy      string                 `protobuf:"bytes,5,opt,name=oversell_policy,json=oversellPolicy,proto3" json:"oversell_policy,omitempty"` // deny, backorder, unlimited; mặc định cho location mới
+	BackorderLimit      int32                  `protobuf:"varint,6,opt,name=backorder_limit,json=backorderLimit,proto3" json:"backorder_limit,omitempty"`
+	ReorderPoint        *int32                 `protobuf:"varint,7,opt,name=reorder_point,json=reorderPoint,proto3,oneof" json:"reorder_point,omitempty"` // không có = không cảnh báo tồn kho thấp
+	SafetyStock         *int32                 `protobuf:"varint,8,opt,name=safety_stock,json=safetyStock,proto3,oneof" json:"safety_stock,omitempty"`
+	StockAlertState     string                 `protobuf:"bytes,9,opt,name=stock_alert_state,json=stockAlertState,proto3" json:"stock_alert_state,omitempty"`              // ok, low, out
+	LotAllocationPolicy string                 `protobuf:"bytes,10,opt,name=lot_allocation_policy,json=lotAllocationPolicy,proto3" json:"lot_allocation_policy,omitempty"` // fefo, fifo
+	Serialized          bool                   `protobuf:"varint,11,opt,name=serialized,proto3" json:"serialized,omitempty"`                                               // true = quantity là số serial available
+	Stock               *StockBreakdown        `protobuf:"bytes,12,opt,name=stock,proto3" json:"stock,omitempty"`                                                          // tổng theo trạng thái trên tất cả location
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InventoryItem) Reset() {
+	*x = InventoryItem{}
+	mi := &file_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryItem) ProtoMessage() {}
+
+func (x *InventoryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryItem.ProtoReflect.Descriptor instead.
+func (*InventoryItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InventoryItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetLocations() []*LocationStock {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *InventoryItem) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetOversellPolicy() string {
+	if x != nil {
+		return x.OversellPolicy
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetBackorderLimit() int32 {
+	if x != nil {
+		return x.BackorderLimit
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetReorderPoint() int32 {
+	if x != nil && x.ReorderPoint != nil {
+		return *x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetSafetyStock() int32 {
+	if x != nil && x.SafetyStock != nil {
+		return *x.SafetyStock
+	}
+	return 0
+}
+
+func (x *InventoryItem) GetStockAlertState() string {
+	if x != nil {
+		return x.StockAlertState
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetLotAllocationPolicy() string {
+	if x != nil {
+		return x.LotAllocationPolicy
+	}
+	return ""
+}
+
+func (x *InventoryItem) GetSerialized() bool {
+	if x != nil {
+		return x.Serialized
+	}
+	return false
+}
+
+func (x *InventoryItem) GetStock() *StockBreakdown {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+type LocationStock struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LocationId     string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Quantity       int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`                                  // on-hand
+	OversellPolicy string                 `protobuf:"bytes,3,opt,name=oversell_policy,json=oversellPolicy,proto3" json:"oversell_policy,omitempty"` // chính sách hiệu lực tại location
+	BackorderLimit int32                  `protobuf:"varint,4,opt,name=backorder_limit,json=backorderLimit,proto3" json:"backorder_limit,omitempty"`
+	Stock          *StockBreakdown        `protobuf:"bytes,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LocationStock) Reset() {
+	*x = LocationStock{}
+	mi := &file_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationStock) ProtoMessage() {}
+
+func (x *LocationStock) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationStock.ProtoReflect.Descriptor instead.
+func (*LocationStock) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LocationStock) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *LocationStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LocationStock) GetOversellPolicy() string {
+	if x != nil {
+		return x.OversellPolicy
+	}
+	return ""
+}
+
+func (x *LocationStock) GetBackorderLimit() int32 {
+	if x != nil {
+		return x.BackorderLimit
+	}
+	return 0
+}
+
+func (x *LocationStock) GetStock() *StockBreakdown {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+// StockBreakdown là tồn kho theo trạng thái. reserved là phần on-hand đang được giữ cho đơn hàng;
+// chỉ available_to_promise = on_hand - reserved là bán được.
+type StockBreakdown struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OnHand             int32                  `protobuf:"varint,1,opt,name=on_hand,json=onHand,proto3" json:"on_hand,omitempty"`
+	Reserved           int32                  `protobuf:"varint,2,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	AvailableToPromise int32                  `protobuf:"varint,3,opt,name=available_to_promise,json=availableToPromise,proto3" json:"available_to_promise,omitempty"`
+	InTransit          int32                  `protobuf:"varint,4,opt,name=in_transit,json=inTransit,proto3" json:"in_transit,omitempty"`
+	Quarantined        int32                  `protobuf:"varint,5,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Damaged            int32                  `protobuf:"varint,6,opt,name=damaged,proto3" json:"damaged,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StockBreakdown) Reset() {
+	*x = StockBreakdown{}
+	mi := &file_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockBreakdown) ProtoMessage() {}
+
+func (x *StockBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockBreakdown.ProtoReflect.Descriptor instead.
+func (*StockBreakdown) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StockBreakdown) GetOnHand() int32 {
+	if x != nil {
+		return x.OnHand
+	}
+	return 0
+}
+
+func (x *StockBreakdown) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *StockBreakdown) GetAvailableToPromise() int32 {
+	if x != nil {
+		return x.AvailableToPromise
+	}
+	return 0
+}
+
+func (x *StockBreakdown) GetInTransit() int32 {
+	if x != nil {
+		return x.InTransit
+	}
+	return 0
+}
+
+func (x *StockBreakdown) GetQuarantined() int32 {
+	if x != nil {
+		return x.Quarantined
+	}
+	return 0
+}
+
+func (x *StockBreakdown) GetDamaged() int32 {
+	if x != nil {
+		return x.Damaged
+	}
+	return 0
+}
+
+type CreateInventoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location mặc định
+	Serialized    bool                   `protobuf:"varint,4,opt,name=serialized,proto3" json:"serialized,omitempty"`                  // theo dõi theo serial; quantity phải là 0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InventoryItem) Reset() {
-	*x = InventoryItem{}
-	mi := &file_inventory_proto_msgTypes[0]
+func (x *CreateInventoryRequest) Reset() {
+	*x = CreateInventoryRequest{}
+	mi := &file_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInventoryRequest) ProtoMessage() {}
+
+func (x *CreateInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInventoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateInventoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateInventoryRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateInventoryRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *CreateInventoryRequest) GetSerialized() bool {
+	if x != nil {
+		return x.Serialized
+	}
+	return false
+}
+
+type CreateInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInventoryResponse) Reset() {
+	*x = CreateInventoryResponse{}
+	mi := &file_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInventoryResponse) ProtoMessage() {}
+
+func (x *CreateInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInventoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateInventoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateInventoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UpdateInventoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuantityChange  int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`
+	LocationId      string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`                 // rỗng = location mặc định
+	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // mã lý do, rỗng = "adjustment"
+	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // khác 0 = chỉ cập nhật khi version khớp
+	Lot             *LotReceipt            `protobuf:"bytes,6,opt,name=lot,proto3" json:"lot,omitempty"`                                                 // ghi số lượng nhập (quantity_change > 0) vào lô; thay đổi giảm tồn kho tự lấy theo lot allocation policy
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateInventoryRequest) Reset() {
+	*x = UpdateInventoryRequest{}
+	mi := &file_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInventoryRequest) ProtoMessage() {}
+
+func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInventoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateInventoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *UpdateInventoryRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateInventoryRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateInventoryRequest) GetLot() *LotReceipt {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+type UpdateInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInventoryResponse) Reset() {
+	*x = UpdateInventoryResponse{}
+	mi := &file_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInventoryResponse) ProtoMessage() {}
+
+func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInventoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateInventoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateInventoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateInventoryResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// BatchAdjustInventory áp dụng tất cả các dòng trong một transaction, mỗi dòng phát một event.
+type BatchAdjustLine struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId      string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location mặc định
+	QuantityChange  int32                  `protobuf:"varint,3,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`
+	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // khác 0 = chỉ áp dụng khi version khớp
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchAdjustLine) Reset() {
+	*x = BatchAdjustLine{}
+	mi := &file_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAdjustLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAdjustLine) ProtoMessage() {}
+
+func (x *BatchAdjustLine) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAdjustLine.ProtoReflect.Descriptor instead.
+func (*BatchAdjustLine) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchAdjustLine) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *BatchAdjustLine) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *BatchAdjustLine) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *BatchAdjustLine) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchAdjustLine) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type BatchAdjustInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*BatchAdjustLine     `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`                              // tối đa 500 dòng
+	BestEffort    bool                   `protobuf:"varint,2,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"` // false = all-or-nothing: một dòng lỗi làm hỏng cả batch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAdjustInventoryRequest) Reset() {
+	*x = BatchAdjustInventoryRequest{}
+	mi := &file_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAdjustInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAdjustInventoryRequest) ProtoMessage() {}
+
+func (x *BatchAdjustInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAdjustInventoryRequest.ProtoReflect.Descriptor instead.
+func (*BatchAdjustInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchAdjustInventoryRequest) GetLines() []*BatchAdjustLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *BatchAdjustInventoryRequest) GetBestEffort() bool {
+	if x != nil {
+		return x.BestEffort
+	}
+	return false
+}
+
+type BatchAdjustLineResult struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Index            int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // vị trí dòng trong request
+	ItemId           string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId       string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Success          bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorCode        string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // mã gRPC của lỗi, ví dụ ResourceExhausted
+	ErrorMessage     string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Quantity         int32                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`                                         // tổng số lượng của item sau thay đổi
+	LocationQuantity int32                  `protobuf:"varint,8,opt,name=location_quantity,json=locationQuantity,proto3" json:"location_quantity,omitempty"` // số lượng tại location sau thay đổi
+	Version          int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BatchAdjustLineResult) Reset() {
+	*x = BatchAdjustLineResult{}
+	mi := &file_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAdjustLineResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAdjustLineResult) ProtoMessage() {}
+
+func (x *BatchAdjustLineResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAdjustLineResult.ProtoReflect.Descriptor instead.
+func (*BatchAdjustLineResult) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchAdjustLineResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchAdjustLineResult) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *BatchAdjustLineResult) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *BatchAdjustLineResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchAdjustLineResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *BatchAdjustLineResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *BatchAdjustLineResult) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BatchAdjustLineResult) GetLocationQuantity() int32 {
+	if x != nil {
+		return x.LocationQuantity
+	}
+	return 0
+}
+
+func (x *BatchAdjustLineResult) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type BatchAdjustInventoryResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*BatchAdjustLineResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // theo thứ tự dòng trong request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAdjustInventoryResponse) Reset() {
+	*x = BatchAdjustInventoryResponse{}
+	mi := &file_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAdjustInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAdjustInventoryResponse) ProtoMessage() {}
+
+func (x *BatchAdjustInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAdjustInventoryResponse.ProtoReflect.Descriptor instead.
+func (*BatchAdjustInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchAdjustInventoryResponse) GetResults() []*BatchAdjustLineResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// WatchInventory gửi trạng thái ban đầu của các item rồi stream mỗi thay đổi sau đó.
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemIds       []string               `protobuf:"bytes,1,rep,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty"`             // tổng item_ids và prefixes tối đa 200
+	ResumeToken   string                 `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // resume_token của change cuối cùng đã nhận; rỗng = nhận snapshot
+	Prefixes      []string               `protobuf:"bytes,3,rep,name=prefixes,proto3" json:"prefixes,omitempty"`                          // mọi item có ID bắt đầu bằng prefix; không có snapshot, chỉ nhận thay đổi
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchRequest) GetItemIds() []string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+type InventoryChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // snapshot, updated, deleted
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Item          *InventoryItem         `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"` // không có khi deleted, hoặc snapshot của item chưa tồn tại
+	ResumeToken   string                 `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryChange) Reset() {
+	*x = InventoryChange{}
+	mi := &file_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryChange) ProtoMessage() {}
+
+func (x *InventoryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryChange.ProtoReflect.Descriptor instead.
+func (*InventoryChange) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InventoryChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *InventoryChange) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *InventoryChange) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *InventoryChange) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type GetInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryRequest) Reset() {
+	*x = GetInventoryRequest{}
+	mi := &file_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryRequest) ProtoMessage() {}
+
+func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInventoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetInventoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []string               `protobuf:"bytes,1,rep,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoriesRequest) Reset() {
+	*x = GetInventoriesRequest{}
+	mi := &file_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoriesRequest) ProtoMessage() {}
+
+func (x *GetInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*GetInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetInventoriesRequest) GetId() []string {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoryResponse) Reset() {
+	*x = GetInventoryResponse{}
+	mi := &file_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoryResponse) ProtoMessage() {}
+
+func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
+func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetInventoryResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type GetInventoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*InventoryItem       `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInventoriesResponse) Reset() {
+	*x = GetInventoriesResponse{}
+	mi := &file_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInventoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInventoriesResponse) ProtoMessage() {}
+
+func (x *GetInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetInventoriesResponse) GetData() []*InventoryItem {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds
+	LocationId    string                 `protobuf:"bytes,7,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *Reservation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Reservation) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type ReserveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 0 = dùng TTL mặc định của service
+	LocationId    string                 `protobuf:"bytes,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`  // rỗng = location mặc định
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveRequest) Reset() {
+	*x = ReserveRequest{}
+	mi := &file_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveRequest) ProtoMessage() {}
+
+func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveRequest.ProtoReflect.Descriptor instead.
+func (*ReserveRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReserveRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type ReserveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveResponse) Reset() {
+	*x = ReserveResponse{}
+	mi := &file_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveResponse) ProtoMessage() {}
+
+func (x *ReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveResponse.ProtoReflect.Descriptor instead.
+func (*ReserveResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReserveResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type ConfirmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmRequest) Reset() {
+	*x = ConfirmRequest{}
+	mi := &file_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmRequest) ProtoMessage() {}
+
+func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfirmRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ConfirmResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmResponse) Reset() {
+	*x = ConfirmResponse{}
+	mi := &file_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmResponse) ProtoMessage() {}
+
+func (x *ConfirmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConfirmResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type ReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRequest) Reset() {
+	*x = ReleaseRequest{}
+	mi := &file_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRequest) ProtoMessage() {}
+
+func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReleaseRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseResponse) Reset() {
+	*x = ReleaseResponse{}
+	mi := &file_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseResponse) ProtoMessage() {}
+
+func (x *ReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Location) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLocationRequest) Reset() {
+	*x = CreateLocationRequest{}
+	mi := &file_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLocationRequest) ProtoMessage() {}
+
+func (x *CreateLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLocationRequest.ProtoReflect.Descriptor instead.
+func (*CreateLocationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateLocationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateLocationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLocationResponse) Reset() {
+	*x = CreateLocationResponse{}
+	mi := &file_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLocationResponse) ProtoMessage() {}
+
+func (x *CreateLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLocationResponse.ProtoReflect.Descriptor instead.
+func (*CreateLocationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateLocationResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type ListLocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsRequest) Reset() {
+	*x = ListLocationsRequest{}
+	mi := &file_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsRequest) ProtoMessage() {}
+
+func (x *ListLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+type ListLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsResponse) Reset() {
+	*x = ListLocationsResponse{}
+	mi := &file_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsResponse) ProtoMessage() {}
+
+func (x *ListLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListLocationsResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Delta         int32                  `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	Balance       int32                  `protobuf:"varint,5,opt,name=balance,proto3" json:"balance,omitempty"`                               // số lượng của stock_state tại location sau thay đổi
+	TotalBalance  int32                  `protobuf:"varint,6,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"` // tổng số lượng của stock_state trên mọi location sau thay đổi
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Source        string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"` // http, grpc, kafka
+	CorrelationId string                 `protobuf:"bytes,9,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`         // unix seconds
+	SerialNumber  string                 `protobuf:"bytes,11,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"` // rỗng nếu movement không gắn với serial
+	StockState    string                 `protobuf:"bytes,12,opt,name=stock_state,json=stockState,proto3" json:"stock_state,omitempty"`       // on_hand, in_transit, quarantined, damaged
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StockMovement) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockMovement) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *StockMovement) GetTotalBalance() int32 {
+	if x != nil {
+		return x.TotalBalance
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StockMovement) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *StockMovement) GetStockState() string {
+	if x != nil {
+		return x.StockState
+	}
+	return ""
+}
+
+type ListMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // tuỳ chọn
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // mặc định 50, tối đa 500
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`    // lấy từ next_page_token của trang trước
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsRequest) Reset() {
+	*x = ListMovementsRequest{}
+	mi := &file_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsRequest) ProtoMessage() {}
+
+func (x *ListMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListMovementsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // rỗng = không còn trang tiếp theo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsResponse) Reset() {
+	*x = ListMovementsResponse{}
+	mi := &file_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsResponse) ProtoMessage() {}
+
+func (x *ListMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListMovementsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SetOversellPolicy đặt chính sách oversell cho item; location_id rỗng = mặc định của item và mọi location.
+type SetOversellPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ItemId         string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId     string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Policy         string                 `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`                                        // deny, backorder, unlimited
+	BackorderLimit int32                  `protobuf:"varint,4,opt,name=backorder_limit,json=backorderLimit,proto3" json:"backorder_limit,omitempty"` // chỉ dùng với backorder
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetOversellPolicyRequest) Reset() {
+	*x = SetOversellPolicyRequest{}
+	mi := &file_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOversellPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOversellPolicyRequest) ProtoMessage() {}
+
+func (x *SetOversellPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOversellPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetOversellPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetOversellPolicyRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetOversellPolicyRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *SetOversellPolicyRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *SetOversellPolicyRequest) GetBackorderLimit() int32 {
+	if x != nil {
+		return x.BackorderLimit
+	}
+	return 0
+}
+
+type SetOversellPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOversellPolicyResponse) Reset() {
+	*x = SetOversellPolicyResponse{}
+	mi := &file_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOversellPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOversellPolicyResponse) ProtoMessage() {}
+
+func (x *SetOversellPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOversellPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetOversellPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetOversellPolicyResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// SetReorderPoint đặt ngưỡng cảnh báo tồn kho; khi tồn kho chạm ngưỡng, cảnh báo được publish lên topic cảnh báo.
+type SetReorderPointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ReorderPoint  *int32                 `protobuf:"varint,2,opt,name=reorder_point,json=reorderPoint,proto3,oneof" json:"reorder_point,omitempty"` // không có = tắt cảnh báo
+	SafetyStock   *int32                 `protobuf:"varint,3,opt,name=safety_stock,json=safetyStock,proto3,oneof" json:"safety_stock,omitempty"`    // không được lớn hơn reorder_point
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderPointRequest) Reset() {
+	*x = SetReorderPointRequest{}
+	mi := &file_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderPointRequest) ProtoMessage() {}
+
+func (x *SetReorderPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderPointRequest.ProtoReflect.Descriptor instead.
+func (*SetReorderPointRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetReorderPointRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetReorderPointRequest) GetReorderPoint() int32 {
+	if x != nil && x.ReorderPoint != nil {
+		return *x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *SetReorderPointRequest) GetSafetyStock() int32 {
+	if x != nil && x.SafetyStock != nil {
+		return *x.SafetyStock
+	}
+	return 0
+}
+
+type SetReorderPointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderPointResponse) Reset() {
+	*x = SetReorderPointResponse{}
+	mi := &file_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderPointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderPointResponse) ProtoMessage() {}
+
+func (x *SetReorderPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderPointResponse.ProtoReflect.Descriptor instead.
+func (*SetReorderPointResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetReorderPointResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// LotReceipt là lô của một lần nhập hàng; ngày có dạng YYYY-MM-DD, rỗng = không có.
+type LotReceipt struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LotNumber      string                 `protobuf:"bytes,1,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ManufacturedAt string                 `protobuf:"bytes,2,opt,name=manufactured_at,json=manufacturedAt,proto3" json:"manufactured_at,omitempty"`
+	ExpiresAt      string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LotReceipt) Reset() {
+	*x = LotReceipt{}
+	mi := &file_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotReceipt) ProtoMessage() {}
+
+func (x *LotReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotReceipt.ProtoReflect.Descriptor instead.
+func (*LotReceipt) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LotReceipt) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *LotReceipt) GetManufacturedAt() string {
+	if x != nil {
+		return x.ManufacturedAt
+	}
+	return ""
+}
+
+func (x *LotReceipt) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type InventoryLot struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId         string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId     string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LotNumber      string                 `protobuf:"bytes,4,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ManufacturedAt string                 `protobuf:"bytes,5,opt,name=manufactured_at,json=manufacturedAt,proto3" json:"manufactured_at,omitempty"` // YYYY-MM-DD, rỗng = không có
+	ExpiresAt      string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                // YYYY-MM-DD, rỗng = không có
+	Quantity       int32                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReceivedAt     int64                  `protobuf:"varint,8,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"` // unix seconds
+	StockState     string                 `protobuf:"bytes,9,opt,name=stock_state,json=stockState,proto3" json:"stock_state,omitempty"`  // on_hand, in_transit, quarantined hoặc damaged; chỉ lô on_hand được allocate
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InventoryLot) Reset() {
+	*x = InventoryLot{}
+	mi := &file_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryLot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryLot) ProtoMessage() {}
+
+func (x *InventoryLot) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryLot.ProtoReflect.Descriptor instead.
+func (*InventoryLot) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *InventoryLot) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *InventoryLot) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *InventoryLot) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *InventoryLot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *InventoryLot) GetManufacturedAt() string {
+	if x != nil {
+		return x.ManufacturedAt
+	}
+	return ""
+}
+
+func (x *InventoryLot) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *InventoryLot) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InventoryLot) GetReceivedAt() int64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
+func (x *InventoryLot) GetStockState() string {
+	if x != nil {
+		return x.StockState
+	}
+	return ""
+}
+
+// ListLots trả về các lô của item theo thứ tự sẽ được lấy hàng.
+type ListLotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`        // tuỳ chọn
+	IncludeEmpty  bool                   `protobuf:"varint,3,opt,name=include_empty,json=includeEmpty,proto3" json:"include_empty,omitempty"` // gồm cả lô đã hết hàng
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsRequest) Reset() {
+	*x = ListLotsRequest{}
+	mi := &file_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsRequest) ProtoMessage() {}
+
+func (x *ListLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListLotsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListLotsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListLotsRequest) GetIncludeEmpty() bool {
+	if x != nil {
+		return x.IncludeEmpty
+	}
+	return false
+}
+
+type ListLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lots          []*InventoryLot        `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsResponse) Reset() {
+	*x = ListLotsResponse{}
+	mi := &file_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsResponse) ProtoMessage() {}
+
+func (x *ListLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListLotsResponse) GetLots() []*InventoryLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+// ListExpiringLots trả về các lô còn hàng hết hạn trong within_days ngày tới, kể cả lô đã hết hạn.
+type ListExpiringLotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithinDays    int32                  `protobuf:"varint,1,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // tuỳ chọn
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // mặc định 100, tối đa 1000
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringLotsRequest) Reset() {
+	*x = ListExpiringLotsRequest{}
+	mi := &file_inventory_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringLotsRequest) ProtoMessage() {}
+
+func (x *ListExpiringLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListExpiringLotsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListExpiringLotsRequest) GetWithinDays() int32 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+func (x *ListExpiringLotsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListExpiringLotsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExpiringLotsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExpiringLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lots          []*InventoryLot        `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // rỗng = không còn trang tiếp theo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringLotsResponse) Reset() {
+	*x = ListExpiringLotsResponse{}
+	mi := &file_inventory_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringLotsResponse) ProtoMessage() {}
+
+func (x *ListExpiringLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListExpiringLotsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListExpiringLotsResponse) GetLots() []*InventoryLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *ListExpiringLotsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SetLotAllocationPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Policy        string                 `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"` // fefo, fifo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLotAllocationPolicyRequest) Reset() {
+	*x = SetLotAllocationPolicyRequest{}
+	mi := &file_inventory_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLotAllocationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLotAllocationPolicyRequest) ProtoMessage() {}
+
+func (x *SetLotAllocationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLotAllocationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetLotAllocationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetLotAllocationPolicyRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *SetLotAllocationPolicyRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+type SetLotAllocationPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLotAllocationPolicyResponse) Reset() {
+	*x = SetLotAllocationPolicyResponse{}
+	mi := &file_inventory_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLotAllocationPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLotAllocationPolicyResponse) ProtoMessage() {}
+
+func (x *SetLotAllocationPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLotAllocationPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetLotAllocationPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetLotAllocationPolicyResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// InventorySerial là trạng thái hiện tại của một serial: available, reserved, shipped.
+type InventorySerial struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	OrderId       string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventorySerial) Reset() {
+	*x = InventorySerial{}
+	mi := &file_inventory_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventorySerial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventorySerial) ProtoMessage() {}
+
+func (x *InventorySerial) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventorySerial.ProtoReflect.Descriptor instead.
+func (*InventorySerial) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *InventorySerial) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *InventorySerial) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *InventorySerial) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *InventorySerial) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InventorySerial) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *InventorySerial) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Các thao tác serial xử lý mọi serial trong một transaction: tất cả thành công hoặc không serial nào thay đổi.
+type ReceiveSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location mặc định
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveSerialsRequest) Reset() {
+	*x = ReceiveSerialsRequest{}
+	mi := &file_inventory_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveSerialsRequest) ProtoMessage() {}
+
+func (x *ReceiveSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReceiveSerialsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReceiveSerialsRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReceiveSerialsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type ReserveSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveSerialsRequest) Reset() {
+	*x = ReserveSerialsRequest{}
+	mi := &file_inventory_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSerialsRequest) ProtoMessage() {}
+
+func (x *ReserveSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReserveSerialsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReserveSerialsRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReserveSerialsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ShipSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // serial đang giữ cho đơn khác sẽ bị từ chối
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipSerialsRequest) Reset() {
+	*x = ShipSerialsRequest{}
+	mi := &file_inventory_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipSerialsRequest) ProtoMessage() {}
+
+func (x *ShipSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ShipSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ShipSerialsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ShipSerialsRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ShipSerialsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// ReturnSerials nhận lại serial đã xuất, hoặc huỷ giữ serial đang reserved.
+type ReturnSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumbers []string               `protobuf:"bytes,2,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location cũ của serial
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnSerialsRequest) Reset() {
+	*x = ReturnSerialsRequest{}
+	mi := &file_inventory_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnSerialsRequest) ProtoMessage() {}
+
+func (x *ReturnSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ReturnSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReturnSerialsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReturnSerialsRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ReturnSerialsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type SerialOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serials       []*InventorySerial     `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerialOperationResponse) Reset() {
+	*x = SerialOperationResponse{}
+	mi := &file_inventory_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerialOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialOperationResponse) ProtoMessage() {}
+
+func (x *SerialOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialOperationResponse.ProtoReflect.Descriptor instead.
+func (*SerialOperationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SerialOperationResponse) GetSerials() []*InventorySerial {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+type ListSerialsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                           // tuỳ chọn: available, reserved, shipped
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // tuỳ chọn
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // mặc định 100, tối đa 1000
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialsRequest) Reset() {
+	*x = ListSerialsRequest{}
+	mi := &file_inventory_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialsRequest) ProtoMessage() {}
+
+func (x *ListSerialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialsRequest.ProtoReflect.Descriptor instead.
+func (*ListSerialsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListSerialsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListSerialsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListSerialsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListSerialsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSerialsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSerialsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serials       []*InventorySerial     `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // rỗng = không còn trang tiếp theo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSerialsResponse) Reset() {
+	*x = ListSerialsResponse{}
+	mi := &file_inventory_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSerialsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSerialsResponse) ProtoMessage() {}
+
+func (x *ListSerialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSerialsResponse.ProtoReflect.Descriptor instead.
+func (*ListSerialsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListSerialsResponse) GetSerials() []*InventorySerial {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *ListSerialsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type TraceSerialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceSerialRequest) Reset() {
+	*x = TraceSerialRequest{}
+	mi := &file_inventory_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSerialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSerialRequest) ProtoMessage() {}
+
+func (x *TraceSerialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSerialRequest.ProtoReflect.Descriptor instead.
+func (*TraceSerialRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *TraceSerialRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *TraceSerialRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+// TraceSerialResponse là vòng đời của serial từ sổ cái movement, cũ nhất trước.
+type TraceSerialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serial        *InventorySerial       `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"` // không có nếu item đã bị xoá
+	Movements     []*StockMovement       `protobuf:"bytes,2,rep,name=movements,proto3" json:"movements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceSerialResponse) Reset() {
+	*x = TraceSerialResponse{}
+	mi := &file_inventory_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSerialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSerialResponse) ProtoMessage() {}
+
+func (x *TraceSerialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSerialResponse.ProtoReflect.Descriptor instead.
+func (*TraceSerialResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TraceSerialResponse) GetSerial() *InventorySerial {
+	if x != nil {
+		return x.Serial
+	}
+	return nil
+}
+
+func (x *TraceSerialResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+// TransitionStock chuyển hàng giữa các trạng thái on_hand, quarantined và damaged tại một location,
+// ví dụ quarantined → on_hand sau khi QA đạt. Hàng lấy từ on_hand phải nằm trong available_to_promise.
+type TransitionStockRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	LocationId      string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location mặc định
+	From            string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To              string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Quantity        int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                           // rỗng = "state_transition"
+	ExpectedVersion int64                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // khác 0 = chỉ chuyển khi version khớp
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransitionStockRequest) Reset() {
+	*x = TransitionStockRequest{}
+	mi := &file_inventory_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionStockRequest) ProtoMessage() {}
+
+func (x *TransitionStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionStockRequest.ProtoReflect.Descriptor instead.
+func (*TransitionStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TransitionStockRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *TransitionStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *TransitionStockRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TransitionStockRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TransitionStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransitionStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransitionStockRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type TransitionStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransitionStockResponse) Reset() {
+	*x = TransitionStockResponse{}
+	mi := &file_inventory_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionStockResponse) ProtoMessage() {}
+
+func (x *TransitionStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionStockResponse.ProtoReflect.Descriptor instead.
+func (*TransitionStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *TransitionStockResponse) GetItem() *InventoryItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// TransferOrder là lệnh chuyển kho: draft → shipped → partially_received → received, hoặc cancelled.
+// Khi xuất, hàng bị trừ ở nguồn và nằm ở in_transit của đích cho tới khi được nhận.
+type TransferOrder struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceLocationId      string                 `protobuf:"bytes,2,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`
+	DestinationLocationId string                 `protobuf:"bytes,3,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"`
+	Status                string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Note                  string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	Lines                 []*TransferLine        `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt             int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix seconds
+	UpdatedAt             int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ShippedAt             int64                  `protobuf:"varint,9,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"` // 0 = chưa xuất
+	ClosedAt              int64                  `protobuf:"varint,10,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`   // 0 = chưa received/cancelled
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TransferOrder) Reset() {
+	*x = TransferOrder{}
+	mi := &file_inventory_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOrder) ProtoMessage() {}
+
+func (x *TransferOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOrder.ProtoReflect.Descriptor instead.
+func (*TransferOrder) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TransferOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferOrder) GetSourceLocationId() string {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return ""
+}
+
+func (x *TransferOrder) GetDestinationLocationId() string {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return ""
+}
+
+func (x *TransferOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransferOrder) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *TransferOrder) GetLines() []*TransferLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *TransferOrder) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *TransferOrder) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *TransferOrder) GetShippedAt() int64 {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return 0
+}
+
+func (x *TransferOrder) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return 0
+}
+
+type TransferLine struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ItemId           string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity         int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`                                         // số lượng xuất
+	ReceivedQuantity int32                  `protobuf:"varint,3,opt,name=received_quantity,json=receivedQuantity,proto3" json:"received_quantity,omitempty"` // tổng đã nhận
+	Discrepancy      int32                  `protobuf:"varint,4,opt,name=discrepancy,proto3" json:"discrepancy,omitempty"`                                   // quantity - received_quantity khi lệnh đóng: dương là thiếu, âm là thừa
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TransferLine) Reset() {
+	*x = TransferLine{}
+	mi := &file_inventory_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLine) ProtoMessage() {}
+
+func (x *TransferLine) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLine.ProtoReflect.Descriptor instead.
+func (*TransferLine) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *TransferLine) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *TransferLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferLine) GetReceivedQuantity() int32 {
+	if x != nil {
+		return x.ReceivedQuantity
+	}
+	return 0
+}
+
+func (x *TransferLine) GetDiscrepancy() int32 {
+	if x != nil {
+		return x.Discrepancy
+	}
+	return 0
+}
+
+type TransferReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferReceipt) Reset() {
+	*x = TransferReceipt{}
+	mi := &file_inventory_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferReceipt) ProtoMessage() {}
+
+func (x *TransferReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferReceipt.ProtoReflect.Descriptor instead.
+func (*TransferReceipt) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TransferReceipt) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *TransferReceipt) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CreateTransferRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SourceLocationId      string                 `protobuf:"bytes,1,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`                // rỗng = location mặc định
+	DestinationLocationId string                 `protobuf:"bytes,2,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"` // rỗng = location mặc định
+	Note                  string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	Lines                 []*TransferReceipt     `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"` // tối đa 500 item
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateTransferRequest) Reset() {
+	*x = CreateTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferRequest) ProtoMessage() {}
+
+func (x *CreateTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateTransferRequest) GetSourceLocationId() string {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetDestinationLocationId() string {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetLines() []*TransferReceipt {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type GetTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferRequest) Reset() {
+	*x = GetTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferRequest) ProtoMessage() {}
+
+func (x *GetTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetTransferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                           // tuỳ chọn
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // tuỳ chọn: lệnh có nguồn hoặc đích là location này
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // mặc định 50, tối đa 500
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersRequest) Reset() {
+	*x = ListTransfersRequest{}
+	mi := &file_inventory_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersRequest) ProtoMessage() {}
+
+func (x *ListTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListTransfersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransfersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*TransferOrder       `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`                                // mới nhất trước
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // rỗng = không còn trang tiếp theo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersResponse) Reset() {
+	*x = ListTransfersResponse{}
+	mi := &file_inventory_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersResponse) ProtoMessage() {}
+
+func (x *ListTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListTransfersResponse) GetTransfers() []*TransferOrder {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *ListTransfersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ShipTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipTransferRequest) Reset() {
+	*x = ShipTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipTransferRequest) ProtoMessage() {}
+
+func (x *ShipTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipTransferRequest.ProtoReflect.Descriptor instead.
+func (*ShipTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ShipTransferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ReceiveTransfer ghi một lần nhận hàng; lệnh đóng khi mọi dòng đã nhận đủ hoặc close = true.
+type ReceiveTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Lines         []*TransferReceipt     `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Close         bool                   `protobuf:"varint,3,opt,name=close,proto3" json:"close,omitempty"` // đóng lệnh, ghi phần chưa nhận là thiếu
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveTransferRequest) Reset() {
+	*x = ReceiveTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveTransferRequest) ProtoMessage() {}
+
+func (x *ReceiveTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ReceiveTransferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReceiveTransferRequest) GetLines() []*TransferReceipt {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReceiveTransferRequest) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+// CancelTransfer huỷ lệnh draft, hoặc lệnh đã xuất nhưng chưa nhận (hàng trả về nguồn).
+type CancelTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTransferRequest) Reset() {
+	*x = CancelTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTransferRequest) ProtoMessage() {}
+
+func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CancelTransferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfer      *TransferOrder         `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_inventory_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TransferResponse) GetTransfer() *TransferOrder {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+// CountSession là phiên kiểm kê: open → posted, hoặc open → pending_approval → posted khi chênh lệch vượt ngưỡng;
+// hoặc cancelled. Khi post, chênh lệch được ghi thành movement cycle_count, không ghi đè quantity.
+type CountSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	Lines         []*CountLine           `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
+	ApprovedBy    string                 `protobuf:"bytes,6,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix seconds
+	UpdatedAt     int64                  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SubmittedAt   int64                  `protobuf:"varint,9,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"` // 0 = chưa submit
+	ClosedAt      int64                  `protobuf:"varint,10,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`         // 0 = chưa posted/cancelled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountSession) Reset() {
+	*x = CountSession{}
+	mi := &file_inventory_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountSession) ProtoMessage() {}
+
+func (x *CountSession) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountSession.ProtoReflect.Descriptor instead.
+func (*CountSession) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CountSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CountSession) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *CountSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CountSession) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CountSession) GetLines() []*CountLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CountSession) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+func (x *CountSession) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *CountSession) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *CountSession) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *CountSession) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return 0
+}
+
+type CountLine struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ItemId           string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ExpectedQuantity int32                  `protobuf:"varint,2,opt,name=expected_quantity,json=expectedQuantity,proto3" json:"expected_quantity,omitempty"`    // on-hand tại location khi tạo phiên
+	CountedQuantity  *int32                 `protobuf:"varint,3,opt,name=counted_quantity,json=countedQuantity,proto3,oneof" json:"counted_quantity,omitempty"` // không có = chưa đếm
+	Variance         *int32                 `protobuf:"varint,4,opt,name=variance,proto3,oneof" json:"variance,omitempty"`                                      // counted_quantity - expected_quantity
+	RequiresApproval bool                   `protobuf:"varint,5,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`    // chênh lệch vượt ngưỡng duyệt
+	CountedAt        int64                  `protobuf:"varint,6,opt,name=counted_at,json=countedAt,proto3" json:"counted_at,omitempty"`                         // 0 = chưa đếm
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CountLine) Reset() {
+	*x = CountLine{}
+	mi := &file_inventory_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountLine) ProtoMessage() {}
+
+func (x *CountLine) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountLine.ProtoReflect.Descriptor instead.
+func (*CountLine) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CountLine) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CountLine) GetExpectedQuantity() int32 {
+	if x != nil {
+		return x.ExpectedQuantity
+	}
+	return 0
+}
+
+func (x *CountLine) GetCountedQuantity() int32 {
+	if x != nil && x.CountedQuantity != nil {
+		return *x.CountedQuantity
+	}
+	return 0
+}
+
+func (x *CountLine) GetVariance() int32 {
+	if x != nil && x.Variance != nil {
+		return *x.Variance
+	}
+	return 0
+}
+
+func (x *CountLine) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *CountLine) GetCountedAt() int64 {
+	if x != nil {
+		return x.CountedAt
+	}
+	return 0
+}
+
+type CountEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ItemId          string                 `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	CountedQuantity int32                  `protobuf:"varint,2,opt,name=counted_quantity,json=countedQuantity,proto3" json:"counted_quantity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CountEntry) Reset() {
+	*x = CountEntry{}
+	mi := &file_inventory_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InventoryItem) String() string {
+func (x *CountEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InventoryItem) ProtoMessage() {}
+func (*CountEntry) ProtoMessage() {}
 
-func (x *InventoryItem) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[0]
+func (x *CountEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -54,48 +4383,49 @@ func (x *InventoryItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InventoryItem.ProtoReflect.Descriptor instead.
-func (*InventoryItem) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use CountEntry.ProtoReflect.Descriptor instead.
+func (*CountEntry) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *InventoryItem) GetId() string {
+func (x *CountEntry) GetItemId() string {
 	if x != nil {
-		return x.Id
+		return x.ItemId
 	}
 	return ""
 }
 
-func (x *InventoryItem) GetQuantity() int32 {
+func (x *CountEntry) GetCountedQuantity() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.CountedQuantity
 	}
 	return 0
 }
 
-type CreateInventoryRequest struct {
+type CreateCountSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // rỗng = location mặc định
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	ItemIds       []string               `protobuf:"bytes,3,rep,name=item_ids,json=itemIds,proto3" json:"item_ids,omitempty"` // tối đa 1000 item
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateInventoryRequest) Reset() {
-	*x = CreateInventoryRequest{}
-	mi := &file_inventory_proto_msgTypes[1]
+func (x *CreateCountSessionRequest) Reset() {
+	*x = CreateCountSessionRequest{}
+	mi := &file_inventory_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateInventoryRequest) String() string {
+func (x *CreateCountSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInventoryRequest) ProtoMessage() {}
+func (*CreateCountSessionRequest) ProtoMessage() {}
 
-func (x *CreateInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[1]
+func (x *CreateCountSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,48 +4436,54 @@ func (x *CreateInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInventoryRequest.ProtoReflect.Descriptor instead.
-func (*CreateInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use CreateCountSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCountSessionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *CreateInventoryRequest) GetId() string {
+func (x *CreateCountSessionRequest) GetLocationId() string {
 	if x != nil {
-		return x.Id
+		return x.LocationId
 	}
 	return ""
 }
 
-func (x *CreateInventoryRequest) GetQuantity() int32 {
+func (x *CreateCountSessionRequest) GetNote() string {
 	if x != nil {
-		return x.Quantity
+		return x.Note
 	}
-	return 0
+	return ""
 }
 
-type CreateInventoryResponse struct {
+func (x *CreateCountSessionRequest) GetItemIds() []string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+type GetCountSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateInventoryResponse) Reset() {
-	*x = CreateInventoryResponse{}
-	mi := &file_inventory_proto_msgTypes[2]
+func (x *GetCountSessionRequest) Reset() {
+	*x = GetCountSessionRequest{}
+	mi := &file_inventory_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateInventoryResponse) String() string {
+func (x *GetCountSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInventoryResponse) ProtoMessage() {}
+func (*GetCountSessionRequest) ProtoMessage() {}
 
-func (x *CreateInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[2]
+func (x *GetCountSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,48 +4494,109 @@ func (x *CreateInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInventoryResponse.ProtoReflect.Descriptor instead.
-func (*CreateInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetCountSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetCountSessionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *CreateInventoryResponse) GetSuccess() bool {
+func (x *GetCountSessionRequest) GetId() string {
 	if x != nil {
-		return x.Success
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *CreateInventoryResponse) GetMessage() string {
+type ListCountSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                           // tuỳ chọn
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"` // tuỳ chọn
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // mặc định 50, tối đa 500
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCountSessionsRequest) Reset() {
+	*x = ListCountSessionsRequest{}
+	mi := &file_inventory_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCountSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCountSessionsRequest) ProtoMessage() {}
+
+func (x *ListCountSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[72]
 	if x != nil {
-		return x.Message
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCountSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCountSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListCountSessionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-type UpdateInventoryRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ListCountSessionsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
 }
 
-func (x *UpdateInventoryRequest) Reset() {
-	*x = UpdateInventoryRequest{}
-	mi := &file_inventory_proto_msgTypes[3]
+func (x *ListCountSessionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCountSessionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCountSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*CountSession        `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`                                  // mới nhất trước
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // rỗng = không còn trang tiếp theo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCountSessionsResponse) Reset() {
+	*x = ListCountSessionsResponse{}
+	mi := &file_inventory_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateInventoryRequest) String() string {
+func (x *ListCountSessionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateInventoryRequest) ProtoMessage() {}
+func (*ListCountSessionsResponse) ProtoMessage() {}
 
-func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[3]
+func (x *ListCountSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,48 +4607,51 @@ func (x *UpdateInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateInventoryRequest.ProtoReflect.Descriptor instead.
-func (*UpdateInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListCountSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCountSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *UpdateInventoryRequest) GetId() string {
+func (x *ListCountSessionsResponse) GetSessions() []*CountSession {
 	if x != nil {
-		return x.Id
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateInventoryRequest) GetQuantityChange() int32 {
+func (x *ListCountSessionsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.QuantityChange
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
-type UpdateInventoryResponse struct {
+// RecordCounts ghi số lượng đếm; đếm lại một item ghi đè số trước đó.
+// Dùng counts, hoặc csv là nội dung CSV hai cột item_id,counted_quantity (dòng tiêu đề tuỳ chọn).
+type RecordCountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Counts        []*CountEntry          `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty"`
+	Csv           []byte                 `protobuf:"bytes,3,opt,name=csv,proto3" json:"csv,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateInventoryResponse) Reset() {
-	*x = UpdateInventoryResponse{}
-	mi := &file_inventory_proto_msgTypes[4]
+func (x *RecordCountsRequest) Reset() {
+	*x = RecordCountsRequest{}
+	mi := &file_inventory_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateInventoryResponse) String() string {
+func (x *RecordCountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateInventoryResponse) ProtoMessage() {}
+func (*RecordCountsRequest) ProtoMessage() {}
 
-func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[4]
+func (x *RecordCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,47 +4662,55 @@ func (x *UpdateInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateInventoryResponse.ProtoReflect.Descriptor instead.
-func (*UpdateInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RecordCountsRequest.ProtoReflect.Descriptor instead.
+func (*RecordCountsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *UpdateInventoryResponse) GetSuccess() bool {
+func (x *RecordCountsRequest) GetId() string {
 	if x != nil {
-		return x.Success
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *UpdateInventoryResponse) GetMessage() string {
+func (x *RecordCountsRequest) GetCounts() []*CountEntry {
 	if x != nil {
-		return x.Message
+		return x.Counts
 	}
-	return ""
+	return nil
 }
 
-type GetInventoryRequest struct {
+func (x *RecordCountsRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+// SubmitCountSession post chênh lệch ngay, hoặc chuyển phiên sang pending_approval nếu có dòng vượt ngưỡng.
+type SubmitCountSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoryRequest) Reset() {
-	*x = GetInventoryRequest{}
-	mi := &file_inventory_proto_msgTypes[5]
+func (x *SubmitCountSessionRequest) Reset() {
+	*x = SubmitCountSessionRequest{}
+	mi := &file_inventory_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoryRequest) String() string {
+func (x *SubmitCountSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryRequest) ProtoMessage() {}
+func (*SubmitCountSessionRequest) ProtoMessage() {}
 
-func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[5]
+func (x *SubmitCountSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,40 +4721,41 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SubmitCountSessionRequest.ProtoReflect.Descriptor instead.
+func (*SubmitCountSessionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *GetInventoryRequest) GetId() string {
+func (x *SubmitCountSessionRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetInventoriesRequest struct {
+type ApproveCountSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            []string               `protobuf:"bytes,1,rep,name=id,proto3" json:"id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApprovedBy    string                 `protobuf:"bytes,2,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoriesRequest) Reset() {
-	*x = GetInventoriesRequest{}
-	mi := &file_inventory_proto_msgTypes[6]
+func (x *ApproveCountSessionRequest) Reset() {
+	*x = ApproveCountSessionRequest{}
+	mi := &file_inventory_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoriesRequest) String() string {
+func (x *ApproveCountSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoriesRequest) ProtoMessage() {}
+func (*ApproveCountSessionRequest) ProtoMessage() {}
 
-func (x *GetInventoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[6]
+func (x *ApproveCountSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,40 +4766,47 @@ func (x *GetInventoriesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoriesRequest.ProtoReflect.Descriptor instead.
-func (*GetInventoriesRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ApproveCountSessionRequest.ProtoReflect.Descriptor instead.
+func (*ApproveCountSessionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetInventoriesRequest) GetId() []string {
+func (x *ApproveCountSessionRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type GetInventoryResponse struct {
+func (x *ApproveCountSessionRequest) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+type CancelCountSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *InventoryItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoryResponse) Reset() {
-	*x = GetInventoryResponse{}
-	mi := &file_inventory_proto_msgTypes[7]
+func (x *CancelCountSessionRequest) Reset() {
+	*x = CancelCountSessionRequest{}
+	mi := &file_inventory_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoryResponse) String() string {
+func (x *CancelCountSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoryResponse) ProtoMessage() {}
+func (*CancelCountSessionRequest) ProtoMessage() {}
 
-func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[7]
+func (x *CancelCountSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,40 +4817,40 @@ func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
-func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use CancelCountSessionRequest.ProtoReflect.Descriptor instead.
+func (*CancelCountSessionRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetInventoryResponse) GetItem() *InventoryItem {
+func (x *CancelCountSessionRequest) GetId() string {
 	if x != nil {
-		return x.Item
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type GetInventoriesResponse struct {
+type CountSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []*InventoryItem       `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Session       *CountSession          `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetInventoriesResponse) Reset() {
-	*x = GetInventoriesResponse{}
-	mi := &file_inventory_proto_msgTypes[8]
+func (x *CountSessionResponse) Reset() {
+	*x = CountSessionResponse{}
+	mi := &file_inventory_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetInventoriesResponse) String() string {
+func (x *CountSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetInventoriesResponse) ProtoMessage() {}
+func (*CountSessionResponse) ProtoMessage() {}
 
-func (x *GetInventoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[8]
+func (x *CountSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,14 +4861,14 @@ func (x *GetInventoriesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetInventoriesResponse.ProtoReflect.Descriptor instead.
-func (*GetInventoriesResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CountSessionResponse.ProtoReflect.Descriptor instead.
+func (*CountSessionResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *GetInventoriesResponse) GetData() []*InventoryItem {
+func (x *CountSessionResponse) GetSession() *CountSession {
 	if x != nil {
-		return x.Data
+		return x.Session
 	}
 	return nil
 }
@@ -461,22 +4877,97 @@ var File_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x0finventory.proto\x12\tinventory\";\n" +
+	"\x0finventory.proto\x12\tinventory\"\x85\x04\n" +
 	"\rInventoryItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"D\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x126\n" +
+	"\tlocations\x18\x03 \x03(\v2\x18.inventory.LocationStockR\tlocations\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12'\n" +
+	"\x0foversell_policy\x18\x05 \x01(\tR\x0eoversellPolicy\x12'\n" +
+	"\x0fbackorder_limit\x18\x06 \x01(\x05R\x0ebackorderLimit\x12(\n" +
+	"\rreorder_point\x18\a \x01(\x05H\x00R\freorderPoint\x88\x01\x01\x12&\n" +
+	"\fsafety_stock\x18\b \x01(\x05H\x01R\vsafetyStock\x88\x01\x01\x12*\n" +
+	"\x11stock_alert_state\x18\t \x01(\tR\x0fstockAlertState\x122\n" +
+	"\x15lot_allocation_policy\x18\n" +
+	" \x01(\tR\x13lotAllocationPolicy\x12\x1e\n" +
+	"\n" +
+	"serialized\x18\v \x01(\bR\n" +
+	"serialized\x12/\n" +
+	"\x05stock\x18\f \x01(\v2\x19.inventory.StockBreakdownR\x05stockB\x10\n" +
+	"\x0e_reorder_pointB\x0f\n" +
+	"\r_safety_stock\"\xcf\x01\n" +
+	"\rLocationStock\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12'\n" +
+	"\x0foversell_policy\x18\x03 \x01(\tR\x0eoversellPolicy\x12'\n" +
+	"\x0fbackorder_limit\x18\x04 \x01(\x05R\x0ebackorderLimit\x12/\n" +
+	"\x05stock\x18\x05 \x01(\v2\x19.inventory.StockBreakdownR\x05stock\"\xd2\x01\n" +
+	"\x0eStockBreakdown\x12\x17\n" +
+	"\aon_hand\x18\x01 \x01(\x05R\x06onHand\x12\x1a\n" +
+	"\breserved\x18\x02 \x01(\x05R\breserved\x120\n" +
+	"\x14available_to_promise\x18\x03 \x01(\x05R\x12availableToPromise\x12\x1d\n" +
+	"\n" +
+	"in_transit\x18\x04 \x01(\x05R\tinTransit\x12 \n" +
+	"\vquarantined\x18\x05 \x01(\x05R\vquarantined\x12\x18\n" +
+	"\adamaged\x18\x06 \x01(\x05R\adamaged\"\x85\x01\n" +
 	"\x16CreateInventoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"M\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x1e\n" +
+	"\n" +
+	"serialized\x18\x04 \x01(\bR\n" +
+	"serialized\"M\n" +
 	"\x17CreateInventoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"Q\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xde\x01\n" +
 	"\x16UpdateInventoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
-	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\"M\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x03R\x0fexpectedVersion\x12'\n" +
+	"\x03lot\x18\x06 \x01(\v2\x15.inventory.LotReceiptR\x03lot\"g\n" +
 	"\x17UpdateInventoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"%\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\xb7\x01\n" +
+	"\x0fBatchAdjustLine\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12'\n" +
+	"\x0fquantity_change\x18\x03 \x01(\x05R\x0equantityChange\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x03R\x0fexpectedVersion\"p\n" +
+	"\x1bBatchAdjustInventoryRequest\x120\n" +
+	"\x05lines\x18\x01 \x03(\v2\x1a.inventory.BatchAdjustLineR\x05lines\x12\x1f\n" +
+	"\vbest_effort\x18\x02 \x01(\bR\n" +
+	"bestEffort\"\xa8\x02\n" +
+	"\x15BatchAdjustLineResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x05R\bquantity\x12+\n" +
+	"\x11location_quantity\x18\b \x01(\x05R\x10locationQuantity\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\"Z\n" +
+	"\x1cBatchAdjustInventoryResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .inventory.BatchAdjustLineResultR\aresults\"h\n" +
+	"\fWatchRequest\x12\x19\n" +
+	"\bitem_ids\x18\x01 \x03(\tR\aitemIds\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\x12\x1a\n" +
+	"\bprefixes\x18\x03 \x03(\tR\bprefixes\"\x8f\x01\n" +
+	"\x0fInventoryChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12,\n" +
+	"\x04item\x18\x03 \x01(\v2\x18.inventory.InventoryItemR\x04item\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"%\n" +
 	"\x13GetInventoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
 	"\x15GetInventoriesRequest\x12\x0e\n" +
@@ -484,12 +4975,340 @@ const file_inventory_proto_rawDesc = "" +
 	"\x14GetInventoryResponse\x12,\n" +
 	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"F\n" +
 	"\x16GetInventoriesResponse\x12,\n" +
-	"\x04data\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x04data2\xee\x02\n" +
+	"\x04data\x18\x01 \x03(\v2\x18.inventory.InventoryItemR\x04data\"\xc5\x01\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x1f\n" +
+	"\vlocation_id\x18\a \x01(\tR\n" +
+	"locationId\"\xa2\x01\n" +
+	"\x0eReserveRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\tR\n" +
+	"locationId\"K\n" +
+	"\x0fReserveResponse\x128\n" +
+	"\vreservation\x18\x01 \x01(\v2\x16.inventory.ReservationR\vreservation\"7\n" +
+	"\x0eConfirmRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"K\n" +
+	"\x0fConfirmResponse\x128\n" +
+	"\vreservation\x18\x01 \x01(\v2\x16.inventory.ReservationR\vreservation\"7\n" +
+	"\x0eReleaseRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"K\n" +
+	"\x0fReleaseResponse\x128\n" +
+	"\vreservation\x18\x01 \x01(\v2\x16.inventory.ReservationR\vreservation\".\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\";\n" +
+	"\x15CreateLocationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"I\n" +
+	"\x16CreateLocationResponse\x12/\n" +
+	"\blocation\x18\x01 \x01(\v2\x13.inventory.LocationR\blocation\"\x16\n" +
+	"\x14ListLocationsRequest\"J\n" +
+	"\x15ListLocationsResponse\x121\n" +
+	"\tlocations\x18\x01 \x03(\v2\x13.inventory.LocationR\tlocations\"\xea\x02\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\x05R\x05delta\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\x05R\abalance\x12#\n" +
+	"\rtotal_balance\x18\x06 \x01(\x05R\ftotalBalance\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12%\n" +
+	"\x0ecorrelation_id\x18\t \x01(\tR\rcorrelationId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12#\n" +
+	"\rserial_number\x18\v \x01(\tR\fserialNumber\x12\x1f\n" +
+	"\vstock_state\x18\f \x01(\tR\n" +
+	"stockState\"\x8c\x01\n" +
+	"\x14ListMovementsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"w\n" +
+	"\x15ListMovementsResponse\x126\n" +
+	"\tmovements\x18\x01 \x03(\v2\x18.inventory.StockMovementR\tmovements\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x95\x01\n" +
+	"\x18SetOversellPolicyRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06policy\x18\x03 \x01(\tR\x06policy\x12'\n" +
+	"\x0fbackorder_limit\x18\x04 \x01(\x05R\x0ebackorderLimit\"I\n" +
+	"\x19SetOversellPolicyResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xa6\x01\n" +
+	"\x16SetReorderPointRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12(\n" +
+	"\rreorder_point\x18\x02 \x01(\x05H\x00R\freorderPoint\x88\x01\x01\x12&\n" +
+	"\fsafety_stock\x18\x03 \x01(\x05H\x01R\vsafetyStock\x88\x01\x01B\x10\n" +
+	"\x0e_reorder_pointB\x0f\n" +
+	"\r_safety_stock\"G\n" +
+	"\x17SetReorderPointResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"s\n" +
+	"\n" +
+	"LotReceipt\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x01 \x01(\tR\tlotNumber\x12'\n" +
+	"\x0fmanufactured_at\x18\x02 \x01(\tR\x0emanufacturedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\"\x9d\x02\n" +
+	"\fInventoryLot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x04 \x01(\tR\tlotNumber\x12'\n" +
+	"\x0fmanufactured_at\x18\x05 \x01(\tR\x0emanufacturedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vreceived_at\x18\b \x01(\x03R\n" +
+	"receivedAt\x12\x1f\n" +
+	"\vstock_state\x18\t \x01(\tR\n" +
+	"stockState\"p\n" +
+	"\x0fListLotsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12#\n" +
+	"\rinclude_empty\x18\x03 \x01(\bR\fincludeEmpty\"?\n" +
+	"\x10ListLotsResponse\x12+\n" +
+	"\x04lots\x18\x01 \x03(\v2\x17.inventory.InventoryLotR\x04lots\"\x97\x01\n" +
+	"\x17ListExpiringLotsRequest\x12\x1f\n" +
+	"\vwithin_days\x18\x01 \x01(\x05R\n" +
+	"withinDays\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"o\n" +
+	"\x18ListExpiringLotsResponse\x12+\n" +
+	"\x04lots\x18\x01 \x03(\v2\x17.inventory.InventoryLotR\x04lots\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"P\n" +
+	"\x1dSetLotAllocationPolicyRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06policy\x18\x02 \x01(\tR\x06policy\"N\n" +
+	"\x1eSetLotAllocationPolicyResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xc2\x01\n" +
+	"\x0fInventorySerial\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"x\n" +
+	"\x15ReceiveSerialsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\"r\n" +
+	"\x15ReserveSerialsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\"o\n" +
+	"\x12ShipSerialsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\"w\n" +
+	"\x14ReturnSerialsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12%\n" +
+	"\x0eserial_numbers\x18\x02 \x03(\tR\rserialNumbers\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\"O\n" +
+	"\x17SerialOperationResponse\x124\n" +
+	"\aserials\x18\x01 \x03(\v2\x1a.inventory.InventorySerialR\aserials\"\xa2\x01\n" +
+	"\x12ListSerialsRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"s\n" +
+	"\x13ListSerialsResponse\x124\n" +
+	"\aserials\x18\x01 \x03(\v2\x1a.inventory.InventorySerialR\aserials\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"R\n" +
+	"\x12TraceSerialRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\"\x81\x01\n" +
+	"\x13TraceSerialResponse\x122\n" +
+	"\x06serial\x18\x01 \x01(\v2\x1a.inventory.InventorySerialR\x06serial\x126\n" +
+	"\tmovements\x18\x02 \x03(\v2\x18.inventory.StockMovementR\tmovements\"\xd5\x01\n" +
+	"\x16TransitionStockRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x03R\x0fexpectedVersion\"G\n" +
+	"\x17TransitionStockResponse\x12,\n" +
+	"\x04item\x18\x01 \x01(\v2\x18.inventory.InventoryItemR\x04item\"\xda\x02\n" +
+	"\rTransferOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x12source_location_id\x18\x02 \x01(\tR\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x03 \x01(\tR\x15destinationLocationId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\x12-\n" +
+	"\x05lines\x18\x06 \x03(\v2\x17.inventory.TransferLineR\x05lines\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"shipped_at\x18\t \x01(\x03R\tshippedAt\x12\x1b\n" +
+	"\tclosed_at\x18\n" +
+	" \x01(\x03R\bclosedAt\"\x92\x01\n" +
+	"\fTransferLine\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12+\n" +
+	"\x11received_quantity\x18\x03 \x01(\x05R\x10receivedQuantity\x12 \n" +
+	"\vdiscrepancy\x18\x04 \x01(\x05R\vdiscrepancy\"F\n" +
+	"\x0fTransferReceipt\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xc3\x01\n" +
+	"\x15CreateTransferRequest\x12,\n" +
+	"\x12source_location_id\x18\x01 \x01(\tR\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x02 \x01(\tR\x15destinationLocationId\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x120\n" +
+	"\x05lines\x18\x04 \x03(\v2\x1a.inventory.TransferReceiptR\x05lines\"$\n" +
+	"\x12GetTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8b\x01\n" +
+	"\x14ListTransfersRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"w\n" +
+	"\x15ListTransfersResponse\x126\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x18.inventory.TransferOrderR\ttransfers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"%\n" +
+	"\x13ShipTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"p\n" +
+	"\x16ReceiveTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x05lines\x18\x02 \x03(\v2\x1a.inventory.TransferReceiptR\x05lines\x12\x14\n" +
+	"\x05close\x18\x03 \x01(\bR\x05close\"'\n" +
+	"\x15CancelTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x10TransferResponse\x124\n" +
+	"\btransfer\x18\x01 \x01(\v2\x18.inventory.TransferOrderR\btransfer\"\xb6\x02\n" +
+	"\fCountSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12*\n" +
+	"\x05lines\x18\x05 \x03(\v2\x14.inventory.CountLineR\x05lines\x12\x1f\n" +
+	"\vapproved_by\x18\x06 \x01(\tR\n" +
+	"approvedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12!\n" +
+	"\fsubmitted_at\x18\t \x01(\x03R\vsubmittedAt\x12\x1b\n" +
+	"\tclosed_at\x18\n" +
+	" \x01(\x03R\bclosedAt\"\x90\x02\n" +
+	"\tCountLine\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12+\n" +
+	"\x11expected_quantity\x18\x02 \x01(\x05R\x10expectedQuantity\x12.\n" +
+	"\x10counted_quantity\x18\x03 \x01(\x05H\x00R\x0fcountedQuantity\x88\x01\x01\x12\x1f\n" +
+	"\bvariance\x18\x04 \x01(\x05H\x01R\bvariance\x88\x01\x01\x12+\n" +
+	"\x11requires_approval\x18\x05 \x01(\bR\x10requiresApproval\x12\x1d\n" +
+	"\n" +
+	"counted_at\x18\x06 \x01(\x03R\tcountedAtB\x13\n" +
+	"\x11_counted_quantityB\v\n" +
+	"\t_variance\"P\n" +
+	"\n" +
+	"CountEntry\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\tR\x06itemId\x12)\n" +
+	"\x10counted_quantity\x18\x02 \x01(\x05R\x0fcountedQuantity\"k\n" +
+	"\x19CreateCountSessionRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x19\n" +
+	"\bitem_ids\x18\x03 \x03(\tR\aitemIds\"(\n" +
+	"\x16GetCountSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8f\x01\n" +
+	"\x18ListCountSessionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"x\n" +
+	"\x19ListCountSessionsResponse\x123\n" +
+	"\bsessions\x18\x01 \x03(\v2\x17.inventory.CountSessionR\bsessions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"f\n" +
+	"\x13RecordCountsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x06counts\x18\x02 \x03(\v2\x15.inventory.CountEntryR\x06counts\x12\x10\n" +
+	"\x03csv\x18\x03 \x01(\fR\x03csv\"+\n" +
+	"\x19SubmitCountSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x1aApproveCountSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vapproved_by\x18\x02 \x01(\tR\n" +
+	"approvedBy\"+\n" +
+	"\x19CancelCountSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x14CountSessionResponse\x121\n" +
+	"\asession\x18\x01 \x01(\v2\x17.inventory.CountSessionR\asession2\xdd\x18\n" +
 	"\x10InventoryService\x12X\n" +
 	"\x0fCreateInventory\x12!.inventory.CreateInventoryRequest\x1a\".inventory.CreateInventoryResponse\x12X\n" +
 	"\x0fUpdateInventory\x12!.inventory.UpdateInventoryRequest\x1a\".inventory.UpdateInventoryResponse\x12O\n" +
 	"\fGetInventory\x12\x1e.inventory.GetInventoryRequest\x1a\x1f.inventory.GetInventoryResponse\x12U\n" +
-	"\x0eGetInventories\x12 .inventory.GetInventoriesRequest\x1a!.inventory.GetInventoriesResponseB'Z%internal/grpc/inventorypb;inventorypbb\x06proto3"
+	"\x0eGetInventories\x12 .inventory.GetInventoriesRequest\x1a!.inventory.GetInventoriesResponse\x12g\n" +
+	"\x14BatchAdjustInventory\x12&.inventory.BatchAdjustInventoryRequest\x1a'.inventory.BatchAdjustInventoryResponse\x12G\n" +
+	"\x0eWatchInventory\x12\x17.inventory.WatchRequest\x1a\x1a.inventory.InventoryChange0\x01\x12@\n" +
+	"\aReserve\x12\x19.inventory.ReserveRequest\x1a\x1a.inventory.ReserveResponse\x12@\n" +
+	"\aConfirm\x12\x19.inventory.ConfirmRequest\x1a\x1a.inventory.ConfirmResponse\x12@\n" +
+	"\aRelease\x12\x19.inventory.ReleaseRequest\x1a\x1a.inventory.ReleaseResponse\x12U\n" +
+	"\x0eCreateLocation\x12 .inventory.CreateLocationRequest\x1a!.inventory.CreateLocationResponse\x12R\n" +
+	"\rListLocations\x12\x1f.inventory.ListLocationsRequest\x1a .inventory.ListLocationsResponse\x12R\n" +
+	"\rListMovements\x12\x1f.inventory.ListMovementsRequest\x1a .inventory.ListMovementsResponse\x12^\n" +
+	"\x11SetOversellPolicy\x12#.inventory.SetOversellPolicyRequest\x1a$.inventory.SetOversellPolicyResponse\x12X\n" +
+	"\x0fSetReorderPoint\x12!.inventory.SetReorderPointRequest\x1a\".inventory.SetReorderPointResponse\x12C\n" +
+	"\bListLots\x12\x1a.inventory.ListLotsRequest\x1a\x1b.inventory.ListLotsResponse\x12[\n" +
+	"\x10ListExpiringLots\x12\".inventory.ListExpiringLotsRequest\x1a#.inventory.ListExpiringLotsResponse\x12m\n" +
+	"\x16SetLotAllocationPolicy\x12(.inventory.SetLotAllocationPolicyRequest\x1a).inventory.SetLotAllocationPolicyResponse\x12V\n" +
+	"\x0eReceiveSerials\x12 .inventory.ReceiveSerialsRequest\x1a\".inventory.SerialOperationResponse\x12V\n" +
+	"\x0eReserveSerials\x12 .inventory.ReserveSerialsRequest\x1a\".inventory.SerialOperationResponse\x12P\n" +
+	"\vShipSerials\x12\x1d.inventory.ShipSerialsRequest\x1a\".inventory.SerialOperationResponse\x12T\n" +
+	"\rReturnSerials\x12\x1f.inventory.ReturnSerialsRequest\x1a\".inventory.SerialOperationResponse\x12L\n" +
+	"\vListSerials\x12\x1d.inventory.ListSerialsRequest\x1a\x1e.inventory.ListSerialsResponse\x12L\n" +
+	"\vTraceSerial\x12\x1d.inventory.TraceSerialRequest\x1a\x1e.inventory.TraceSerialResponse\x12X\n" +
+	"\x0fTransitionStock\x12!.inventory.TransitionStockRequest\x1a\".inventory.TransitionStockResponse\x12O\n" +
+	"\x0eCreateTransfer\x12 .inventory.CreateTransferRequest\x1a\x1b.inventory.TransferResponse\x12I\n" +
+	"\vGetTransfer\x12\x1d.inventory.GetTransferRequest\x1a\x1b.inventory.TransferResponse\x12R\n" +
+	"\rListTransfers\x12\x1f.inventory.ListTransfersRequest\x1a .inventory.ListTransfersResponse\x12K\n" +
+	"\fShipTransfer\x12\x1e.inventory.ShipTransferRequest\x1a\x1b.inventory.TransferResponse\x12Q\n" +
+	"\x0fReceiveTransfer\x12!.inventory.ReceiveTransferRequest\x1a\x1b.inventory.TransferResponse\x12O\n" +
+	"\x0eCancelTransfer\x12 .inventory.CancelTransferRequest\x1a\x1b.inventory.TransferResponse\x12[\n" +
+	"\x12CreateCountSession\x12$.inventory.CreateCountSessionRequest\x1a\x1f.inventory.CountSessionResponse\x12U\n" +
+	"\x0fGetCountSession\x12!.inventory.GetCountSessionRequest\x1a\x1f.inventory.CountSessionResponse\x12^\n" +
+	"\x11ListCountSessions\x12#.inventory.ListCountSessionsRequest\x1a$.inventory.ListCountSessionsResponse\x12O\n" +
+	"\fRecordCounts\x12\x1e.inventory.RecordCountsRequest\x1a\x1f.inventory.CountSessionResponse\x12[\n" +
+	"\x12SubmitCountSession\x12$.inventory.SubmitCountSessionRequest\x1a\x1f.inventory.CountSessionResponse\x12]\n" +
+	"\x13ApproveCountSession\x12%.inventory.ApproveCountSessionRequest\x1a\x1f.inventory.CountSessionResponse\x12[\n" +
+	"\x12CancelCountSession\x12$.inventory.CancelCountSessionRequest\x1a\x1f.inventory.CountSessionResponseB'Z%internal/grpc/inventorypb;inventorypbb\x06proto3"
 
 var (
 	file_inventory_proto_rawDescOnce sync.Once
@@ -503,34 +5322,202 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
 var file_inventory_proto_goTypes = []any{
-	(*InventoryItem)(nil),           // 0: inventory.InventoryItem
-	(*CreateInventoryRequest)(nil),  // 1: inventory.CreateInventoryRequest
-	(*CreateInventoryResponse)(nil), // 2: inventory.CreateInventoryResponse
-	(*UpdateInventoryRequest)(nil),  // 3: inventory.UpdateInventoryRequest
-	(*UpdateInventoryResponse)(nil), // 4: inventory.UpdateInventoryResponse
-	(*GetInventoryRequest)(nil),     // 5: inventory.GetInventoryRequest
-	(*GetInventoriesRequest)(nil),   // 6: inventory.GetInventoriesRequest
-	(*GetInventoryResponse)(nil),    // 7: inventory.GetInventoryResponse
-	(*GetInventoriesResponse)(nil),  // 8: inventory.GetInventoriesResponse
+	(*InventoryItem)(nil),                  // 0: inventory.InventoryItem
+	(*LocationStock)(nil),                  // 1: inventory.LocationStock
+	(*StockBreakdown)(nil),                 // 2: inventory.StockBreakdown
+	(*CreateInventoryRequest)(nil),         // 3: inventory.CreateInventoryRequest
+	(*CreateInventoryResponse)(nil),        // 4: inventory.CreateInventoryResponse
+	(*UpdateInventoryRequest)(nil),         // 5: inventory.UpdateInventoryRequest
+	(*UpdateInventoryResponse)(nil),        // 6: inventory.UpdateInventoryResponse
+	(*BatchAdjustLine)(nil),                // 7: inventory.BatchAdjustLine
+	(*BatchAdjustInventoryRequest)(nil),    // 8: inventory.BatchAdjustInventoryRequest
+	(*BatchAdjustLineResult)(nil),          // 9: inventory.BatchAdjustLineResult
+	(*BatchAdjustInventoryResponse)(nil),   // 10: inventory.BatchAdjustInventoryResponse
+	(*WatchRequest)(nil),                   // 11: inventory.WatchRequest
+	(*InventoryChange)(nil),                // 12: inventory.InventoryChange
+	(*GetInventoryRequest)(nil),            // 13: inventory.GetInventoryRequest
+	(*GetInventoriesRequest)(nil),          // 14: inventory.GetInventoriesRequest
+	(*GetInventoryResponse)(nil),           // 15: inventory.GetInventoryResponse
+	(*GetInventoriesResponse)(nil),         // 16: inventory.GetInventoriesResponse
+	(*Reservation)(nil),                    // 17: inventory.Reservation
+	(*ReserveRequest)(nil),                 // 18: inventory.ReserveRequest
+	(*ReserveResponse)(nil),                // 19: inventory.ReserveResponse
+	(*ConfirmRequest)(nil),                 // 20: inventory.ConfirmRequest
+	(*ConfirmResponse)(nil),                // 21: inventory.ConfirmResponse
+	(*ReleaseRequest)(nil),                 // 22: inventory.ReleaseRequest
+	(*ReleaseResponse)(nil),                // 23: inventory.ReleaseResponse
+	(*Location)(nil),                       // 24: inventory.Location
+	(*CreateLocationRequest)(nil),          // 25: inventory.CreateLocationRequest
+	(*CreateLocationResponse)(nil),         // 26: inventory.CreateLocationResponse
+	(*ListLocationsRequest)(nil),           // 27: inventory.ListLocationsRequest
+	(*ListLocationsResponse)(nil),          // 28: inventory.ListLocationsResponse
+	(*StockMovement)(nil),                  // 29: inventory.StockMovement
+	(*ListMovementsRequest)(nil),           // 30: inventory.ListMovementsRequest
+	(*ListMovementsResponse)(nil),          // 31: inventory.ListMovementsResponse
+	(*SetOversellPolicyRequest)(nil),       // 32: inventory.SetOversellPolicyRequest
+	(*SetOversellPolicyResponse)(nil),      // 33: inventory.SetOversellPolicyResponse
+	(*SetReorderPointRequest)(nil),         // 34: inventory.SetReorderPointRequest
+	(*SetReorderPointResponse)(nil),        // 35: inventory.SetReorderPointResponse
+	(*LotReceipt)(nil),                     // 36: inventory.LotReceipt
+	(*InventoryLot)(nil),                   // 37: inventory.InventoryLot
+	(*ListLotsRequest)(nil),                // 38: inventory.ListLotsRequest
+	(*ListLotsResponse)(nil),               // 39: inventory.ListLotsResponse
+	(*ListExpiringLotsRequest)(nil),        // 40: inventory.ListExpiringLotsRequest
+	(*ListExpiringLotsResponse)(nil),       // 41: inventory.ListExpiringLotsResponse
+	(*SetLotAllocationPolicyRequest)(nil),  // 42: inventory.SetLotAllocationPolicyRequest
+	(*SetLotAllocationPolicyResponse)(nil), // 43: inventory.SetLotAllocationPolicyResponse
+	(*InventorySerial)(nil),                // 44: inventory.InventorySerial
+	(*ReceiveSerialsRequest)(nil),          // 45: inventory.ReceiveSerialsRequest
+	(*ReserveSerialsRequest)(nil),          // 46: inventory.ReserveSerialsRequest
+	(*ShipSerialsRequest)(nil),             // 47: inventory.ShipSerialsRequest
+	(*ReturnSerialsRequest)(nil),           // 48: inventory.ReturnSerialsRequest
+	(*SerialOperationResponse)(nil),        // 49: inventory.SerialOperationResponse
+	(*ListSerialsRequest)(nil),             // 50: inventory.ListSerialsRequest
+	(*ListSerialsResponse)(nil),            // 51: inventory.ListSerialsResponse
+	(*TraceSerialRequest)(nil),             // 52: inventory.TraceSerialRequest
+	(*TraceSerialResponse)(nil),            // 53: inventory.TraceSerialResponse
+	(*TransitionStockRequest)(nil),         // 54: inventory.TransitionStockRequest
+	(*TransitionStockResponse)(nil),        // 55: inventory.TransitionStockResponse
+	(*TransferOrder)(nil),                  // 56: inventory.TransferOrder
+	(*TransferLine)(nil),                   // 57: inventory.TransferLine
+	(*TransferReceipt)(nil),                // 58: inventory.TransferReceipt
+	(*CreateTransferRequest)(nil),          // 59: inventory.CreateTransferRequest
+	(*GetTransferRequest)(nil),             // 60: inventory.GetTransferRequest
+	(*ListTransfersRequest)(nil),           // 61: inventory.ListTransfersRequest
+	(*ListTransfersResponse)(nil),          // 62: inventory.ListTransfersResponse
+	(*ShipTransferRequest)(nil),            // 63: inventory.ShipTransferRequest
+	(*ReceiveTransferRequest)(nil),         // 64: inventory.ReceiveTransferRequest
+	(*CancelTransferRequest)(nil),          // 65: inventory.CancelTransferRequest
+	(*TransferResponse)(nil),               // 66: inventory.TransferResponse
+	(*CountSession)(nil),                   // 67: inventory.CountSession
+	(*CountLine)(nil),                      // 68: inventory.CountLine
+	(*CountEntry)(nil),                     // 69: inventory.CountEntry
+	(*CreateCountSessionRequest)(nil),      // 70: inventory.CreateCountSessionRequest
+	(*GetCountSessionRequest)(nil),         // 71: inventory.GetCountSessionRequest
+	(*ListCountSessionsRequest)(nil),       // 72: inventory.ListCountSessionsRequest
+	(*ListCountSessionsResponse)(nil),      // 73: inventory.ListCountSessionsResponse
+	(*RecordCountsRequest)(nil),            // 74: inventory.RecordCountsRequest
+	(*SubmitCountSessionRequest)(nil),      // 75: inventory.SubmitCountSessionRequest
+	(*ApproveCountSessionRequest)(nil),     // 76: inventory.ApproveCountSessionRequest
+	(*CancelCountSessionRequest)(nil),      // 77: inventory.CancelCountSessionRequest
+	(*CountSessionResponse)(nil),           // 78: inventory.CountSessionResponse
 }
 var file_inventory_proto_depIdxs = []int32{
-	0, // 0: inventory.GetInventoryResponse.item:type_name -> inventory.InventoryItem
-	0, // 1: inventory.GetInventoriesResponse.data:type_name -> inventory.InventoryItem
-	1, // 2: inventory.InventoryService.CreateInventory:input_type -> inventory.CreateInventoryRequest
-	3, // 3: inventory.InventoryService.UpdateInventory:input_type -> inventory.UpdateInventoryRequest
-	5, // 4: inventory.InventoryService.GetInventory:input_type -> inventory.GetInventoryRequest
-	6, // 5: inventory.InventoryService.GetInventories:input_type -> inventory.GetInventoriesRequest
-	2, // 6: inventory.InventoryService.CreateInventory:output_type -> inventory.CreateInventoryResponse
-	4, // 7: inventory.InventoryService.UpdateInventory:output_type -> inventory.UpdateInventoryResponse
-	7, // 8: inventory.InventoryService.GetInventory:output_type -> inventory.GetInventoryResponse
-	8, // 9: inventory.InventoryService.GetInventories:output_type -> inventory.GetInventoriesResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: inventory.InventoryItem.locations:type_name -> inventory.LocationStock
+	2,  // 1: inventory.InventoryItem.stock:type_name -> inventory.StockBreakdown
+	2,  // 2: inventory.LocationStock.stock:type_name -> inventory.StockBreakdown
+	36, // 3: inventory.UpdateInventoryRequest.lot:type_name -> inventory.LotReceipt
+	7,  // 4: inventory.BatchAdjustInventoryRequest.lines:type_name -> inventory.BatchAdjustLine
+	9,  // 5: inventory.BatchAdjustInventoryResponse.results:type_name -> inventory.BatchAdjustLineResult
+	0,  // 6: inventory.InventoryChange.item:type_name -> inventory.InventoryItem
+	0,  // 7: inventory.GetInventoryResponse.item:type_name -> inventory.InventoryItem
+	0,  // 8: inventory.GetInventoriesResponse.data:type_name -> inventory.InventoryItem
+	17, // 9: inventory.ReserveResponse.reservation:type_name -> inventory.Reservation
+	17, // 10: inventory.ConfirmResponse.reservation:type_name -> inventory.Reservation
+	17, // 11: inventory.ReleaseResponse.reservation:type_name -> inventory.Reservation
+	24, // 12: inventory.CreateLocationResponse.location:type_name -> inventory.Location
+	24, // 13: inventory.ListLocationsResponse.locations:type_name -> inventory.Location
+	29, // 14: inventory.ListMovementsResponse.movements:type_name -> inventory.StockMovement
+	0,  // 15: inventory.SetOversellPolicyResponse.item:type_name -> inventory.InventoryItem
+	0,  // 16: inventory.SetReorderPointResponse.item:type_name -> inventory.InventoryItem
+	37, // 17: inventory.ListLotsResponse.lots:type_name -> inventory.InventoryLot
+	37, // 18: inventory.ListExpiringLotsResponse.lots:type_name -> inventory.InventoryLot
+	0,  // 19: inventory.SetLotAllocationPolicyResponse.item:type_name -> inventory.InventoryItem
+	44, // 20: inventory.SerialOperationResponse.serials:type_name -> inventory.InventorySerial
+	44, // 21: inventory.ListSerialsResponse.serials:type_name -> inventory.InventorySerial
+	44, // 22: inventory.TraceSerialResponse.serial:type_name -> inventory.InventorySerial
+	29, // 23: inventory.TraceSerialResponse.movements:type_name -> inventory.StockMovement
+	0,  // 24: inventory.TransitionStockResponse.item:type_name -> inventory.InventoryItem
+	57, // 25: inventory.TransferOrder.lines:type_name -> inventory.TransferLine
+	58, // 26: inventory.CreateTransferRequest.lines:type_name -> inventory.TransferReceipt
+	56, // 27: inventory.ListTransfersResponse.transfers:type_name -> inventory.TransferOrder
+	58, // 28: inventory.ReceiveTransferRequest.lines:type_name -> inventory.TransferReceipt
+	56, // 29: inventory.TransferResponse.transfer:type_name -> inventory.TransferOrder
+	68, // 30: inventory.CountSession.lines:type_name -> inventory.CountLine
+	67, // 31: inventory.ListCountSessionsResponse.sessions:type_name -> inventory.CountSession
+	69, // 32: inventory.RecordCountsRequest.counts:type_name -> inventory.CountEntry
+	67, // 33: inventory.CountSessionResponse.session:type_name -> inventory.CountSession
+	3,  // 34: inventory.InventoryService.CreateInventory:input_type -> inventory.CreateInventoryRequest
+	5,  // 35: inventory.InventoryService.UpdateInventory:input_type -> inventory.UpdateInventoryRequest
+	13, // 36: inventory.InventoryService.GetInventory:input_type -> inventory.GetInventoryRequest
+	14, // 37: inventory.InventoryService.GetInventories:input_type -> inventory.GetInventoriesRequest
+	8,  // 38: inventory.InventoryService.BatchAdjustInventory:input_type -> inventory.BatchAdjustInventoryRequest
+	11, // 39: inventory.InventoryService.WatchInventory:input_type -> inventory.WatchRequest
+	18, // 40: inventory.InventoryService.Reserve:input_type -> inventory.ReserveRequest
+	20, // 41: inventory.InventoryService.Confirm:input_type -> inventory.ConfirmRequest
+	22, // 42: inventory.InventoryService.Release:input_type -> inventory.ReleaseRequest
+	25, // 43: inventory.InventoryService.CreateLocation:input_type -> inventory.CreateLocationRequest
+	27, // 44: inventory.InventoryService.ListLocations:input_type -> inventory.ListLocationsRequest
+	30, // 45: inventory.InventoryService.ListMovements:input_type -> inventory.ListMovementsRequest
+	32, // 46: inventory.InventoryService.SetOversellPolicy:input_type -> inventory.SetOversellPolicyRequest
+	34, // 47: inventory.InventoryService.SetReorderPoint:input_type -> inventory.SetReorderPointRequest
+	38, // 48: inventory.InventoryService.ListLots:input_type -> inventory.ListLotsRequest
+	40, // 49: inventory.InventoryService.ListExpiringLots:input_type -> inventory.ListExpiringLotsRequest
+	42, // 50: inventory.InventoryService.SetLotAllocationPolicy:input_type -> inventory.SetLotAllocationPolicyRequest
+	45, // 51: inventory.InventoryService.ReceiveSerials:input_type -> inventory.ReceiveSerialsRequest
+	46, // 52: inventory.InventoryService.ReserveSerials:input_type -> inventory.ReserveSerialsRequest
+	47, // 53: inventory.InventoryService.ShipSerials:input_type -> inventory.ShipSerialsRequest
+	48, // 54: inventory.InventoryService.ReturnSerials:input_type -> inventory.ReturnSerialsRequest
+	50, // 55: inventory.InventoryService.ListSerials:input_type -> inventory.ListSerialsRequest
+	52, // 56: inventory.InventoryService.TraceSerial:input_type -> inventory.TraceSerialRequest
+	54, // 57: inventory.InventoryService.TransitionStock:input_type -> inventory.TransitionStockRequest
+	59, // 58: inventory.InventoryService.CreateTransfer:input_type -> inventory.CreateTransferRequest
+	60, // 59: inventory.InventoryService.GetTransfer:input_type -> inventory.GetTransferRequest
+	61, // 60: inventory.InventoryService.ListTransfers:input_type -> inventory.ListTransfersRequest
+	63, // 61: inventory.InventoryService.ShipTransfer:input_type -> inventory.ShipTransferRequest
+	64, // 62: inventory.InventoryService.ReceiveTransfer:input_type -> inventory.ReceiveTransferRequest
+	65, // 63: inventory.InventoryService.CancelTransfer:input_type -> inventory.CancelTransferRequest
+	70, // 64: inventory.InventoryService.CreateCountSession:input_type -> inventory.CreateCountSessionRequest
+	71, // 65: inventory.InventoryService.GetCountSession:input_type -> inventory.GetCountSessionRequest
+	72, // 66: inventory.InventoryService.ListCountSessions:input_type -> inventory.ListCountSessionsRequest
+	74, // 67: inventory.InventoryService.RecordCounts:input_type -> inventory.RecordCountsRequest
+	75, // 68: inventory.InventoryService.SubmitCountSession:input_type -> inventory.SubmitCountSessionRequest
+	76, // 69: inventory.InventoryService.ApproveCountSession:input_type -> inventory.ApproveCountSessionRequest
+	77, // 70: inventory.InventoryService.CancelCountSession:input_type -> inventory.CancelCountSessionRequest
+	4,  // 71: inventory.InventoryService.CreateInventory:output_type -> inventory.CreateInventoryResponse
+	6,  // 72: inventory.InventoryService.UpdateInventory:output_type -> inventory.UpdateInventoryResponse
+	15, // 73: inventory.InventoryService.GetInventory:output_type -> inventory.GetInventoryResponse
+	16, // 74: inventory.InventoryService.GetInventories:output_type -> inventory.GetInventoriesResponse
+	10, // 75: inventory.InventoryService.BatchAdjustInventory:output_type -> inventory.BatchAdjustInventoryResponse
+	12, // 76: inventory.InventoryService.WatchInventory:output_type -> inventory.InventoryChange
+	19, // 77: inventory.InventoryService.Reserve:output_type -> inventory.ReserveResponse
+	21, // 78: inventory.InventoryService.Confirm:output_type -> inventory.ConfirmResponse
+	23, // 79: inventory.InventoryService.Release:output_type -> inventory.ReleaseResponse
+	26, // 80: inventory.InventoryService.CreateLocation:output_type -> inventory.CreateLocationResponse
+	28, // 81: inventory.InventoryService.ListLocations:output_type -> inventory.ListLocationsResponse
+	31, // 82: inventory.InventoryService.ListMovements:output_type -> inventory.ListMovementsResponse
+	33, // 83: inventory.InventoryService.SetOversellPolicy:output_type -> inventory.SetOversellPolicyResponse
+	35, // 84: inventory.InventoryService.SetReorderPoint:output_type -> inventory.SetReorderPointResponse
+	39, // 85: inventory.InventoryService.ListLots:output_type -> inventory.ListLotsResponse
+	41, // 86: inventory.InventoryService.ListExpiringLots:output_type -> inventory.ListExpiringLotsResponse
+	43, // 87: inventory.InventoryService.SetLotAllocationPolicy:output_type -> inventory.SetLotAllocationPolicyResponse
+	49, // 88: inventory.InventoryService.ReceiveSerials:output_type -> inventory.SerialOperationResponse
+	49, // 89: inventory.InventoryService.ReserveSerials:output_type -> inventory.SerialOperationResponse
+	49, // 90: inventory.InventoryService.ShipSerials:output_type -> inventory.SerialOperationResponse
+	49, // 91: inventory.InventoryService.ReturnSerials:output_type -> inventory.SerialOperationResponse
+	51, // 92: inventory.InventoryService.ListSerials:output_type -> inventory.ListSerialsResponse
+	53, // 93: inventory.InventoryService.TraceSerial:output_type -> inventory.TraceSerialResponse
+	55, // 94: inventory.InventoryService.TransitionStock:output_type -> inventory.TransitionStockResponse
+	66, // 95: inventory.InventoryService.CreateTransfer:output_type -> inventory.TransferResponse
+	66, // 96: inventory.InventoryService.GetTransfer:output_type -> inventory.TransferResponse
+	62, // 97: inventory.InventoryService.ListTransfers:output_type -> inventory.ListTransfersResponse
+	66, // 98: inventory.InventoryService.ShipTransfer:output_type -> inventory.TransferResponse
+	66, // 99: inventory.InventoryService.ReceiveTransfer:output_type -> inventory.TransferResponse
+	66, // 100: inventory.InventoryService.CancelTransfer:output_type -> inventory.TransferResponse
+	78, // 101: inventory.InventoryService.CreateCountSession:output_type -> inventory.CountSessionResponse
+	78, // 102: inventory.InventoryService.GetCountSession:output_type -> inventory.CountSessionResponse
+	73, // 103: inventory.InventoryService.ListCountSessions:output_type -> inventory.ListCountSessionsResponse
+	78, // 104: inventory.InventoryService.RecordCounts:output_type -> inventory.CountSessionResponse
+	78, // 105: inventory.InventoryService.SubmitCountSession:output_type -> inventory.CountSessionResponse
+	78, // 106: inventory.InventoryService.ApproveCountSession:output_type -> inventory.CountSessionResponse
+	78, // 107: inventory.InventoryService.CancelCountSession:output_type -> inventory.CountSessionResponse
+	71, // [71:108] is the sub-list for method output_type
+	34, // [34:71] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -538,13 +5525,16 @@ func file_inventory_proto_init() {
 	if File_inventory_proto != nil {
 		return
 	}
+	file_inventory_proto_msgTypes[0].OneofWrappers = []any{}
+	file_inventory_proto_msgTypes[34].OneofWrappers = []any{}
+	file_inventory_proto_msgTypes[68].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
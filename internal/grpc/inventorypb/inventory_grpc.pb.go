@@ -19,10 +19,43 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_CreateInventory_FullMethodName = "/inventory.InventoryService/CreateInventory"
-	InventoryService_UpdateInventory_FullMethodName = "/inventory.InventoryService/UpdateInventory"
-	InventoryService_GetInventory_FullMethodName    = "/inventory.InventoryService/GetInventory"
-	InventoryService_GetInventories_FullMethodName  = "/inventory.InventoryService/GetInventories"
+	InventoryService_CreateInventory_FullMethodName        = "/inventory.InventoryService/CreateInventory"
+	InventoryService_UpdateInventory_FullMethodName        = "/inventory.InventoryService/UpdateInventory"
+	InventoryService_GetInventory_FullMethodName           = "/inventory.InventoryService/GetInventory"
+	InventoryService_GetInventories_FullMethodName         = "/inventory.InventoryService/GetInventories"
+	InventoryService_BatchAdjustInventory_FullMethodName   = "/inventory.InventoryService/BatchAdjustInventory"
+	InventoryService_WatchInventory_FullMethodName         = "/inventory.InventoryService/WatchInventory"
+	InventoryService_Reserve_FullMethodName                = "/inventory.InventoryService/Reserve"
+	InventoryService_Confirm_FullMethodName                = "/inventory.InventoryService/Confirm"
+	InventoryService_Release_FullMethodName                = "/inventory.InventoryService/Release"
+	InventoryService_CreateLocation_FullMethodName         = "/inventory.InventoryService/CreateLocation"
+	InventoryService_ListLocations_FullMethodName          = "/inventory.InventoryService/ListLocations"
+	InventoryService_ListMovements_FullMethodName          = "/inventory.InventoryService/ListMovements"
+	InventoryService_SetOversellPolicy_FullMethodName      = "/inventory.InventoryService/SetOversellPolicy"
+	InventoryService_SetReorderPoint_FullMethodName        = "/inventory.InventoryService/SetReorderPoint"
+	InventoryService_ListLots_FullMethodName               = "/inventory.InventoryService/ListLots"
+	InventoryService_ListExpiringLots_FullMethodName       = "/inventory.InventoryService/ListExpiringLots"
+	InventoryService_SetLotAllocationPolicy_FullMethodName = "/inventory.InventoryService/SetLotAllocationPolicy"
+	InventoryService_ReceiveSerials_FullMethodName         = "/inventory.InventoryService/ReceiveSerials"
+	InventoryService_ReserveSerials_FullMethodName         = "/inventory.InventoryService/ReserveSerials"
+	InventoryService_ShipSerials_FullMethodName            = "/inventory.InventoryService/ShipSerials"
+	InventoryService_ReturnSerials_FullMethodName          = "/inventory.InventoryService/ReturnSerials"
+	InventoryService_ListSerials_FullMethodName            = "/inventory.InventoryService/ListSerials"
+	InventoryService_TraceSerial_FullMethodName            = "/inventory.InventoryService/TraceSerial"
+	InventoryService_TransitionStock_FullMethodName        = "/inventory.InventoryService/TransitionStock"
+	InventoryService_CreateTransfer_FullMethodName         = "/inventory.InventoryService/CreateTransfer"
+	InventoryService_GetTransfer_FullMethodName            = "/inventory.InventoryService/GetTransfer"
+	InventoryService_ListTransfers_FullMethodName          = "/inventory.InventoryService/ListTransfers"
+	InventoryService_ShipTransfer_FullMethodName           = "/inventory.InventoryService/ShipTransfer"
+	InventoryService_ReceiveTransfer_FullMethodName        = "/inventory.InventoryService/ReceiveTransfer"
+	InventoryService_CancelTransfer_FullMethodName         = "/inventory.InventoryService/CancelTransfer"
+	InventoryService_CreateCountSession_FullMethodName     = "/inventory.InventoryService/CreateCountSession"
+	InventoryService_GetCountSession_FullMethodName        = "/inventory.InventoryService/GetCountSession"
+	InventoryService_ListCountSessions_FullMethodName      = "/inventory.InventoryService/ListCountSessions"
+	InventoryService_RecordCounts_FullMethodName           = "/inventory.InventoryService/RecordCounts"
+	InventoryService_SubmitCountSession_FullMethodName     = "/inventory.InventoryService/SubmitCountSession"
+	InventoryService_ApproveCountSession_FullMethodName    = "/inventory.InventoryService/ApproveCountSession"
+	InventoryService_CancelCountSession_FullMethodName     = "/inventory.InventoryService/CancelCountSession"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -33,6 +66,39 @@ type InventoryServiceClient interface {
 	UpdateInventory(ctx context.Context, in *UpdateInventoryRequest, opts ...grpc.CallOption) (*UpdateInventoryResponse, error)
 	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error)
 	GetInventories(ctx context.Context, in *GetInventoriesRequest, opts ...grpc.CallOption) (*GetInventoriesResponse, error)
+	BatchAdjustInventory(ctx context.Context, in *BatchAdjustInventoryRequest, opts ...grpc.CallOption) (*BatchAdjustInventoryResponse, error)
+	WatchInventory(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventoryChange], error)
+	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
+	Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmResponse, error)
+	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
+	CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error)
+	ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error)
+	ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error)
+	SetOversellPolicy(ctx context.Context, in *SetOversellPolicyRequest, opts ...grpc.CallOption) (*SetOversellPolicyResponse, error)
+	SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error)
+	ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error)
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error)
+	SetLotAllocationPolicy(ctx context.Context, in *SetLotAllocationPolicyRequest, opts ...grpc.CallOption) (*SetLotAllocationPolicyResponse, error)
+	ReceiveSerials(ctx context.Context, in *ReceiveSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error)
+	ReserveSerials(ctx context.Context, in *ReserveSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error)
+	ShipSerials(ctx context.Context, in *ShipSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error)
+	ReturnSerials(ctx context.Context, in *ReturnSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error)
+	ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*ListSerialsResponse, error)
+	TraceSerial(ctx context.Context, in *TraceSerialRequest, opts ...grpc.CallOption) (*TraceSerialResponse, error)
+	TransitionStock(ctx context.Context, in *TransitionStockRequest, opts ...grpc.CallOption) (*TransitionStockResponse, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	ShipTransfer(ctx context.Context, in *ShipTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	CreateCountSession(ctx context.Context, in *CreateCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
+	GetCountSession(ctx context.Context, in *GetCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
+	ListCountSessions(ctx context.Context, in *ListCountSessionsRequest, opts ...grpc.CallOption) (*ListCountSessionsResponse, error)
+	RecordCounts(ctx context.Context, in *RecordCountsRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
+	SubmitCountSession(ctx context.Context, in *SubmitCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
+	ApproveCountSession(ctx context.Context, in *ApproveCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
+	CancelCountSession(ctx context.Context, in *CancelCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -83,6 +149,345 @@ func (c *inventoryServiceClient) GetInventories(ctx context.Context, in *GetInve
 	return out, nil
 }
 
+func (c *inventoryServiceClient) BatchAdjustInventory(ctx context.Context, in *BatchAdjustInventoryRequest, opts ...grpc.CallOption) (*BatchAdjustInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchAdjustInventoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_BatchAdjustInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) WatchInventory(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventoryChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchInventory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, InventoryChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchInventoryClient = grpc.ServerStreamingClient[InventoryChange]
+
+func (c *inventoryServiceClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveResponse)
+	err := c.cc.Invoke(ctx, InventoryService_Reserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmResponse)
+	err := c.cc.Invoke(ctx, InventoryService_Confirm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseResponse)
+	err := c.cc.Invoke(ctx, InventoryService_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateLocation(ctx context.Context, in *CreateLocationRequest, opts ...grpc.CallOption) (*CreateLocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLocationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLocations(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*ListLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocationsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovementsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetOversellPolicy(ctx context.Context, in *SetOversellPolicyRequest, opts ...grpc.CallOption) (*SetOversellPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOversellPolicyResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetOversellPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReorderPointResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetReorderPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLots(ctx context.Context, in *ListLotsRequest, opts ...grpc.CallOption) (*ListLotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLotsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpiringLotsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListExpiringLots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetLotAllocationPolicy(ctx context.Context, in *SetLotAllocationPolicyRequest, opts ...grpc.CallOption) (*SetLotAllocationPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLotAllocationPolicyResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetLotAllocationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceiveSerials(ctx context.Context, in *ReceiveSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SerialOperationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReserveSerials(ctx context.Context, in *ReserveSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SerialOperationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReserveSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ShipSerials(ctx context.Context, in *ShipSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SerialOperationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ShipSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReturnSerials(ctx context.Context, in *ReturnSerialsRequest, opts ...grpc.CallOption) (*SerialOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SerialOperationResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReturnSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListSerials(ctx context.Context, in *ListSerialsRequest, opts ...grpc.CallOption) (*ListSerialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSerialsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListSerials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) TraceSerial(ctx context.Context, in *TraceSerialRequest, opts ...grpc.CallOption) (*TraceSerialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TraceSerialResponse)
+	err := c.cc.Invoke(ctx, InventoryService_TraceSerial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) TransitionStock(ctx context.Context, in *TransitionStockRequest, opts ...grpc.CallOption) (*TransitionStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransitionStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_TransitionStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ShipTransfer(ctx context.Context, in *ShipTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ShipTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReceiveTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CreateCountSession(ctx context.Context, in *CreateCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CreateCountSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetCountSession(ctx context.Context, in *GetCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetCountSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListCountSessions(ctx context.Context, in *ListCountSessionsRequest, opts ...grpc.CallOption) (*ListCountSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCountSessionsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListCountSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RecordCounts(ctx context.Context, in *RecordCountsRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RecordCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SubmitCountSession(ctx context.Context, in *SubmitCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SubmitCountSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ApproveCountSession(ctx context.Context, in *ApproveCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ApproveCountSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CancelCountSession(ctx context.Context, in *CancelCountSessionRequest, opts ...grpc.CallOption) (*CountSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CancelCountSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -91,117 +496,836 @@ type InventoryServiceServer interface {
 	UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryResponse, error)
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
 	GetInventories(context.Context, *GetInventoriesRequest) (*GetInventoriesResponse, error)
+	BatchAdjustInventory(context.Context, *BatchAdjustInventoryRequest) (*BatchAdjustInventoryResponse, error)
+	WatchInventory(*WatchRequest, grpc.ServerStreamingServer[InventoryChange]) error
+	Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error)
+	Confirm(context.Context, *ConfirmRequest) (*ConfirmResponse, error)
+	Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
+	CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error)
+	ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error)
+	ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error)
+	SetOversellPolicy(context.Context, *SetOversellPolicyRequest) (*SetOversellPolicyResponse, error)
+	SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error)
+	ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error)
+	ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error)
+	SetLotAllocationPolicy(context.Context, *SetLotAllocationPolicyRequest) (*SetLotAllocationPolicyResponse, error)
+	ReceiveSerials(context.Context, *ReceiveSerialsRequest) (*SerialOperationResponse, error)
+	ReserveSerials(context.Context, *ReserveSerialsRequest) (*SerialOperationResponse, error)
+	ShipSerials(context.Context, *ShipSerialsRequest) (*SerialOperationResponse, error)
+	ReturnSerials(context.Context, *ReturnSerialsRequest) (*SerialOperationResponse, error)
+	ListSerials(context.Context, *ListSerialsRequest) (*ListSerialsResponse, error)
+	TraceSerial(context.Context, *TraceSerialRequest) (*TraceSerialResponse, error)
+	TransitionStock(context.Context, *TransitionStockRequest) (*TransitionStockResponse, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*TransferResponse, error)
+	GetTransfer(context.Context, *GetTransferRequest) (*TransferResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	ShipTransfer(context.Context, *ShipTransferRequest) (*TransferResponse, error)
+	ReceiveTransfer(context.Context, *ReceiveTransferRequest) (*TransferResponse, error)
+	CancelTransfer(context.Context, *CancelTransferRequest) (*TransferResponse, error)
+	CreateCountSession(context.Context, *CreateCountSessionRequest) (*CountSessionResponse, error)
+	GetCountSession(context.Context, *GetCountSessionRequest) (*CountSessionResponse, error)
+	ListCountSessions(context.Context, *ListCountSessionsRequest) (*ListCountSessionsResponse, error)
+	RecordCounts(context.Context, *RecordCountsRequest) (*CountSessionResponse, error)
+	SubmitCountSession(context.Context, *SubmitCountSessionRequest) (*CountSessionResponse, error)
+	ApproveCountSession(context.Context, *ApproveCountSessionRequest) (*CountSessionResponse, error)
+	CancelCountSession(context.Context, *CancelCountSessionRequest) (*CountSessionResponse, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
-// UnimplementedInventoryServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedInventoryServiceServer struct{}
+// UnimplementedInventoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryServiceServer struct{}
+
+func (UnimplementedInventoryServiceServer) CreateInventory(context.Context, *CreateInventoryRequest) (*CreateInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetInventories(context.Context, *GetInventoriesRequest) (*GetInventoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventories not implemented")
+}
+func (UnimplementedInventoryServiceServer) BatchAdjustInventory(context.Context, *BatchAdjustInventoryRequest) (*BatchAdjustInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAdjustInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) WatchInventory(*WatchRequest, grpc.ServerStreamingServer[InventoryChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInventory not implemented")
+}
+func (UnimplementedInventoryServiceServer) Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedInventoryServiceServer) Confirm(context.Context, *ConfirmRequest) (*ConfirmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Confirm not implemented")
+}
+func (UnimplementedInventoryServiceServer) Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateLocation(context.Context, *CreateLocationRequest) (*CreateLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLocation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLocations(context.Context, *ListLocationsRequest) (*ListLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocations not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovements not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetOversellPolicy(context.Context, *SetOversellPolicyRequest) (*SetOversellPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOversellPolicy not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReorderPoint not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLots(context.Context, *ListLotsRequest) (*ListLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLots not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetLotAllocationPolicy(context.Context, *SetLotAllocationPolicyRequest) (*SetLotAllocationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLotAllocationPolicy not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveSerials(context.Context, *ReceiveSerialsRequest) (*SerialOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReserveSerials(context.Context, *ReserveSerialsRequest) (*SerialOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ShipSerials(context.Context, *ShipSerialsRequest) (*SerialOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReturnSerials(context.Context, *ReturnSerialsRequest) (*SerialOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListSerials(context.Context, *ListSerialsRequest) (*ListSerialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSerials not implemented")
+}
+func (UnimplementedInventoryServiceServer) TraceSerial(context.Context, *TraceSerialRequest) (*TraceSerialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TraceSerial not implemented")
+}
+func (UnimplementedInventoryServiceServer) TransitionStock(context.Context, *TransitionStockRequest) (*TransitionStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateTransfer(context.Context, *CreateTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetTransfer(context.Context, *GetTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedInventoryServiceServer) ShipTransfer(context.Context, *ShipTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReceiveTransfer(context.Context, *ReceiveTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelTransfer(context.Context, *CancelTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransfer not implemented")
+}
+func (UnimplementedInventoryServiceServer) CreateCountSession(context.Context, *CreateCountSessionRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetCountSession(context.Context, *GetCountSessionRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListCountSessions(context.Context, *ListCountSessionsRequest) (*ListCountSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountSessions not implemented")
+}
+func (UnimplementedInventoryServiceServer) RecordCounts(context.Context, *RecordCountsRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordCounts not implemented")
+}
+func (UnimplementedInventoryServiceServer) SubmitCountSession(context.Context, *SubmitCountSessionRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) ApproveCountSession(context.Context, *ApproveCountSessionRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) CancelCountSession(context.Context, *CancelCountSessionRequest) (*CountSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCountSession not implemented")
+}
+func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
+func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryServiceServer will
+// result in compilation errors.
+type UnsafeInventoryServiceServer interface {
+	mustEmbedUnimplementedInventoryServiceServer()
+}
+
+func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InventoryService_ServiceDesc, srv)
+}
+
+func _InventoryService_CreateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateInventory(ctx, req.(*CreateInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_UpdateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).UpdateInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_UpdateInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).UpdateInventory(ctx, req.(*UpdateInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetInventories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetInventories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetInventories(ctx, req.(*GetInventoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_BatchAdjustInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAdjustInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).BatchAdjustInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_BatchAdjustInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).BatchAdjustInventory(ctx, req.(*BatchAdjustInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_WatchInventory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchInventory(m, &grpc.GenericServerStream[WatchRequest, InventoryChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchInventoryServer = grpc.ServerStreamingServer[InventoryChange]
+
+func _InventoryService_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_Reserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).Reserve(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_Confirm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).Confirm(ctx, req.(*ConfirmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).Release(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CreateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateLocation(ctx, req.(*CreateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLocations(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SetOversellPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOversellPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetOversellPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetOversellPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetOversellPolicy(ctx, req.(*SetOversellPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SetReorderPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReorderPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetReorderPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, req.(*SetReorderPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLots(ctx, req.(*ListLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListExpiringLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListExpiringLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListExpiringLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListExpiringLots(ctx, req.(*ListExpiringLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SetLotAllocationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLotAllocationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetLotAllocationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetLotAllocationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetLotAllocationPolicy(ctx, req.(*SetLotAllocationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReceiveSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveSerials(ctx, req.(*ReceiveSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReserveSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReserveSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReserveSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReserveSerials(ctx, req.(*ReserveSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ShipSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ShipSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ShipSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ShipSerials(ctx, req.(*ShipSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReturnSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReturnSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReturnSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReturnSerials(ctx, req.(*ReturnSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListSerials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSerialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListSerials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListSerials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListSerials(ctx, req.(*ListSerialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_TraceSerial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraceSerialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).TraceSerial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_TraceSerial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).TraceSerial(ctx, req.(*TraceSerialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedInventoryServiceServer) CreateInventory(context.Context, *CreateInventoryRequest) (*CreateInventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateInventory not implemented")
+func _InventoryService_TransitionStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).TransitionStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_TransitionStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).TransitionStock(ctx, req.(*TransitionStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) UpdateInventory(context.Context, *UpdateInventoryRequest) (*UpdateInventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateInventory not implemented")
+
+func _InventoryService_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CreateTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
+
+func _InventoryService_GetTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetTransfer(ctx, req.(*GetTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) GetInventories(context.Context, *GetInventoriesRequest) (*GetInventoriesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetInventories not implemented")
+
+func _InventoryService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
-func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeInventoryServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to InventoryServiceServer will
-// result in compilation errors.
-type UnsafeInventoryServiceServer interface {
-	mustEmbedUnimplementedInventoryServiceServer()
+func _InventoryService_ShipTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ShipTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ShipTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ShipTransfer(ctx, req.(*ShipTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterInventoryServiceServer(s grpc.ServiceRegistrar, srv InventoryServiceServer) {
-	// If the following call pancis, it indicates UnimplementedInventoryServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _InventoryService_ReceiveTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&InventoryService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReceiveTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReceiveTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReceiveTransfer(ctx, req.(*ReceiveTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_CreateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateInventoryRequest)
+func _InventoryService_CancelTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTransferRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).CreateInventory(ctx, in)
+		return srv.(InventoryServiceServer).CancelTransfer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_CreateInventory_FullMethodName,
+		FullMethod: InventoryService_CancelTransfer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).CreateInventory(ctx, req.(*CreateInventoryRequest))
+		return srv.(InventoryServiceServer).CancelTransfer(ctx, req.(*CancelTransferRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_UpdateInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInventoryRequest)
+func _InventoryService_CreateCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCountSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).UpdateInventory(ctx, in)
+		return srv.(InventoryServiceServer).CreateCountSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_UpdateInventory_FullMethodName,
+		FullMethod: InventoryService_CreateCountSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).UpdateInventory(ctx, req.(*UpdateInventoryRequest))
+		return srv.(InventoryServiceServer).CreateCountSession(ctx, req.(*CreateCountSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInventoryRequest)
+func _InventoryService_GetCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetInventory(ctx, in)
+		return srv.(InventoryServiceServer).GetCountSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetInventory_FullMethodName,
+		FullMethod: InventoryService_GetCountSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+		return srv.(InventoryServiceServer).GetCountSession(ctx, req.(*GetCountSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _InventoryService_GetInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInventoriesRequest)
+func _InventoryService_ListCountSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(InventoryServiceServer).GetInventories(ctx, in)
+		return srv.(InventoryServiceServer).ListCountSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: InventoryService_GetInventories_FullMethodName,
+		FullMethod: InventoryService_ListCountSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(InventoryServiceServer).GetInventories(ctx, req.(*GetInventoriesRequest))
+		return srv.(InventoryServiceServer).ListCountSessions(ctx, req.(*ListCountSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RecordCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RecordCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RecordCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RecordCounts(ctx, req.(*RecordCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SubmitCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SubmitCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SubmitCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SubmitCountSession(ctx, req.(*SubmitCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ApproveCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ApproveCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ApproveCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ApproveCountSession(ctx, req.(*ApproveCountSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CancelCountSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCountSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CancelCountSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CancelCountSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CancelCountSession(ctx, req.(*CancelCountSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -229,7 +1353,141 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInventories",
 			Handler:    _InventoryService_GetInventories_Handler,
 		},
+		{
+			MethodName: "BatchAdjustInventory",
+			Handler:    _InventoryService_BatchAdjustInventory_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _InventoryService_Reserve_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _InventoryService_Confirm_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _InventoryService_Release_Handler,
+		},
+		{
+			MethodName: "CreateLocation",
+			Handler:    _InventoryService_CreateLocation_Handler,
+		},
+		{
+			MethodName: "ListLocations",
+			Handler:    _InventoryService_ListLocations_Handler,
+		},
+		{
+			MethodName: "ListMovements",
+			Handler:    _InventoryService_ListMovements_Handler,
+		},
+		{
+			MethodName: "SetOversellPolicy",
+			Handler:    _InventoryService_SetOversellPolicy_Handler,
+		},
+		{
+			MethodName: "SetReorderPoint",
+			Handler:    _InventoryService_SetReorderPoint_Handler,
+		},
+		{
+			MethodName: "ListLots",
+			Handler:    _InventoryService_ListLots_Handler,
+		},
+		{
+			MethodName: "ListExpiringLots",
+			Handler:    _InventoryService_ListExpiringLots_Handler,
+		},
+		{
+			MethodName: "SetLotAllocationPolicy",
+			Handler:    _InventoryService_SetLotAllocationPolicy_Handler,
+		},
+		{
+			MethodName: "ReceiveSerials",
+			Handler:    _InventoryService_ReceiveSerials_Handler,
+		},
+		{
+			MethodName: "ReserveSerials",
+			Handler:    _InventoryService_ReserveSerials_Handler,
+		},
+		{
+			MethodName: "ShipSerials",
+			Handler:    _InventoryService_ShipSerials_Handler,
+		},
+		{
+			MethodName: "ReturnSerials",
+			Handler:    _InventoryService_ReturnSerials_Handler,
+		},
+		{
+			MethodName: "ListSerials",
+			Handler:    _InventoryService_ListSerials_Handler,
+		},
+		{
+			MethodName: "TraceSerial",
+			Handler:    _InventoryService_TraceSerial_Handler,
+		},
+		{
+			MethodName: "TransitionStock",
+			Handler:    _InventoryService_TransitionStock_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _InventoryService_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "GetTransfer",
+			Handler:    _InventoryService_GetTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _InventoryService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "ShipTransfer",
+			Handler:    _InventoryService_ShipTransfer_Handler,
+		},
+		{
+			MethodName: "ReceiveTransfer",
+			Handler:    _InventoryService_ReceiveTransfer_Handler,
+		},
+		{
+			MethodName: "CancelTransfer",
+			Handler:    _InventoryService_CancelTransfer_Handler,
+		},
+		{
+			MethodName: "CreateCountSession",
+			Handler:    _InventoryService_CreateCountSession_Handler,
+		},
+		{
+			MethodName: "GetCountSession",
+			Handler:    _InventoryService_GetCountSession_Handler,
+		},
+		{
+			MethodName: "ListCountSessions",
+			Handler:    _InventoryService_ListCountSessions_Handler,
+		},
+		{
+			MethodName: "RecordCounts",
+			Handler:    _InventoryService_RecordCounts_Handler,
+		},
+		{
+			MethodName: "SubmitCountSession",
+			Handler:    _InventoryService_SubmitCountSession_Handler,
+		},
+		{
+			MethodName: "ApproveCountSession",
+			Handler:    _InventoryService_ApproveCountSession_Handler,
+		},
+		{
+			MethodName: "CancelCountSession",
+			Handler:    _InventoryService_CancelCountSession_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchInventory",
+			Handler:       _InventoryService_WatchInventory_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory.proto",
 }
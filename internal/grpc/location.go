@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/repository"
+)
+
+// CreateLocation tạo mới một kho/location.
+func (s *inventoryGRPCServer) CreateLocation(ctx context.Context, req *inventorypb.CreateLocationRequest) (*inventorypb.CreateLocationResponse, error) {
+	log.Printf("gRPC CreateLocation: id=%s, name=%s", req.GetId(), req.GetName())
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	loc, err := s.locationRepo.CreateLocation(ctx, req.GetId(), req.GetName())
+	if errors.Is(err, repository.ErrLocationAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &inventorypb.CreateLocationResponse{
+		Location: &inventorypb.Location{Id: loc.ID, Name: loc.Name},
+	}, nil
+}
+
+// ListLocations trả về tất cả location.
+func (s *inventoryGRPCServer) ListLocations(ctx context.Context, req *inventorypb.ListLocationsRequest) (*inventorypb.ListLocationsResponse, error) {
+	locs, err := s.locationRepo.ListLocations(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	result := make([]*inventorypb.Location, 0, len(locs))
+	for _, loc := range locs {
+		result = append(result, &inventorypb.Location{Id: loc.ID, Name: loc.Name})
+	}
+	return &inventorypb.ListLocationsResponse{Locations: result}, nil
+}
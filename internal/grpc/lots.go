@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// ListLots trả về các lô của item theo thứ tự sẽ được lấy hàng.
+func (s *inventoryGRPCServer) ListLots(ctx context.Context, req *inventorypb.ListLotsRequest) (*inventorypb.ListLotsResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	lots, err := s.inventorySvc.ListLots(ctx, req.GetItemId(), req.GetLocationId(), req.GetIncludeEmpty())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.ListLotsResponse{Lots: toInventoryLotsPB(lots)}, nil
+}
+
+// ListExpiringLots trả về các lô còn hàng hết hạn trong within_days ngày tới, hạn dùng sớm nhất trước.
+func (s *inventoryGRPCServer) ListExpiringLots(ctx context.Context, req *inventorypb.ListExpiringLotsRequest) (*inventorypb.ListExpiringLotsResponse, error) {
+	lots, next, err := s.inventorySvc.ListExpiringLots(ctx, int(req.GetWithinDays()), req.GetLocationId(), req.GetPageToken(), int(req.GetPageSize()))
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.ListExpiringLotsResponse{Lots: toInventoryLotsPB(lots), NextPageToken: next}, nil
+}
+
+// SetLotAllocationPolicy đặt thứ tự lấy hàng từ các lô của item: fefo hoặc fifo.
+func (s *inventoryGRPCServer) SetLotAllocationPolicy(ctx context.Context, req *inventorypb.SetLotAllocationPolicyRequest) (*inventorypb.SetLotAllocationPolicyResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	item, err := s.inventorySvc.SetLotAllocationPolicy(ctx, req.GetItemId(), model.LotAllocationPolicy(req.GetPolicy()))
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.SetLotAllocationPolicyResponse{Item: toInventoryItemPB(item)}, nil
+}
+
+func lotReceiptFromPB(lot *inventorypb.LotReceipt) (*repository.LotReceipt, error) {
+	if lot == nil {
+		return nil, nil
+	}
+	manufacturedAt, err := service.ParseLotDate(lot.GetManufacturedAt())
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := service.ParseLotDate(lot.GetExpiresAt())
+	if err != nil {
+		return nil, err
+	}
+	return &repository.LotReceipt{LotNumber: lot.GetLotNumber(), ManufacturedAt: manufacturedAt, ExpiresAt: expiresAt}, nil
+}
+
+func toInventoryLotsPB(lots []*model.InventoryLot) []*inventorypb.InventoryLot {
+	result := make([]*inventorypb.InventoryLot, 0, len(lots))
+	for _, lot := range lots {
+		result = append(result, &inventorypb.InventoryLot{
+			Id:             lot.ID,
+			ItemId:         lot.ItemID,
+			LocationId:     lot.LocationID,
+			LotNumber:      lot.LotNumber,
+			ManufacturedAt: formatLotDate(lot.ManufacturedAt),
+			ExpiresAt:      formatLotDate(lot.ExpiresAt),
+			Quantity:       int32(lot.Quantity),
+			ReceivedAt:     lot.ReceivedAt.Unix(),
+			StockState:     string(lot.StockState),
+		})
+	}
+	return result
+}
+
+func formatLotDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
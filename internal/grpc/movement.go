@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// correlationIDFromContext lấy correlation ID từ metadata "x-correlation-id", sinh mới nếu client không gửi.
+func correlationIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-correlation-id"); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return idUtils.NewID()
+}
+
+// ListMovements trả về lịch sử thay đổi tồn kho của một item, phân trang theo page token.
+func (s *inventoryGRPCServer) ListMovements(ctx context.Context, req *inventorypb.ListMovementsRequest) (*inventorypb.ListMovementsResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	movements, nextToken, err := s.movementRepo.ListMovements(ctx, req.GetItemId(), req.GetLocationId(), req.GetPageToken(), int(req.GetPageSize()))
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &inventorypb.ListMovementsResponse{Movements: toStockMovementsPB(movements), NextPageToken: nextToken}, nil
+}
+
+func toStockMovementsPB(movements []*model.StockMovement) []*inventorypb.StockMovement {
+	result := make([]*inventorypb.StockMovement, 0, len(movements))
+	for _, m := range movements {
+		result = append(result, &inventorypb.StockMovement{
+			Id:            m.ID,
+			ItemId:        m.ItemID,
+			LocationId:    m.LocationID,
+			Delta:         int32(m.Delta),
+			Balance:       int32(m.Balance),
+			TotalBalance:  int32(m.TotalBalance),
+			Reason:        string(m.Reason),
+			Source:        string(m.Source),
+			CorrelationId: m.CorrelationID,
+			CreatedAt:     m.CreatedAt.Unix(),
+			SerialNumber:  m.SerialNumber,
+			StockState:    string(m.StockState),
+		})
+	}
+	return result
+}
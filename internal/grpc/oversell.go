@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// SetOversellPolicy đặt chính sách oversell cho item hoặc cho một location của item.
+func (s *inventoryGRPCServer) SetOversellPolicy(ctx context.Context, req *inventorypb.SetOversellPolicyRequest) (*inventorypb.SetOversellPolicyResponse, error) {
+	policy := model.OversellPolicy(req.GetPolicy())
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+	if !policy.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "policy must be one of deny, backorder, unlimited")
+	}
+	if req.GetBackorderLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "backorder_limit must not be negative")
+	}
+
+	item, err := s.inventorySvc.SetOversellPolicy(ctx, req.GetItemId(), req.GetLocationId(), policy, int(req.GetBackorderLimit()))
+	switch {
+	case errors.Is(err, repository.ErrInventoryNotFound), errors.Is(err, repository.ErrLocationNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrInsufficientStock):
+		return nil, status.Error(codes.FailedPrecondition, "current stock is below the limit allowed by the new policy")
+	case err != nil:
+		log.Printf("SetOversellPolicy error: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventorypb.SetOversellPolicyResponse{Item: toInventoryItemPB(item)}, nil
+}
+
+// insufficientStockStatus trả về status ResourceExhausted kèm ErrorInfo chứa available/requested
+// để client đọc được mà không cần phân tích message.
+func insufficientStockStatus(err error) (error, bool) {
+	var insufficient *repository.InsufficientStockError
+	if !errors.As(err, &insufficient) {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return status.Error(codes.ResourceExhausted, err.Error()), true
+		}
+		return nil, false
+	}
+	st, detailErr := status.New(codes.ResourceExhausted, insufficient.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: "INSUFFICIENT_STOCK",
+		Domain: "inventory-service",
+		Metadata: map[string]string{
+			"item_id":     insufficient.ItemID,
+			"location_id": insufficient.LocationID,
+			"available":   strconv.Itoa(insufficient.Available),
+			"requested":   strconv.Itoa(insufficient.Requested),
+		},
+	})
+	if detailErr != nil {
+		return status.Error(codes.ResourceExhausted, insufficient.Error()), true
+	}
+	return st.Err(), true
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestInsufficientStockStatus(t *testing.T) {
+	insufficient := &repository.InsufficientStockError{ItemID: "sku-1", LocationID: "wh-1", Available: 2, Requested: 5}
+	tests := []struct {
+		name         string
+		err          error
+		wantOK       bool
+		wantMetadata map[string]string
+	}{
+		{
+			name:   "typed error carries details",
+			err:    fmt.Errorf("reserve: %w", insufficient),
+			wantOK: true,
+			wantMetadata: map[string]string{
+				"item_id": "sku-1", "location_id": "wh-1", "available": "2", "requested": "5",
+			},
+		},
+		{name: "sentinel without details", err: repository.ErrInsufficientStock, wantOK: true},
+		{name: "other errors are not mapped", err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, ok := insufficientStockStatus(tt.err)
+			if ok != tt.wantOK {
+				t.Fatalf("insufficientStockStatus() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			st := status.Convert(err)
+			if st.Code() != codes.ResourceExhausted {
+				t.Errorf("code = %s, want ResourceExhausted", st.Code())
+			}
+			var info *errdetails.ErrorInfo
+			for _, d := range st.Details() {
+				if i, isInfo := d.(*errdetails.ErrorInfo); isInfo {
+					info = i
+				}
+			}
+			if tt.wantMetadata == nil {
+				if info != nil {
+					t.Errorf("unexpected ErrorInfo %v", info)
+				}
+				return
+			}
+			if info == nil || info.Reason != "INSUFFICIENT_STOCK" {
+				t.Fatalf("ErrorInfo = %v, want reason INSUFFICIENT_STOCK", info)
+			}
+			for k, want := range tt.wantMetadata {
+				if got := info.Metadata[k]; got != want {
+					t.Errorf("metadata[%s] = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
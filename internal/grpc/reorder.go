@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+)
+
+// SetReorderPoint đặt reorder point và safety stock của item; không truyền reorder_point để tắt cảnh báo.
+func (s *inventoryGRPCServer) SetReorderPoint(ctx context.Context, req *inventorypb.SetReorderPointRequest) (*inventorypb.SetReorderPointResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	item, err := s.inventorySvc.SetReorderPoint(ctx, req.GetItemId(), optionalInt(req.ReorderPoint), optionalInt(req.SafetyStock))
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.SetReorderPointResponse{Item: toInventoryItemPB(item)}, nil
+}
+
+func optionalInt(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func optionalInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// Reserve giữ chỗ tồn kho cho một đơn hàng đang checkout.
+func (s *inventoryGRPCServer) Reserve(ctx context.Context, req *inventorypb.ReserveRequest) (*inventorypb.ReserveResponse, error) {
+	log.Printf("gRPC Reserve: item_id=%s, location_id=%s, quantity=%d, order_id=%s", req.GetItemId(), req.GetLocationId(), req.GetQuantity(), req.GetOrderId())
+	if req.GetItemId() == "" || req.GetQuantity() <= 0 || req.GetTtlSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "item_id and a positive quantity are required")
+	}
+
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	res, err := s.reservationSvc.Reserve(ctx, req.GetItemId(), req.GetLocationId(), req.GetOrderId(), int(req.GetQuantity()), ttl)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+	return &inventorypb.ReserveResponse{Reservation: toReservationPB(res)}, nil
+}
+
+// Confirm chốt reservation và trừ tồn kho thực tế.
+func (s *inventoryGRPCServer) Confirm(ctx context.Context, req *inventorypb.ConfirmRequest) (*inventorypb.ConfirmResponse, error) {
+	log.Printf("gRPC Confirm: reservation_id=%s", req.GetReservationId())
+	if req.GetReservationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	res, err := s.reservationSvc.Confirm(ctx, req.GetReservationId(), model.MovementSourceGRPC)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+	return &inventorypb.ConfirmResponse{Reservation: toReservationPB(res)}, nil
+}
+
+// Release huỷ reservation và trả lại lượng đã giữ chỗ.
+func (s *inventoryGRPCServer) Release(ctx context.Context, req *inventorypb.ReleaseRequest) (*inventorypb.ReleaseResponse, error) {
+	log.Printf("gRPC Release: reservation_id=%s", req.GetReservationId())
+	if req.GetReservationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	res, err := s.reservationSvc.Release(ctx, req.GetReservationId())
+	if err != nil {
+		return nil, reservationError(err)
+	}
+	return &inventorypb.ReleaseResponse{Reservation: toReservationPB(res)}, nil
+}
+
+// reservationError ánh xạ lỗi repository sang gRPC status code.
+func reservationError(err error) error {
+	if st, ok := insufficientStockStatus(err); ok {
+		return st
+	}
+	switch {
+	case errors.Is(err, repository.ErrInventoryNotFound), errors.Is(err, repository.ErrReservationNotFound),
+		errors.Is(err, repository.ErrLocationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrReservationNotPending), errors.Is(err, repository.ErrReservationExpired),
+		errors.Is(err, repository.ErrSerializedItem):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		log.Printf("Reservation error: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toReservationPB(res *model.Reservation) *inventorypb.Reservation {
+	return &inventorypb.Reservation{
+		Id:         res.ID,
+		ItemId:     res.ItemID,
+		LocationId: res.LocationID,
+		OrderId:    res.OrderID,
+		Quantity:   int32(res.Quantity),
+		Status:     string(res.Status),
+		ExpiresAt:  res.ExpiresAt.Unix(),
+	}
+}
@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/service"
+)
+
+// ReceiveSerials nhập các serial mới của item serialized vào một location.
+func (s *inventoryGRPCServer) ReceiveSerials(ctx context.Context, req *inventorypb.ReceiveSerialsRequest) (*inventorypb.SerialOperationResponse, error) {
+	return s.applySerials(ctx, "grpc:ReceiveSerials", req, service.SerialReceive, service.SerialRequest{
+		ItemID:        req.GetItemId(),
+		LocationID:    req.GetLocationId(),
+		SerialNumbers: req.GetSerialNumbers(),
+	})
+}
+
+// ReserveSerials giữ các serial available cho một đơn hàng.
+func (s *inventoryGRPCServer) ReserveSerials(ctx context.Context, req *inventorypb.ReserveSerialsRequest) (*inventorypb.SerialOperationResponse, error) {
+	return s.applySerials(ctx, "grpc:ReserveSerials", req, service.SerialReserve, service.SerialRequest{
+		ItemID:        req.GetItemId(),
+		SerialNumbers: req.GetSerialNumbers(),
+		OrderID:       req.GetOrderId(),
+	})
+}
+
+// ShipSerials xuất các serial available hoặc đang giữ cho đơn hàng.
+func (s *inventoryGRPCServer) ShipSerials(ctx context.Context, req *inventorypb.ShipSerialsRequest) (*inventorypb.SerialOperationResponse, error) {
+	return s.applySerials(ctx, "grpc:ShipSerials", req, service.SerialShip, service.SerialRequest{
+		ItemID:        req.GetItemId(),
+		SerialNumbers: req.GetSerialNumbers(),
+		OrderID:       req.GetOrderId(),
+	})
+}
+
+// ReturnSerials nhận lại serial đã xuất, hoặc huỷ giữ serial đang reserved.
+func (s *inventoryGRPCServer) ReturnSerials(ctx context.Context, req *inventorypb.ReturnSerialsRequest) (*inventorypb.SerialOperationResponse, error) {
+	return s.applySerials(ctx, "grpc:ReturnSerials", req, service.SerialReturn, service.SerialRequest{
+		ItemID:        req.GetItemId(),
+		LocationID:    req.GetLocationId(),
+		SerialNumbers: req.GetSerialNumbers(),
+	})
+}
+
+func (s *inventoryGRPCServer) applySerials(ctx context.Context, scope string, req proto.Message, op service.SerialOperation, serialReq service.SerialRequest) (*inventorypb.SerialOperationResponse, error) {
+	if serialReq.ItemID == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+	serialReq.Source = model.MovementSourceGRPC
+	serialReq.CorrelationID = correlationIDFromContext(ctx)
+
+	resp := &inventorypb.SerialOperationResponse{}
+	err := s.runIdempotent(ctx, scope, req, resp, func(tx *sql.Tx) error {
+		serials, err := s.inventorySvc.ApplySerialsTx(ctx, tx, op, serialReq)
+		if err != nil {
+			return err
+		}
+		resp.Serials = toInventorySerialsPB(serials)
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	s.inventorySvc.InvalidateCache(ctx, serialReq.ItemID)
+	return resp, nil
+}
+
+// ListSerials trả về các serial của item, lọc theo trạng thái và location.
+func (s *inventoryGRPCServer) ListSerials(ctx context.Context, req *inventorypb.ListSerialsRequest) (*inventorypb.ListSerialsResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	serials, next, err := s.inventorySvc.ListSerials(ctx, req.GetItemId(), model.SerialStatus(req.GetStatus()),
+		req.GetLocationId(), req.GetPageToken(), int(req.GetPageSize()))
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.ListSerialsResponse{Serials: toInventorySerialsPB(serials), NextPageToken: next}, nil
+}
+
+// TraceSerial trả về trạng thái hiện tại và vòng đời của serial từ sổ cái movement.
+func (s *inventoryGRPCServer) TraceSerial(ctx context.Context, req *inventorypb.TraceSerialRequest) (*inventorypb.TraceSerialResponse, error) {
+	if req.GetItemId() == "" || req.GetSerialNumber() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id and serial_number are required")
+	}
+
+	trace, err := s.inventorySvc.TraceSerial(ctx, req.GetItemId(), req.GetSerialNumber())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	resp := &inventorypb.TraceSerialResponse{Movements: toStockMovementsPB(trace.Movements)}
+	if trace.Serial != nil {
+		resp.Serial = toInventorySerialPB(trace.Serial)
+	}
+	return resp, nil
+}
+
+func toInventorySerialPB(serial *model.InventorySerial) *inventorypb.InventorySerial {
+	return &inventorypb.InventorySerial{
+		ItemId:       serial.ItemID,
+		SerialNumber: serial.SerialNumber,
+		LocationId:   serial.LocationID,
+		Status:       string(serial.Status),
+		OrderId:      serial.OrderID,
+		UpdatedAt:    serial.UpdatedAt.Unix(),
+	}
+}
+
+func toInventorySerialsPB(serials []*model.InventorySerial) []*inventorypb.InventorySerial {
+	result := make([]*inventorypb.InventorySerial, 0, len(serials))
+	for _, serial := range serials {
+		result = append(result, toInventorySerialPB(serial))
+	}
+	return result
+}
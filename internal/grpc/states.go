@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// TransitionStock chuyển hàng giữa các trạng thái tồn kho tại một location và trả về item sau thay đổi.
+func (s *inventoryGRPCServer) TransitionStock(ctx context.Context, req *inventorypb.TransitionStockRequest) (*inventorypb.TransitionStockResponse, error) {
+	if req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "item_id is required")
+	}
+
+	resp := &inventorypb.TransitionStockResponse{}
+	err := s.runIdempotent(ctx, "grpc:TransitionStock", req, resp, func(tx *sql.Tx) error {
+		_, err := s.inventorySvc.TransitionStockTx(ctx, tx, repository.StockTransition{
+			ItemID:          req.GetItemId(),
+			LocationID:      req.GetLocationId(),
+			From:            model.StockState(req.GetFrom()),
+			To:              model.StockState(req.GetTo()),
+			Quantity:        int(req.GetQuantity()),
+			Reason:          model.MovementReason(req.GetReason()),
+			Source:          model.MovementSourceGRPC,
+			CorrelationID:   correlationIDFromContext(ctx),
+			ExpectedVersion: req.GetExpectedVersion(),
+		})
+		if err != nil {
+			return err
+		}
+		item, err := s.inventorySvc.GetInventoryTx(ctx, tx, req.GetItemId())
+		if err != nil {
+			return err
+		}
+		resp.Item = toInventoryItemPB(item)
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	s.inventorySvc.InvalidateCache(ctx, req.GetItemId())
+	return resp, nil
+}
+
+func toStockBreakdownPB(b model.StockBreakdown) *inventorypb.StockBreakdown {
+	return &inventorypb.StockBreakdown{
+		OnHand:             int32(b.OnHand),
+		Reserved:           int32(b.Reserved),
+		AvailableToPromise: int32(b.AvailableToPromise),
+		InTransit:          int32(b.InTransit),
+		Quarantined:        int32(b.Quarantined),
+		Damaged:            int32(b.Damaged),
+	}
+}
@@ -0,0 +1,145 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
+)
+
+// CreateTransfer tạo lệnh chuyển kho ở trạng thái draft.
+func (s *inventoryGRPCServer) CreateTransfer(ctx context.Context, req *inventorypb.CreateTransferRequest) (*inventorypb.TransferResponse, error) {
+	in := service.TransferInput{
+		SourceLocationID:      req.GetSourceLocationId(),
+		DestinationLocationID: req.GetDestinationLocationId(),
+		Note:                  req.GetNote(),
+	}
+	for _, line := range req.GetLines() {
+		in.Lines = append(in.Lines, model.TransferLine{ItemID: line.GetItemId(), Quantity: int(line.GetQuantity())})
+	}
+	return s.transferMutation(ctx, "grpc:CreateTransfer", req, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return s.transferSvc.CreateTransferTx(ctx, tx, in)
+	})
+}
+
+func (s *inventoryGRPCServer) GetTransfer(ctx context.Context, req *inventorypb.GetTransferRequest) (*inventorypb.TransferResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	t, err := s.transferSvc.GetTransfer(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	return &inventorypb.TransferResponse{Transfer: toTransferOrderPB(t)}, nil
+}
+
+// ListTransfers trả về các lệnh chuyển kho, mới nhất trước.
+func (s *inventoryGRPCServer) ListTransfers(ctx context.Context, req *inventorypb.ListTransfersRequest) (*inventorypb.ListTransfersResponse, error) {
+	transfers, next, err := s.transferSvc.ListTransfers(ctx, repository.TransferListOptions{
+		Status:     model.TransferStatus(req.GetStatus()),
+		LocationID: req.GetLocationId(),
+		Cursor:     req.GetPageToken(),
+		PageSize:   int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	resp := &inventorypb.ListTransfersResponse{NextPageToken: next}
+	for _, t := range transfers {
+		resp.Transfers = append(resp.Transfers, toTransferOrderPB(t))
+	}
+	return resp, nil
+}
+
+// ShipTransfer xuất lệnh draft: trừ tồn kho ở nguồn và ghi hàng vào in_transit của đích.
+func (s *inventoryGRPCServer) ShipTransfer(ctx context.Context, req *inventorypb.ShipTransferRequest) (*inventorypb.TransferResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.transferMutation(ctx, "grpc:ShipTransfer", req, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return s.transferSvc.ShipTransferTx(ctx, tx, req.GetId(), model.MovementSourceGRPC)
+	})
+}
+
+// ReceiveTransfer ghi một lần nhận hàng (có thể một phần) của lệnh đã xuất.
+func (s *inventoryGRPCServer) ReceiveTransfer(ctx context.Context, req *inventorypb.ReceiveTransferRequest) (*inventorypb.TransferResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	receipts := make([]model.TransferReceipt, 0, len(req.GetLines()))
+	for _, line := range req.GetLines() {
+		receipts = append(receipts, model.TransferReceipt{ItemID: line.GetItemId(), Quantity: int(line.GetQuantity())})
+	}
+	return s.transferMutation(ctx, "grpc:ReceiveTransfer", req, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return s.transferSvc.ReceiveTransferTx(ctx, tx, req.GetId(), receipts, req.GetClose(), model.MovementSourceGRPC)
+	})
+}
+
+// CancelTransfer huỷ lệnh draft, hoặc lệnh đã xuất nhưng chưa nhận.
+func (s *inventoryGRPCServer) CancelTransfer(ctx context.Context, req *inventorypb.CancelTransferRequest) (*inventorypb.TransferResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.transferMutation(ctx, "grpc:CancelTransfer", req, func(tx *sql.Tx) (*model.TransferOrder, error) {
+		return s.transferSvc.CancelTransferTx(ctx, tx, req.GetId(), model.MovementSourceGRPC)
+	})
+}
+
+func (s *inventoryGRPCServer) transferMutation(ctx context.Context, scope string, req proto.Message, fn func(tx *sql.Tx) (*model.TransferOrder, error)) (*inventorypb.TransferResponse, error) {
+	resp := &inventorypb.TransferResponse{}
+	var transfer *model.TransferOrder
+	err := s.runIdempotent(ctx, scope, req, resp, func(tx *sql.Tx) error {
+		t, err := fn(tx)
+		if err != nil {
+			return err
+		}
+		transfer = t
+		resp.Transfer = toTransferOrderPB(t)
+		return nil
+	})
+	if err != nil {
+		return nil, inventoryError(err)
+	}
+	// Response replay từ idempotency key không chạy fn, nên không có gì mới cần invalidate.
+	if transfer != nil {
+		s.transferSvc.InvalidateCache(ctx, transfer)
+	}
+	return resp, nil
+}
+
+func toTransferOrderPB(t *model.TransferOrder) *inventorypb.TransferOrder {
+	pb := &inventorypb.TransferOrder{
+		Id:                    t.ID,
+		SourceLocationId:      t.SourceLocationID,
+		DestinationLocationId: t.DestinationLocationID,
+		Status:                string(t.Status),
+		Note:                  t.Note,
+		CreatedAt:             t.CreatedAt.Unix(),
+		UpdatedAt:             t.UpdatedAt.Unix(),
+		ShippedAt:             unixOrZero(t.ShippedAt),
+		ClosedAt:              unixOrZero(t.ClosedAt),
+	}
+	for _, line := range t.Lines {
+		pb.Lines = append(pb.Lines, &inventorypb.TransferLine{
+			ItemId:           line.ItemID,
+			Quantity:         int32(line.Quantity),
+			ReceivedQuantity: int32(line.ReceivedQuantity),
+			Discrepancy:      int32(line.Discrepancy),
+		})
+	}
+	return pb
+}
+
+func unixOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.Unix()
+}
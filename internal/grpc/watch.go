@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/service"
+)
+
+// WatchInventory stream trạng thái ban đầu và các thay đổi tồn kho của các item trong request,
+// thay cho việc polling GetInventories.
+func (s *inventoryGRPCServer) WatchInventory(req *inventorypb.WatchRequest, stream grpc.ServerStreamingServer[inventorypb.InventoryChange]) error {
+	log.Printf("gRPC WatchInventory: items=%d, prefixes=%d, resume=%t", len(req.GetItemIds()), len(req.GetPrefixes()), req.GetResumeToken() != "")
+
+	ctx := stream.Context()
+	err := s.watcher.Watch(ctx, service.WatchRequest{
+		ItemIDs:     req.GetItemIds(),
+		Prefixes:    req.GetPrefixes(),
+		ResumeToken: req.GetResumeToken(),
+	}, func(change model.InventoryChange) error {
+		return stream.Send(toInventoryChangePB(change))
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, service.ErrInvalidWatch), errors.Is(err, service.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrWatcherStopped):
+		return status.Error(codes.Unavailable, "server is shutting down, reconnect with the last resume_token")
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(ctx.Err()).Err()
+	default:
+		return inventoryError(err)
+	}
+}
+
+func toInventoryChangePB(change model.InventoryChange) *inventorypb.InventoryChange {
+	pb := &inventorypb.InventoryChange{
+		Type:        string(change.Type),
+		ItemId:      change.ItemID,
+		ResumeToken: change.ResumeToken,
+	}
+	if change.Item != nil {
+		pb.Item = toInventoryItemPB(change.Item)
+	}
+	return pb
+}
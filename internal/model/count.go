@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// CountSessionStatus là trạng thái của một phiên kiểm kê.
+type CountSessionStatus string
+
+const (
+	CountSessionOpen            CountSessionStatus = "open"             // đang nhận số lượng đếm
+	CountSessionPendingApproval CountSessionStatus = "pending_approval" // có chênh lệch vượt ngưỡng, chờ duyệt
+	CountSessionPosted          CountSessionStatus = "posted"           // chênh lệch đã được ghi thành movement điều chỉnh
+	CountSessionCancelled       CountSessionStatus = "cancelled"
+)
+
+// CountSession là một phiên kiểm kê các item tại một location. Số lượng kỳ vọng được chụp (snapshot) khi tạo
+// phiên; khi post, chênh lệch giữa số đếm và snapshot được cộng vào tồn kho hiện tại, nên các thay đổi xảy ra
+// trong lúc đếm vẫn được giữ nguyên.
+type CountSession struct {
+	ID          string             `json:"id"`
+	LocationID  string             `json:"location_id"`
+	Status      CountSessionStatus `json:"status"`
+	Note        string             `json:"note,omitempty"`
+	Lines       []CountLine        `json:"lines"`
+	ApprovedBy  string             `json:"approved_by,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	SubmittedAt *time.Time         `json:"submitted_at,omitempty"`
+	ClosedAt    *time.Time         `json:"closed_at,omitempty"` // thời điểm posted hoặc cancelled
+}
+
+// CountLine là số lượng kỳ vọng và số lượng đếm được của một item trong phiên kiểm kê.
+type CountLine struct {
+	ItemID           string     `json:"item_id"`
+	ExpectedQuantity int        `json:"expected_quantity"`          // on-hand tại location khi tạo phiên
+	CountedQuantity  *int       `json:"counted_quantity,omitempty"` // nil = chưa đếm, không được điều chỉnh
+	CountedAt        *time.Time `json:"counted_at,omitempty"`
+	// Variance = CountedQuantity - ExpectedQuantity; nil nếu chưa đếm.
+	Variance *int `json:"variance,omitempty"`
+	// RequiresApproval cho biết chênh lệch vượt ngưỡng cấu hình, phiên phải được duyệt trước khi post.
+	RequiresApproval bool `json:"requires_approval"`
+}
+
+// CountEntry là số lượng đếm được của một item.
+type CountEntry struct {
+	ItemID          string `json:"item_id"`
+	CountedQuantity int    `json:"counted_quantity"`
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// DeadLetterStatus là trạng thái xử lý của một dead letter.
+type DeadLetterStatus string
+
+const (
+	DeadLetterPending   DeadLetterStatus = "pending"
+	DeadLetterRedriven  DeadLetterStatus = "redriven"
+	DeadLetterDiscarded DeadLetterStatus = "discarded"
+)
+
+// RetryAttempt là một lần xử lý thất bại của event trong pipeline retry.
+type RetryAttempt struct {
+	Attempt int       `json:"attempt"`
+	Topic   string    `json:"topic"`
+	Error   string    `json:"error"`
+	At      time.Time `json:"at"`
+}
+
+// DeadLetter là event không thể xử lý, được lưu lại kèm payload gốc và lịch sử lỗi.
+type DeadLetter struct {
+	ID              int64            `json:"id"`
+	EventID         string           `json:"event_id"`
+	EventType       string           `json:"event_type"`
+	ItemID          string           `json:"item_id"`
+	MessageKey      string           `json:"message_key"`
+	Payload         string           `json:"payload"`
+	OriginalPayload string           `json:"original_payload"`
+	ErrorChain      []string         `json:"error_chain"`
+	AttemptHistory  []RetryAttempt   `json:"attempt_history"`
+	SourceTopic     string           `json:"source_topic"`
+	SourcePartition int              `json:"source_partition"`
+	SourceOffset    int64            `json:"source_offset"`
+	Status          DeadLetterStatus `json:"status"`
+	RedriveCount    int              `json:"redrive_count"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
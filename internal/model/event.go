@@ -3,7 +3,13 @@ package model
 import "time"
 
 type InventoryUpdateEvent struct {
+	EventID  string    `json:"event_id"`
 	Id       string    `json:"id"`
+	Location string    `json:"location,omitempty"`
 	Change   int       `json:"change"`
 	DateTime time.Time `json:"date_time"`
+
+	Reason        string `json:"reason,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	SerialNumber  string `json:"serial_number,omitempty"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// IdempotencyRecord là response đã lưu của một request mutation có Idempotency-Key.
+type IdempotencyRecord struct {
+	Scope          string // Phạm vi của key, ví dụ "http:update-inventory", "grpc:CreateInventory"
+	Key            string
+	Fingerprint    string // Hash của payload request, dùng để phát hiện key bị dùng lại với payload khác
+	ResponseStatus int    // HTTP status hoặc gRPC code của response
+	ResponseBody   []byte
+	ResponseETag   string // ETag của response HTTP, rỗng nếu không có
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
@@ -1,7 +1,56 @@
 package model
 
+import "time"
+
+// DefaultLocationID là location được dùng khi request không chỉ định location.
+const DefaultLocationID = "default"
+
+// OversellPolicy quy định tồn kho tại một location có được phép xuống dưới 0 hay không.
+type OversellPolicy string
+
+const (
+	OversellPolicyDeny      OversellPolicy = "deny"      // không cho phép tồn kho âm
+	OversellPolicyBackorder OversellPolicy = "backorder" // cho phép âm tới BackorderLimit
+	OversellPolicyUnlimited OversellPolicy = "unlimited" // không giới hạn
+)
+
+// Valid cho biết p có phải là một chính sách hợp lệ.
+func (p OversellPolicy) Valid() bool {
+	switch p {
+	case OversellPolicyDeny, OversellPolicyBackorder, OversellPolicyUnlimited:
+		return true
+	}
+	return false
+}
+
 type InventoryItem struct {
-	ID       string
-	Name     string
-	Quantity int
+	ID        string          `json:"id"`
+	Name      string          `json:"name,omitempty"`
+	Quantity  int             `json:"quantity"`  // tổng on-hand trên tất cả location
+	Locations []LocationStock `json:"locations"` // số lượng theo từng location
+	Version   int64           `json:"version"`   // tăng sau mỗi thay đổi, dùng cho ETag/If-Match
+	UpdatedAt time.Time       `json:"updated_at"`
+	Stock     StockBreakdown  `json:"stock"` // tổng tồn kho theo trạng thái trên tất cả location
+
+	OversellPolicy OversellPolicy `json:"oversell_policy"` // chính sách mặc định cho location mới
+	BackorderLimit int            `json:"backorder_limit"`
+
+	ReorderPoint    *int            `json:"reorder_point"` // nil = không cảnh báo tồn kho thấp
+	SafetyStock     *int            `json:"safety_stock"`
+	StockAlertState StockAlertState `json:"stock_alert_state"`
+
+	LotAllocationPolicy LotAllocationPolicy `json:"lot_allocation_policy"` // thứ tự lấy hàng từ các lô khi giảm tồn kho
+
+	// Serialized = true thì Quantity là số serial available và chỉ thay đổi qua các thao tác serial.
+	// Serial reserved không được tính vào Quantity nhưng có trong Stock.OnHand và Stock.Reserved.
+	Serialized bool `json:"serialized"`
+}
+
+// LocationStock là số lượng tồn kho của một item tại một location.
+type LocationStock struct {
+	LocationID     string         `json:"location_id"`
+	Quantity       int            `json:"quantity"`        // on-hand tại location
+	Stock          StockBreakdown `json:"stock"`           // tồn kho theo trạng thái tại location
+	OversellPolicy OversellPolicy `json:"oversell_policy"` // chính sách hiệu lực tại location
+	BackorderLimit int            `json:"backorder_limit"`
 }
@@ -13,8 +13,15 @@ const (
 
 // InventoryEvent định nghĩa cấu trúc chung của các event liên quan đến inventory.
 type InventoryEvent struct {
-	Type     InventoryEventType `json:"type"`      // Loại event: create, update, delete, ...
-	Id       string             `json:"id"`        // ID của sản phẩm
-	Quantity int                `json:"quantity"`  // Số lượng, dùng cho create/update
-	DateTime time.Time          `json:"date_time"` // Thời gian event xảy ra
+	EventID  string             `json:"event_id"`           // ID duy nhất của event, dùng để chống xử lý trùng
+	Type     InventoryEventType `json:"type"`               // Loại event: create, update, delete, ...
+	Id       string             `json:"id"`                 // ID của sản phẩm
+	Location string             `json:"location,omitempty"` // Location/kho, rỗng = location mặc định
+	Quantity int                `json:"quantity"`           // Số lượng, dùng cho create/update
+	DateTime time.Time          `json:"date_time"`          // Thời gian event xảy ra
+
+	Reason        string `json:"reason,omitempty"`         // Mã lý do ghi vào sổ movement
+	CorrelationID string `json:"correlation_id,omitempty"` // ID để truy vết thay đổi giữa các hệ thống
+
+	ExpectedVersion int64 `json:"expected_version,omitempty"` // Nếu khác 0, chỉ áp dụng khi version của item khớp
 }
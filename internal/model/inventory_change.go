@@ -0,0 +1,22 @@
+package model
+
+// InventoryChangeType là loại của một InventoryChange trong luồng watch.
+type InventoryChangeType string
+
+const (
+	// InventoryChangeSnapshot là trạng thái ban đầu của item khi bắt đầu watch không có resume token;
+	// Item nil nếu item chưa tồn tại.
+	InventoryChangeSnapshot InventoryChangeType = "snapshot"
+	InventoryChangeUpdated  InventoryChangeType = "updated"
+	InventoryChangeDeleted  InventoryChangeType = "deleted"
+)
+
+// InventoryChange là trạng thái mới của một item được gửi cho client đang watch.
+type InventoryChange struct {
+	Type   InventoryChangeType `json:"type"`
+	ItemID string              `json:"item_id"`
+	Item   *InventoryItem      `json:"item,omitempty"` // nil khi Type là deleted
+	// ResumeToken ghi nhận những gì client đã nhận tính tới change này; truyền lại khi watch lại
+	// để chỉ nhận các item thay đổi trong lúc mất kết nối.
+	ResumeToken string `json:"resume_token"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// Location đại diện cho một kho/địa điểm lưu trữ hàng.
+type Location struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// LotAllocationPolicy quy định thứ tự lấy hàng từ các lô khi tồn kho giảm.
+type LotAllocationPolicy string
+
+const (
+	LotAllocationFEFO LotAllocationPolicy = "fefo" // lô hết hạn trước được lấy trước; lô không có hạn dùng lấy sau cùng
+	LotAllocationFIFO LotAllocationPolicy = "fifo" // lô nhập trước được lấy trước
+)
+
+// Valid cho biết p có phải là một chính sách hợp lệ.
+func (p LotAllocationPolicy) Valid() bool {
+	return p == LotAllocationFEFO || p == LotAllocationFIFO
+}
+
+// InventoryLot là một lô hàng của item tại một location, trong một trạng thái tồn kho.
+// ManufacturedAt và ExpiresAt là ngày (không có giờ), nil nếu không có.
+type InventoryLot struct {
+	ID             int64      `json:"id"`
+	ItemID         string     `json:"item_id"`
+	LocationID     string     `json:"location_id"`
+	StockState     StockState `json:"stock_state"` // trạng thái tồn kho chứa lô; chỉ lô on_hand được allocate
+	LotNumber      string     `json:"lot_number"`
+	ManufacturedAt *time.Time `json:"manufactured_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	Quantity       int        `json:"quantity"`
+	ReceivedAt     time.Time  `json:"received_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
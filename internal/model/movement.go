@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// MovementSource cho biết thay đổi tồn kho đến từ kênh nào.
+type MovementSource string
+
+const (
+	MovementSourceHTTP  MovementSource = "http"
+	MovementSourceGRPC  MovementSource = "grpc"
+	MovementSourceKafka MovementSource = "kafka"
+)
+
+// MovementReason là mã lý do của một thay đổi tồn kho.
+type MovementReason string
+
+const (
+	MovementReasonCreate              MovementReason = "create"
+	MovementReasonAdjustment          MovementReason = "adjustment"
+	MovementReasonDelete              MovementReason = "delete"
+	MovementReasonReservationConfirm  MovementReason = "reservation_confirm"
+	MovementReasonSerialReceive       MovementReason = "serial_receive"
+	MovementReasonSerialReserve       MovementReason = "serial_reserve"
+	MovementReasonSerialShip          MovementReason = "serial_ship"
+	MovementReasonSerialReturn        MovementReason = "serial_return"
+	MovementReasonStateTransition     MovementReason = "state_transition"
+	MovementReasonTransferShip        MovementReason = "transfer_ship"
+	MovementReasonTransferReceive     MovementReason = "transfer_receive"
+	MovementReasonTransferCancel      MovementReason = "transfer_cancel"
+	MovementReasonTransferDiscrepancy MovementReason = "transfer_discrepancy"
+	MovementReasonCycleCount          MovementReason = "cycle_count"
+)
+
+// StockMovement là một dòng trong sổ cái movement (append-only).
+type StockMovement struct {
+	ID            int64          `json:"id"`
+	ItemID        string         `json:"item_id"`
+	LocationID    string         `json:"location_id"`
+	StockState    StockState     `json:"stock_state"` // trạng thái tồn kho mà delta/balance áp dụng
+	Delta         int            `json:"delta"`
+	Balance       int            `json:"balance"`       // số lượng của stock_state tại location sau thay đổi
+	TotalBalance  int            `json:"total_balance"` // tổng số lượng của stock_state trên mọi location sau thay đổi
+	Reason        MovementReason `json:"reason"`
+	Source        MovementSource `json:"source"`
+	CorrelationID string         `json:"correlation_id"`
+	SerialNumber  string         `json:"serial_number,omitempty"` // serial của item serialized, rỗng nếu không có
+	CreatedAt     time.Time      `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// OutboxEvent là một message chờ được relay publish lên Kafka.
+type OutboxEvent struct {
+	ID       int64
+	Topic    string
+	Key      string
+	Payload  []byte
+	Attempts int
+}
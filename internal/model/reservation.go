@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ReservationStatus định nghĩa các trạng thái của một reservation.
+type ReservationStatus string
+
+const (
+	ReservationPending   ReservationStatus = "pending"
+	ReservationConfirmed ReservationStatus = "confirmed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation giữ chỗ một lượng tồn kho cho đơn hàng đang chờ thanh toán.
+type Reservation struct {
+	ID         string
+	ItemID     string
+	LocationID string
+	OrderID    string
+	Quantity   int
+	Status     ReservationStatus
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
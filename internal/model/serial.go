@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// SerialStatus là trạng thái của một serial.
+type SerialStatus string
+
+const (
+	SerialStatusAvailable SerialStatus = "available" // có trong kho, được tính vào quantity
+	SerialStatusReserved  SerialStatus = "reserved"  // có trong kho, đang được giữ cho một đơn hàng; không tính vào quantity
+	SerialStatusShipped   SerialStatus = "shipped"   // đã xuất khỏi kho
+)
+
+// InventorySerial là trạng thái hiện tại của một serial của item serialized.
+type InventorySerial struct {
+	ItemID       string       `json:"item_id"`
+	SerialNumber string       `json:"serial_number"`
+	LocationID   string       `json:"location_id"`
+	Status       SerialStatus `json:"status"`
+	OrderID      string       `json:"order_id,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// SerialTrace là trạng thái hiện tại của serial (nil nếu item đã bị xoá) cùng mọi movement của nó, cũ nhất trước.
+type SerialTrace struct {
+	Serial    *InventorySerial `json:"serial"`
+	Movements []*StockMovement `json:"movements"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// StockAlertState là mức tồn kho hiện tại của item so với ngưỡng cảnh báo.
+type StockAlertState string
+
+const (
+	StockAlertStateOK  StockAlertState = "ok"  // trên reorder point
+	StockAlertStateLow StockAlertState = "low" // tại hoặc dưới reorder point
+	StockAlertStateOut StockAlertState = "out" // hết hàng
+)
+
+// StockAlertType là loại của StockAlertEvent.
+type StockAlertType string
+
+const (
+	StockAlertLowStock    StockAlertType = "low_stock"     // ok -> low
+	StockAlertOutOfStock  StockAlertType = "out_of_stock"  // ok/low -> out
+	StockAlertBackInStock StockAlertType = "back_in_stock" // out -> low/ok, hoặc low -> ok
+)
+
+// StockAlertEvent được publish lên topic cảnh báo mỗi khi trạng thái cảnh báo của item thay đổi.
+type StockAlertEvent struct {
+	EventID       string          `json:"event_id"`
+	Type          StockAlertType  `json:"type"`
+	Id            string          `json:"id"`
+	Quantity      int             `json:"quantity"` // tổng số lượng của item sau thay đổi
+	ReorderPoint  int             `json:"reorder_point"`
+	SafetyStock   *int            `json:"safety_stock,omitempty"`
+	State         StockAlertState `json:"state"`
+	PreviousState StockAlertState `json:"previous_state"`
+	// BelowSafetyStock cho biết tồn kho đã xuống tới mức safety stock, cần đặt hàng gấp.
+	BelowSafetyStock bool      `json:"below_safety_stock"`
+	DateTime         time.Time `json:"date_time"`
+}
@@ -0,0 +1,41 @@
+package model
+
+// StockState là một trạng thái (bucket) tồn kho được lưu theo location.
+type StockState string
+
+const (
+	StockStateOnHand      StockState = "on_hand"     // hàng tốt trong kho, gồm cả phần đang được giữ chỗ
+	StockStateInTransit   StockState = "in_transit"  // hàng đang chuyển tới location, chỉ thay đổi qua transfer
+	StockStateQuarantined StockState = "quarantined" // hàng chờ QA, chưa được bán
+	StockStateDamaged     StockState = "damaged"     // hàng hỏng
+)
+
+// Transitionable cho biết có thể chuyển hàng vào/ra state bằng thao tác chuyển trạng thái thủ công.
+func (s StockState) Transitionable() bool {
+	switch s {
+	case StockStateOnHand, StockStateQuarantined, StockStateDamaged:
+		return true
+	}
+	return false
+}
+
+// StockBreakdown là tồn kho theo trạng thái. Reserved là phần on-hand đang được giữ cho đơn hàng
+// (reservation pending và serial reserved); chỉ AvailableToPromise = OnHand - Reserved là bán được.
+type StockBreakdown struct {
+	OnHand             int `json:"on_hand"`
+	Reserved           int `json:"reserved"`
+	AvailableToPromise int `json:"available_to_promise"`
+	InTransit          int `json:"in_transit"`
+	Quarantined        int `json:"quarantined"`
+	Damaged            int `json:"damaged"`
+}
+
+// Add cộng other vào b.
+func (b *StockBreakdown) Add(other StockBreakdown) {
+	b.OnHand += other.OnHand
+	b.Reserved += other.Reserved
+	b.AvailableToPromise += other.AvailableToPromise
+	b.InTransit += other.InTransit
+	b.Quarantined += other.Quarantined
+	b.Damaged += other.Damaged
+}
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// TransferStatus là trạng thái của một lệnh chuyển kho.
+type TransferStatus string
+
+const (
+	TransferDraft             TransferStatus = "draft"              // chưa xuất, chưa ảnh hưởng tồn kho
+	TransferShipped           TransferStatus = "shipped"            // đã trừ ở nguồn, hàng nằm ở in_transit của đích
+	TransferPartiallyReceived TransferStatus = "partially_received" // đã nhận một phần
+	TransferReceived          TransferStatus = "received"           // đã đóng; phần chưa nhận được ghi vào discrepancy
+	TransferCancelled         TransferStatus = "cancelled"
+)
+
+// TransferOrder là lệnh chuyển hàng từ SourceLocationID sang DestinationLocationID.
+type TransferOrder struct {
+	ID                    string         `json:"id"`
+	SourceLocationID      string         `json:"source_location_id"`
+	DestinationLocationID string         `json:"destination_location_id"`
+	Status                TransferStatus `json:"status"`
+	Note                  string         `json:"note,omitempty"`
+	Lines                 []TransferLine `json:"lines"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	ShippedAt             *time.Time     `json:"shipped_at,omitempty"`
+	ClosedAt              *time.Time     `json:"closed_at,omitempty"` // thời điểm received hoặc cancelled
+}
+
+// TransferLine là số lượng của một item trong lệnh chuyển kho.
+type TransferLine struct {
+	ItemID           string `json:"item_id"`
+	Quantity         int    `json:"quantity"`          // số lượng xuất
+	ReceivedQuantity int    `json:"received_quantity"` // tổng đã nhận
+	// Discrepancy = Quantity - ReceivedQuantity khi lệnh đóng: dương là thiếu, âm là thừa.
+	Discrepancy int `json:"discrepancy"`
+}
+
+// TransferEventType là bước trong vòng đời lệnh chuyển kho đã sinh ra TransferEvent.
+type TransferEventType string
+
+const (
+	TransferEventCreated   TransferEventType = "created"
+	TransferEventShipped   TransferEventType = "shipped"
+	TransferEventReceived  TransferEventType = "received" // một lần nhận hàng, Status cho biết lệnh đã đóng chưa
+	TransferEventCancelled TransferEventType = "cancelled"
+)
+
+// TransferEvent được publish lên topic chuyển kho sau mỗi bước của lệnh, key theo ID lệnh.
+type TransferEvent struct {
+	EventID     string            `json:"event_id"`
+	Type        TransferEventType `json:"type"`
+	TransferID  string            `json:"transfer_id"`
+	Source      string            `json:"source_location_id"`
+	Destination string            `json:"destination_location_id"`
+	Status      TransferStatus    `json:"status"`
+	Lines       []TransferLine    `json:"lines"`
+	// Received là số lượng nhận trong lần nhận này (chỉ với Type = received).
+	Received []TransferReceipt `json:"received,omitempty"`
+	DateTime time.Time         `json:"date_time"`
+}
+
+// TransferReceipt là số lượng của một item nhận được trong một lần nhận hàng.
+type TransferReceipt struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription là một endpoint của đối tác nhận InventoryEvent qua HTTP.
+type WebhookSubscription struct {
+	ID         string               `json:"id"`
+	URL        string               `json:"url"`
+	EventTypes []InventoryEventType `json:"event_types"` // rỗng = mọi loại event
+	// Secret là khoá ký HMAC-SHA256, chỉ được trả về khi tạo hoặc đổi secret.
+	Secret    string    `json:"secret,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryStatus là trạng thái gửi của một webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // hết số lần retry, chỉ gửi lại khi redeliver
+)
+
+// WebhookDelivery là một event cần gửi tới một subscription cùng kết quả các lần gửi.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+
+	// URL và Secret của subscription, chỉ được nạp cho worker gửi webhook.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
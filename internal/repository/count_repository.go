@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+// Giới hạn kích thước trang của danh sách phiên kiểm kê.
+const (
+	DefaultCountSessionPageSize = 50
+	MaxCountSessionPageSize     = 500
+)
+
+type CountRepository struct {
+	db *sql.DB
+}
+
+func NewCountRepository(db *sql.DB) *CountRepository {
+	return &CountRepository{db: db}
+}
+
+const countSessionColumns = `id, location_id, status, note, approved_by, created_at, updated_at, submitted_at, closed_at`
+
+func scanCountSession(row interface{ Scan(dest ...any) error }) (*model.CountSession, error) {
+	s := &model.CountSession{}
+	var submittedAt, closedAt sql.NullTime
+	err := row.Scan(&s.ID, &s.LocationID, &s.Status, &s.Note, &s.ApprovedBy,
+		&s.CreatedAt, &s.UpdatedAt, &submittedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+	if submittedAt.Valid {
+		s.SubmittedAt = &submittedAt.Time
+	}
+	if closedAt.Valid {
+		s.ClosedAt = &closedAt.Time
+	}
+	return s, nil
+}
+
+// InsertCountSessionTx tạo phiên kiểm kê trong transaction tx và chụp on-hand hiện tại của các item tại location
+// của phiên làm số lượng kỳ vọng (item chưa có tồn kho tại location có kỳ vọng 0). Trả về ErrInventoryNotFound
+// nếu có item không tồn tại, ErrSerializedItem nếu có item serialized. Caller lock các item trước để snapshot
+// nhất quán với các thay đổi song song.
+func (r *CountRepository) InsertCountSessionTx(ctx context.Context, tx *sql.Tx, s *model.CountSession, itemIDs []string) error {
+	var found int
+	var serialized bool
+	err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*), COALESCE(BOOL_OR(serialized), FALSE) FROM inventory WHERE id = ANY($1)",
+		pq.Array(itemIDs)).Scan(&found, &serialized)
+	if err != nil {
+		return err
+	}
+	if found != len(itemIDs) {
+		return ErrInventoryNotFound
+	}
+	if serialized {
+		return ErrSerializedItem
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO count_sessions (id, location_id, status, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`,
+		s.ID, s.LocationID, s.Status, s.Note).Scan(&s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return mapPQError(err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO count_session_lines (session_id, item_id, expected_quantity)
+		SELECT $1, i.id, COALESCE(l.quantity, 0)
+		FROM inventory i
+		LEFT JOIN inventory_locations l ON l.item_id = i.id AND l.location_id = $2
+		WHERE i.id = ANY($3)`,
+		s.ID, s.LocationID, pq.Array(itemIDs))
+	if err != nil {
+		return err
+	}
+	lines, err := countSessionLines(ctx, tx, []string{s.ID})
+	if err != nil {
+		return err
+	}
+	s.Lines = lines[s.ID]
+	return nil
+}
+
+// LockCountSessionTx đọc và lock phiên kiểm kê trong transaction tx.
+func (r *CountRepository) LockCountSessionTx(ctx context.Context, tx *sql.Tx, id string) (*model.CountSession, error) {
+	s, err := scanCountSession(tx.QueryRowContext(ctx, "SELECT "+countSessionColumns+" FROM count_sessions WHERE id = $1 FOR UPDATE", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrCountSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines, err := countSessionLines(ctx, tx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	s.Lines = lines[id]
+	return s, nil
+}
+
+// UpdateCountSessionTx ghi trạng thái, người duyệt, thời điểm submit/đóng và số lượng đếm của các dòng
+// trong transaction tx.
+func (r *CountRepository) UpdateCountSessionTx(ctx context.Context, tx *sql.Tx, s *model.CountSession) error {
+	err := tx.QueryRowContext(ctx, `
+		UPDATE count_sessions SET status = $2, approved_by = $3, submitted_at = $4, closed_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		s.ID, s.Status, s.ApprovedBy, s.SubmittedAt, s.ClosedAt).Scan(&s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrCountSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	for _, line := range s.Lines {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE count_session_lines SET counted_quantity = $3, counted_at = $4
+			WHERE session_id = $1 AND item_id = $2`,
+			s.ID, line.ItemID, line.CountedQuantity, line.CountedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCountSession trả về phiên kiểm kê theo ID.
+func (r *CountRepository) GetCountSession(ctx context.Context, id string) (*model.CountSession, error) {
+	s, err := scanCountSession(r.db.QueryRowContext(ctx, "SELECT "+countSessionColumns+" FROM count_sessions WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrCountSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines, err := countSessionLines(ctx, r.db, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	s.Lines = lines[id]
+	return s, nil
+}
+
+// CountSessionListOptions là bộ lọc và phân trang của ListCountSessions.
+type CountSessionListOptions struct {
+	Status     model.CountSessionStatus // rỗng = mọi trạng thái
+	LocationID string                   // rỗng = mọi location
+	Cursor     string
+	PageSize   int
+}
+
+// ListCountSessions trả về các phiên kiểm kê, mới nhất trước, phân trang theo keyset (created_at, id).
+func (r *CountRepository) ListCountSessions(ctx context.Context, opts CountSessionListOptions) ([]*model.CountSession, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = DefaultCountSessionPageSize
+	}
+	if limit > MaxCountSessionPageSize {
+		limit = MaxCountSessionPageSize
+	}
+	var (
+		before   *time.Time
+		beforeID string
+	)
+	if opts.Cursor != "" {
+		createdAt, id, err := decodeCreatedAtCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		before, beforeID = &createdAt, id
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+countSessionColumns+`
+		FROM count_sessions
+		WHERE ($1::VARCHAR = '' OR status = $1::VARCHAR)
+		  AND ($2::VARCHAR = '' OR location_id = $2::VARCHAR)
+		  AND ($3::TIMESTAMP IS NULL OR (created_at, id) < ($3::TIMESTAMP, $4::VARCHAR))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5`,
+		opts.Status, opts.LocationID, before, beforeID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	result := []*model.CountSession{}
+	for rows.Next() {
+		s, err := scanCountSession(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(result) > limit {
+		result = result[:limit]
+		last := result[limit-1]
+		next = encodeCreatedAtCursor(last.CreatedAt, last.ID)
+	}
+
+	ids := make([]string, 0, len(result))
+	for _, s := range result {
+		ids = append(ids, s.ID)
+	}
+	lines, err := countSessionLines(ctx, r.db, ids)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, s := range result {
+		s.Lines = lines[s.ID]
+	}
+	return result, next, nil
+}
+
+// countSessionLines đọc các dòng của các phiên kiểm kê, theo item_id trong từng phiên.
+func countSessionLines(ctx context.Context, q queryer, ids []string) (map[string][]model.CountLine, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT session_id, item_id, expected_quantity, counted_quantity, counted_at
+		FROM count_session_lines
+		WHERE session_id = ANY($1)
+		ORDER BY session_id, item_id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make(map[string][]model.CountLine, len(ids))
+	for rows.Next() {
+		var (
+			id        string
+			line      model.CountLine
+			counted   sql.NullInt64
+			countedAt sql.NullTime
+		)
+		if err := rows.Scan(&id, &line.ItemID, &line.ExpectedQuantity, &counted, &countedAt); err != nil {
+			return nil, err
+		}
+		if counted.Valid {
+			quantity := int(counted.Int64)
+			line.CountedQuantity = &quantity
+		}
+		if countedAt.Valid {
+			line.CountedAt = &countedAt.Time
+		}
+		result[id] = append(result[id], line)
+	}
+	return result, rows.Err()
+}
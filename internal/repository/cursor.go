@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeCreatedAtCursor tạo cursor dạng "<created_at unix micro>:<id>" cho các danh sách sắp theo created_at, id.
+func encodeCreatedAtCursor(createdAt time.Time, id string) string {
+	return strconv.FormatInt(createdAt.UnixMicro(), 10) + ":" + id
+}
+
+// decodeCreatedAtCursor đọc cursor dạng "<created_at unix micro>:<id>".
+func decodeCreatedAtCursor(s string) (time.Time, string, error) {
+	microStr, id, ok := strings.Cut(s, ":")
+	if !ok || id == "" {
+		return time.Time{}, "", ErrInvalidPageToken
+	}
+	micro, err := strconv.ParseInt(microStr, 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidPageToken
+	}
+	return time.UnixMicro(micro).UTC(), id, nil
+}
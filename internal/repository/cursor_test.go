@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecodeCreatedAtCursor(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.UTC)
+	tests := []struct {
+		name     string
+		cursor   string
+		wantTime time.Time
+		wantID   string
+		wantErr  bool
+	}{
+		{name: "valid", cursor: "1773480413589793:cnt-1", wantTime: createdAt, wantID: "cnt-1"},
+		{name: "encoded cursor", cursor: encodeCreatedAtCursor(createdAt, "tr-1"), wantTime: createdAt, wantID: "tr-1"},
+		{name: "id may contain colon", cursor: "1773480413589793:a:b", wantTime: createdAt, wantID: "a:b"},
+		{name: "zero time", cursor: "0:x", wantTime: time.UnixMicro(0).UTC(), wantID: "x"},
+		{name: "empty", cursor: "", wantErr: true},
+		{name: "missing separator", cursor: "1773480413589793", wantErr: true},
+		{name: "empty id", cursor: "1773480413589793:", wantErr: true},
+		{name: "non-numeric time", cursor: "yesterday:cnt-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotID, err := decodeCreatedAtCursor(tt.cursor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPageToken) {
+					t.Fatalf("decodeCreatedAtCursor(%q) error = %v, want ErrInvalidPageToken", tt.cursor, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCreatedAtCursor(%q) error = %v", tt.cursor, err)
+			}
+			if !gotTime.Equal(tt.wantTime) || gotID != tt.wantID {
+				t.Errorf("decodeCreatedAtCursor(%q) = %s, %q, want %s, %q", tt.cursor, gotTime, gotID, tt.wantTime, tt.wantID)
+			}
+		})
+	}
+}
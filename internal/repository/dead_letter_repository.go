@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+const (
+	DefaultDeadLetterPageSize = 50
+	MaxDeadLetterPageSize     = 500
+)
+
+type DeadLetterRepository struct {
+	db *sql.DB
+}
+
+func NewDeadLetterRepository(db *sql.DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+const deadLetterColumns = `id, event_id, event_type, item_id, message_key, payload, original_payload,
+	error_chain, attempt_history, source_topic, source_partition, source_offset, status, redrive_count,
+	created_at, updated_at`
+
+func scanDeadLetter(row interface{ Scan(dest ...any) error }) (*model.DeadLetter, error) {
+	dl := &model.DeadLetter{}
+	var payload, originalPayload, errorChain, history []byte
+	err := row.Scan(&dl.ID, &dl.EventID, &dl.EventType, &dl.ItemID, &dl.MessageKey, &payload, &originalPayload,
+		&errorChain, &history, &dl.SourceTopic, &dl.SourcePartition, &dl.SourceOffset, &dl.Status, &dl.RedriveCount,
+		&dl.CreatedAt, &dl.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	dl.Payload = string(payload)
+	dl.OriginalPayload = string(originalPayload)
+	if err := json.Unmarshal(errorChain, &dl.ErrorChain); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(history, &dl.AttemptHistory); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// InsertDeadLetter lưu một dead letter. Cùng một (topic, partition, offset) chỉ được lưu một lần,
+// nên việc consumer đọc lại message sau khi restart không tạo bản ghi trùng.
+func (r *DeadLetterRepository) InsertDeadLetter(ctx context.Context, dl *model.DeadLetter) error {
+	errorChain, err := json.Marshal(nonNil(dl.ErrorChain))
+	if err != nil {
+		return err
+	}
+	history, err := json.Marshal(nonNil(dl.AttemptHistory))
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO dead_letters (event_id, event_type, item_id, message_key, payload, original_payload,
+			error_chain, attempt_history, source_topic, source_partition, source_offset)
+		VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (source_topic, source_partition, source_offset) DO NOTHING`,
+		dl.EventID, dl.EventType, dl.ItemID, dl.MessageKey, []byte(dl.Payload),
+		string(errorChain), string(history), dl.SourceTopic, dl.SourcePartition, dl.SourceOffset)
+	return err
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// GetDeadLetter trả về dead letter theo ID.
+func (r *DeadLetterRepository) GetDeadLetter(ctx context.Context, id int64) (*model.DeadLetter, error) {
+	dl, err := scanDeadLetter(r.db.QueryRowContext(ctx, "SELECT "+deadLetterColumns+" FROM dead_letters WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrDeadLetterNotFound
+	}
+	return dl, err
+}
+
+// ListDeadLetters trả về dead letter mới nhất trước, lọc theo status (rỗng = tất cả), phân trang theo cursor.
+func (r *DeadLetterRepository) ListDeadLetters(ctx context.Context, status, cursor string, limit int) ([]*model.DeadLetter, string, error) {
+	var afterID int64
+	if cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, "", ErrInvalidPageToken
+		}
+		afterID = id
+	}
+	if limit <= 0 {
+		limit = DefaultDeadLetterPageSize
+	}
+	if limit > MaxDeadLetterPageSize {
+		limit = MaxDeadLetterPageSize
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+deadLetterColumns+`
+		FROM dead_letters
+		WHERE ($1::VARCHAR = '' OR status = $1::VARCHAR)
+		  AND ($2::BIGINT = 0 OR id < $2::BIGINT)
+		ORDER BY id DESC
+		LIMIT $3`,
+		status, afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var result []*model.DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(result) > limit {
+		result = result[:limit]
+		next = strconv.FormatInt(result[limit-1].ID, 10)
+	}
+	return result, next, nil
+}
+
+// RedriveDeadLetter đưa một dead letter pending trở lại topic qua outbox. Nếu payload khác nil
+// thì payload đã chỉnh sửa được lưu lại và dùng để redrive; payload gốc vẫn được giữ nguyên.
+func (r *DeadLetterRepository) RedriveDeadLetter(ctx context.Context, id int64, topic string, payload []byte) (*model.DeadLetter, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if payload != nil {
+		result, err := tx.ExecContext(ctx, "UPDATE dead_letters SET payload = $2, updated_at = NOW() WHERE id = $1 AND status = $3",
+			id, payload, model.DeadLetterPending)
+		if err != nil {
+			return nil, err
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return nil, r.notPendingError(ctx, tx, id)
+		}
+	}
+
+	redriven, err := redriveTx(ctx, tx, []int64{id}, topic)
+	if err != nil {
+		return nil, err
+	}
+	if len(redriven) == 0 {
+		return nil, r.notPendingError(ctx, tx, id)
+	}
+	return redriven[0], tx.Commit()
+}
+
+// RedriveDeadLetters redrive nhiều dead letter trong một transaction. Các ID không tồn tại
+// hoặc không còn pending bị bỏ qua; trả về các dead letter đã được redrive.
+func (r *DeadLetterRepository) RedriveDeadLetters(ctx context.Context, ids []int64, topic string) ([]*model.DeadLetter, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	redriven, err := redriveTx(ctx, tx, ids, topic)
+	if err != nil {
+		return nil, err
+	}
+	return redriven, tx.Commit()
+}
+
+// redriveTx lock các dead letter pending theo thứ tự ID, ghi payload vào outbox rồi đánh dấu redriven.
+func redriveTx(ctx context.Context, tx *sql.Tx, ids []int64, topic string) ([]*model.DeadLetter, error) {
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE dead_letters SET status = $2, redrive_count = redrive_count + 1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM dead_letters WHERE id = ANY($1) AND status = $3 ORDER BY id FOR UPDATE
+		)
+		RETURNING `+deadLetterColumns,
+		pq.Array(ids), model.DeadLetterRedriven, model.DeadLetterPending)
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		result = append(result, dl)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, dl := range result {
+		key := dl.MessageKey
+		if key == "" {
+			key = dl.ItemID
+		}
+		if err := EnqueueOutboxTx(ctx, tx, topic, key, []byte(dl.Payload)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// DiscardDeadLetter đánh dấu dead letter pending là discarded, không redrive nữa.
+func (r *DeadLetterRepository) DiscardDeadLetter(ctx context.Context, id int64) (*model.DeadLetter, error) {
+	dl, err := scanDeadLetter(r.db.QueryRowContext(ctx, `
+		UPDATE dead_letters SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = $3
+		RETURNING `+deadLetterColumns,
+		id, model.DeadLetterDiscarded, model.DeadLetterPending))
+	if err == sql.ErrNoRows {
+		if _, getErr := r.GetDeadLetter(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, ErrDeadLetterNotPending
+	}
+	return dl, err
+}
+
+func (r *DeadLetterRepository) notPendingError(ctx context.Context, tx *sql.Tx, id int64) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM dead_letters WHERE id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrDeadLetterNotFound
+	}
+	return ErrDeadLetterNotPending
+}
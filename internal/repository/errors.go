@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+var (
+	// ErrInventoryNotFound được trả về khi item không tồn tại trong bảng inventory.
+	ErrInventoryNotFound = errors.New("inventory item not found")
+	// ErrInventoryAlreadyExists được trả về khi tạo item đã tồn tại.
+	ErrInventoryAlreadyExists = errors.New("inventory item already exists")
+	// ErrLocationNotFound được trả về khi location không tồn tại.
+	ErrLocationNotFound = errors.New("location not found")
+	// ErrLocationAlreadyExists được trả về khi tạo location đã tồn tại.
+	ErrLocationAlreadyExists = errors.New("location already exists")
+	// ErrInsufficientStock được trả về khi lượng tồn kho khả dụng không đủ.
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrReservationNotFound được trả về khi reservation không tồn tại.
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrReservationNotPending được trả về khi reservation đã được confirm/release/hết hạn.
+	ErrReservationNotPending = errors.New("reservation is not pending")
+	// ErrReservationExpired được trả về khi reservation đã quá TTL.
+	ErrReservationExpired = errors.New("reservation expired")
+	// ErrDeadLetterNotFound được trả về khi dead letter không tồn tại.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterNotPending được trả về khi dead letter đã được redrive hoặc discard.
+	ErrDeadLetterNotPending = errors.New("dead letter is not pending")
+	// ErrInvalidPageToken được trả về khi page token/cursor không hợp lệ.
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrInvalidSort được trả về khi trường sắp xếp không được hỗ trợ.
+	ErrInvalidSort = errors.New("invalid sort field")
+	// ErrIdempotencyKeyNotFound được trả về khi Idempotency-Key chưa được dùng hoặc đã hết hạn.
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	// ErrIdempotencyKeyConflict được trả về khi một request khác đã lưu cùng Idempotency-Key trước.
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already used")
+	// ErrWebhookSubscriptionNotFound được trả về khi webhook subscription không tồn tại.
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	// ErrWebhookDeliveryNotFound được trả về khi webhook delivery không tồn tại.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrLotConflict được trả về khi nhập thêm vào lô đã có với ngày sản xuất/hạn dùng khác.
+	ErrLotConflict = errors.New("lot already exists with different dates")
+	// ErrSerializedItem được trả về khi thay đổi số lượng item serialized mà không chỉ định serial.
+	ErrSerializedItem = errors.New("item is serialized, stock must change through serial operations")
+	// ErrNotSerialized được trả về khi thao tác serial trên item không serialized.
+	ErrNotSerialized = errors.New("item is not serialized")
+	// ErrSerialNotFound được trả về khi serial không tồn tại.
+	ErrSerialNotFound = errors.New("serial not found")
+	// ErrSerialAlreadyExists được trả về khi nhập serial đã có.
+	ErrSerialAlreadyExists = errors.New("serial already exists")
+	// ErrStaleFencingToken được trả về khi thao tác ghi mang fencing token cũ hơn token đã ghi.
+	ErrStaleFencingToken = errors.New("stale fencing token")
+)
+
+// ErrVersionMismatch được so khớp (errors.Is) với mọi VersionMismatchError.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// VersionMismatchError được trả về khi expected version của request không khớp version hiện tại của item.
+type VersionMismatchError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("version mismatch: expected %d, current %d", e.Expected, e.Current)
+}
+
+func (e *VersionMismatchError) Is(target error) bool {
+	return target == ErrVersionMismatch
+}
+
+// InsufficientStockError được trả về khi thay đổi làm tồn kho tại location vượt quá mức oversell policy cho phép.
+// Được so khớp (errors.Is) với ErrInsufficientStock.
+type InsufficientStockError struct {
+	ItemID     string
+	LocationID string
+	Available  int // số lượng còn có thể lấy ra (đã tính backorder limit và reservation)
+	Requested  int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for item %s at %s: available %d, requested %d",
+		e.ItemID, e.LocationID, e.Available, e.Requested)
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
+
+// ErrSerialState được so khớp (errors.Is) với mọi SerialStateError.
+var ErrSerialState = errors.New("invalid serial state")
+
+// SerialStateError được trả về khi trạng thái hiện tại của serial không cho phép thao tác.
+type SerialStateError struct {
+	SerialNumber string
+	Status       model.SerialStatus
+	Operation    string
+}
+
+func (e *SerialStateError) Error() string {
+	return fmt.Sprintf("serial %s is %s, cannot %s", e.SerialNumber, e.Status, e.Operation)
+}
+
+func (e *SerialStateError) Is(target error) bool {
+	return target == ErrSerialState
+}
+
+// ErrTransferNotFound được trả về khi lệnh chuyển kho không tồn tại.
+var ErrTransferNotFound = errors.New("transfer order not found")
+
+// ErrTransferState được so khớp (errors.Is) với mọi TransferStateError.
+var ErrTransferState = errors.New("invalid transfer order state")
+
+// TransferStateError được trả về khi trạng thái hiện tại của lệnh chuyển kho không cho phép thao tác.
+type TransferStateError struct {
+	TransferID string
+	Status     model.TransferStatus
+	Operation  string
+}
+
+func (e *TransferStateError) Error() string {
+	return fmt.Sprintf("transfer order %s is %s, cannot %s", e.TransferID, e.Status, e.Operation)
+}
+
+func (e *TransferStateError) Is(target error) bool {
+	return target == ErrTransferState
+}
+
+// ErrCountSessionNotFound được trả về khi phiên kiểm kê không tồn tại.
+var ErrCountSessionNotFound = errors.New("count session not found")
+
+// ErrCountSessionState được so khớp (errors.Is) với mọi CountSessionStateError.
+var ErrCountSessionState = errors.New("invalid count session state")
+
+// CountSessionStateError được trả về khi trạng thái hiện tại của phiên kiểm kê không cho phép thao tác.
+type CountSessionStateError struct {
+	SessionID string
+	Status    model.CountSessionStatus
+	Operation string
+}
+
+func (e *CountSessionStateError) Error() string {
+	return fmt.Sprintf("count session %s is %s, cannot %s", e.SessionID, e.Status, e.Operation)
+}
+
+func (e *CountSessionStateError) Is(target error) bool {
+	return target == ErrCountSessionState
+}
+
+// mapPQError chuyển các lỗi ràng buộc của Postgres sang lỗi của repository.
+func mapPQError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23503": // foreign_key_violation
+			switch {
+			case strings.HasSuffix(pqErr.Constraint, "_location_id_fkey"):
+				return ErrLocationNotFound
+			case strings.HasSuffix(pqErr.Constraint, "_item_id_fkey"):
+				return ErrInventoryNotFound
+			}
+		case "23514": // check_violation
+			if pqErr.Constraint == "inventory_locations_stock_check" || pqErr.Constraint == "inventory_locations_states_check" {
+				return ErrInsufficientStock
+			}
+		case "23505": // unique_violation
+			switch pqErr.Table {
+			case "inventory":
+				return ErrInventoryAlreadyExists
+			case "locations":
+				return ErrLocationAlreadyExists
+			}
+		}
+	}
+	return err
+}
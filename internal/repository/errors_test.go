@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestMapPQError(t *testing.T) {
+	other := errors.New("connection reset")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unknown location", &pq.Error{Code: "23503", Constraint: "inventory_locations_location_id_fkey"}, ErrLocationNotFound},
+		{"unknown item", &pq.Error{Code: "23503", Constraint: "inventory_reservations_item_id_fkey"}, ErrInventoryNotFound},
+		{"duplicate item", &pq.Error{Code: "23505", Table: "inventory"}, ErrInventoryAlreadyExists},
+		{"duplicate location", &pq.Error{Code: "23505", Table: "locations"}, ErrLocationAlreadyExists},
+		{"other constraint", &pq.Error{Code: "23514", Table: "inventory"}, nil},
+		{"not a pq error", other, other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapPQError(tt.err)
+			want := tt.want
+			if want == nil {
+				want = tt.err
+			}
+			if got != want {
+				t.Errorf("mapPQError() = %v, want %v", got, want)
+			}
+		})
+	}
+}
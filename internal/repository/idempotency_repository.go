@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// GetIdempotencyRecord trả về response đã lưu của key trong scope, bỏ qua record đã hết hạn.
+func (r *IdempotencyRepository) GetIdempotencyRecord(ctx context.Context, scope, key string) (*model.IdempotencyRecord, error) {
+	var rec model.IdempotencyRecord
+	err := r.db.QueryRowContext(ctx, `
+		SELECT scope, idempotency_key, fingerprint, response_status, response_body, response_etag, created_at, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND idempotency_key = $2 AND expires_at > NOW()`,
+		scope, key).
+		Scan(&rec.Scope, &rec.Key, &rec.Fingerprint, &rec.ResponseStatus, &rec.ResponseBody, &rec.ResponseETag, &rec.CreatedAt, &rec.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrIdempotencyKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// SaveIdempotencyRecordTx lưu response của key trong cùng transaction với thay đổi tồn kho,
+// nên response chỉ được lưu khi thay đổi đã commit. Record đã hết hạn được ghi đè.
+// Trả về ErrIdempotencyKeyConflict nếu một request khác đã lưu cùng key (request chạy song song).
+func SaveIdempotencyRecordTx(ctx context.Context, tx *sql.Tx, rec *model.IdempotencyRecord, ttl time.Duration) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (scope, idempotency_key, fingerprint, response_status, response_body, response_etag, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW() + make_interval(secs => $7))
+		ON CONFLICT (scope, idempotency_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+		    response_status = EXCLUDED.response_status,
+		    response_body = EXCLUDED.response_body,
+		    response_etag = EXCLUDED.response_etag,
+		    created_at = CURRENT_TIMESTAMP,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()`,
+		rec.Scope, rec.Key, rec.Fingerprint, rec.ResponseStatus, rec.ResponseBody, rec.ResponseETag, ttl.Seconds())
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrIdempotencyKeyConflict
+	}
+	return nil
+}
+
+// PurgeExpired xoá các idempotency key đã hết hạn.
+func (r *IdempotencyRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -1,62 +1,719 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/lib/pq"
-	"inventory-service.com/m/internal/grpc/inventorypb"
+	"inventory-service.com/m/internal/model"
 )
 
 type InventoryRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	alerts StockAlertConfig
 }
 
-func NewInventoryRepository(db *sql.DB) *InventoryRepository {
-	return &InventoryRepository{db: db}
+// NewInventoryRepository tạo repository; mọi thay đổi tồn kho qua repository được đánh giá theo alerts.
+func NewInventoryRepository(db *sql.DB, alerts StockAlertConfig) *InventoryRepository {
+	return &InventoryRepository{db: db, alerts: alerts}
 }
 
-func (r *InventoryRepository) CreateInventory(productId string, quantity int32) error {
-	fmt.Println("Creating inventory for item: ", productId)
-	query := `
-		INSERT INTO inventory (id, quantity) 
-		VALUES ($1, $2)
-	`
-	fmt.Println("Querying")
-	_, err := r.db.Exec(query, productId, quantity)
-	return err
+// StockChange mô tả một thay đổi số lượng tồn kho cùng thông tin được ghi vào sổ movement.
+type StockChange struct {
+	ItemID        string
+	LocationID    string // rỗng = location mặc định
+	Delta         int
+	Reason        model.MovementReason
+	Source        model.MovementSource
+	CorrelationID string
+	// ExpectedVersion khác 0 thì thay đổi chỉ được áp dụng khi version hiện tại của item khớp.
+	ExpectedVersion int64
+	// Lot khác nil thì số lượng nhập (Delta > 0) được ghi vào lô này. Thay đổi giảm tồn kho
+	// luôn lấy từ các lô theo lot allocation policy của item.
+	Lot *LotReceipt
+	// SerialNumber là serial của thay đổi; bắt buộc với item serialized và không được dùng với item khác.
+	SerialNumber string
+	// Serialized chỉ dùng khi tạo item: item chỉ thay đổi số lượng qua các thao tác serial.
+	Serialized bool
+	// SkipAvailabilityCheck = true thì thay đổi ghi nhận số lượng thực tế (ví dụ kết quả kiểm kê): không kiểm tra
+	// available-to-promise theo oversell policy, chỉ còn ràng buộc tồn kho của bảng inventory_locations.
+	SkipAvailabilityCheck bool
 }
 
-func (r *InventoryRepository) GetInventory(itemID string) (int32, error) {
-	var quantity int32
-	err := r.db.QueryRow("SELECT quantity FROM inventories WHERE id = $1", itemID).Scan(&quantity)
+// StockResult là trạng thái của item sau một thay đổi tồn kho.
+type StockResult struct {
+	Total   int   // tổng số lượng của item
+	Balance int   // số lượng tại location bị thay đổi
+	Version int64 // version mới của item
+	// Lots là phần đã lấy từ từng lô on-hand khi thay đổi làm giảm tồn kho.
+	Lots []LotAllocation
+}
+
+// locationOrDefault trả về location mặc định nếu locationID rỗng.
+func locationOrDefault(locationID string) string {
+	if locationID == "" {
+		return model.DefaultLocationID
+	}
+	return locationID
+}
+
+func (r *InventoryRepository) CreateInventory(ctx context.Context, change StockChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.CreateInventoryTx(ctx, tx, change); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateInventoryTx tạo item mới với số lượng ban đầu change.Delta đặt tại change.LocationID, trong transaction tx.
+func (r *InventoryRepository) CreateInventoryTx(ctx context.Context, tx *sql.Tx, change StockChange) error {
+	locationID := locationOrDefault(change.LocationID)
+	_, err := tx.ExecContext(ctx, "INSERT INTO inventory (id, quantity, serialized) VALUES ($1, $2, $3)", change.ItemID, change.Delta, change.Serialized)
+	if err != nil {
+		return mapPQError(err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO inventory_locations (item_id, location_id, quantity)
+		VALUES ($1, $2, $3)`,
+		change.ItemID, locationID, change.Delta)
+	if err != nil {
+		return mapPQError(err)
+	}
+
+	return insertMovementTx(ctx, tx, &model.StockMovement{
+		ItemID:        change.ItemID,
+		LocationID:    locationID,
+		Delta:         change.Delta,
+		Balance:       change.Delta,
+		TotalBalance:  change.Delta,
+		Reason:        reasonOrDefault(change.Reason, model.MovementReasonCreate),
+		Source:        change.Source,
+		CorrelationID: change.CorrelationID,
+	})
+}
+
+// UpdateInventory cộng change.Delta vào tồn kho của item tại location và trả về trạng thái mới.
+func (r *InventoryRepository) UpdateInventory(ctx context.Context, change StockChange) (StockResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return StockResult{}, err
+	}
+	defer tx.Rollback()
+
+	result, err := r.AdjustStockTx(ctx, tx, change)
+	if err != nil {
+		return StockResult{}, err
+	}
+	return result, tx.Commit()
+}
+
+// AdjustStockTx là điểm duy nhất thay đổi số lượng tồn kho: cập nhật tổng và version ở bảng inventory,
+// số lượng tại location và theo lô, ghi movement vào sổ cái và cảnh báo tồn kho (nếu có) trong cùng transaction tx.
+func (r *InventoryRepository) AdjustStockTx(ctx context.Context, tx *sql.Tx, change StockChange) (StockResult, error) {
+	locationID := locationOrDefault(change.LocationID)
+
+	var (
+		result         StockResult
+		itemPolicy     model.OversellPolicy
+		itemBackorders int
+		lotPolicy      model.LotAllocationPolicy
+		serialized     bool
+	)
+	// Cập nhật bảng inventory trước để lock dòng của item, tuần tự hoá các thay đổi đồng thời.
+	err := tx.QueryRowContext(ctx, `
+		UPDATE inventory SET quantity = quantity + $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND ($3::BIGINT = 0 OR version = $3::BIGINT)
+		RETURNING quantity, version, oversell_policy, backorder_limit, lot_allocation_policy, serialized`,
+		change.Delta, change.ItemID, change.ExpectedVersion).Scan(&result.Total, &result.Version, &itemPolicy, &itemBackorders, &lotPolicy, &serialized)
 	if err == sql.ErrNoRows {
-		return 0, nil
+		return StockResult{}, versionMismatchOrNotFoundTx(ctx, tx, change.ItemID, change.ExpectedVersion)
+	}
+	if err != nil {
+		return StockResult{}, err
+	}
+	// On-hand của item serialized luôn bằng số serial còn trong kho, nên chỉ thao tác serial được thay đổi nó.
+	if serialized && change.SerialNumber == "" {
+		return StockResult{}, ErrSerializedItem
+	}
+	if !serialized && change.SerialNumber != "" {
+		return StockResult{}, ErrNotSerialized
+	}
+
+	// Chỉ thay đổi làm giảm tồn kho mới cần kiểm tra oversell policy của location.
+	if change.Delta < 0 && !change.SkipAvailabilityCheck {
+		if err := checkOversellTx(ctx, tx, change, locationID, itemPolicy, itemBackorders); err != nil {
+			return StockResult{}, err
+		}
+	}
+
+	// Location mới nhận chính sách mặc định của item.
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_locations (item_id, location_id, quantity, oversell_policy, backorder_limit)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (item_id, location_id)
+		DO UPDATE SET quantity = inventory_locations.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING quantity`,
+		change.ItemID, locationID, change.Delta, itemPolicy, itemBackorders).Scan(&result.Balance)
+	if err != nil {
+		return StockResult{}, mapPQError(err)
 	}
-	return quantity, err
+
+	switch {
+	case change.Delta > 0 && change.Lot != nil:
+		err = receiveLotTx(ctx, tx, change.ItemID, locationID, model.StockStateOnHand, *change.Lot, change.Delta)
+	case change.Delta < 0:
+		result.Lots, err = allocateLotsTx(ctx, tx, change.ItemID, locationID, model.StockStateOnHand, -change.Delta, lotPolicy)
+	}
+	if err != nil {
+		return StockResult{}, err
+	}
+
+	err = insertMovementTx(ctx, tx, &model.StockMovement{
+		ItemID:        change.ItemID,
+		LocationID:    locationID,
+		Delta:         change.Delta,
+		Balance:       result.Balance,
+		TotalBalance:  result.Total,
+		Reason:        reasonOrDefault(change.Reason, model.MovementReasonAdjustment),
+		Source:        change.Source,
+		CorrelationID: change.CorrelationID,
+		SerialNumber:  change.SerialNumber,
+	})
+	if err != nil {
+		return StockResult{}, err
+	}
+	if err := r.evaluateStockAlertTx(ctx, tx, change.ItemID); err != nil {
+		return StockResult{}, err
+	}
+	return result, nil
 }
 
-func (r *InventoryRepository) GetInventories(itemIDs []string) (*inventorypb.GetInventoriesResponse, error) {
+// checkOversellTx trả về InsufficientStockError nếu change.Delta lấy quá available-to-promise tại location
+// (on-hand trừ phần đang được giữ chỗ) theo mức oversell policy cho phép. Location chưa có dòng tồn kho
+// dùng chính sách mặc định của item. Dòng inventory của item phải đã được lock trong tx.
+func checkOversellTx(ctx context.Context, tx *sql.Tx, change StockChange, locationID string, itemPolicy model.OversellPolicy, itemBackorders int) error {
+	balance := 0
+	policy, backorders := itemPolicy, itemBackorders
+	err := tx.QueryRowContext(ctx, `
+		SELECT quantity, oversell_policy, backorder_limit
+		FROM inventory_locations
+		WHERE item_id = $1 AND location_id = $2`,
+		change.ItemID, locationID).Scan(&balance, &policy, &backorders)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	reserved, err := reservedTx(ctx, tx, change.ItemID, locationID)
+	if err != nil {
+		return err
+	}
 
-	rows, err := r.db.Query("SELECT id, quantity FROM inventories WHERE id = ANY($1)", pq.Array(itemIDs))
+	available, limited := availableUnderPolicy(balance-reserved, policy, backorders)
+	if limited && available < -change.Delta {
+		return &InsufficientStockError{
+			ItemID:     change.ItemID,
+			LocationID: locationID,
+			Available:  available,
+			Requested:  -change.Delta,
+		}
+	}
+	return nil
+}
+
+// availableUnderPolicy trả về số lượng có thể lấy ra khỏi balance theo policy.
+// limited = false nghĩa là policy không giới hạn.
+func availableUnderPolicy(balance int, policy model.OversellPolicy, backorders int) (available int, limited bool) {
+	switch policy {
+	case model.OversellPolicyUnlimited:
+		return 0, false
+	case model.OversellPolicyBackorder:
+		return balance + backorders, true
+	default:
+		return balance, true
+	}
+}
+
+// LockItemsTx lock các dòng inventory của itemIDs theo thứ tự ID trong transaction tx,
+// để các transaction thay đổi nhiều item luôn lấy lock theo cùng một thứ tự. ID không tồn tại bị bỏ qua.
+func (r *InventoryRepository) LockItemsTx(ctx context.Context, tx *sql.Tx, itemIDs []string) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM inventory WHERE id = ANY($1) ORDER BY id FOR UPDATE", pq.Array(itemIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// versionMismatchOrNotFoundTx phân biệt item không tồn tại với version không khớp.
+func versionMismatchOrNotFoundTx(ctx context.Context, tx *sql.Tx, itemID string, expected int64) error {
+	var current int64
+	err := tx.QueryRowContext(ctx, "SELECT version FROM inventory WHERE id = $1", itemID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return ErrInventoryNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return &VersionMismatchError{Expected: expected, Current: current}
+}
+
+// DeleteInventory xoá item. Nếu change.LocationID khác rỗng thì chỉ xoá tồn kho tại location đó
+// và trừ phần tương ứng khỏi tổng. change.Delta bị bỏ qua.
+func (r *InventoryRepository) DeleteInventory(ctx context.Context, change StockChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := r.DeleteInventoryTx(ctx, tx, change); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteInventoryTx xoá item (hoặc tồn kho tại change.LocationID) trong transaction tx
+// và trả về tổng số lượng đã bị xoá.
+func (r *InventoryRepository) DeleteInventoryTx(ctx context.Context, tx *sql.Tx, change StockChange) (int, error) {
+	var (
+		total   int
+		version int64
+	)
+	err := tx.QueryRowContext(ctx, "SELECT quantity, version FROM inventory WHERE id = $1 FOR UPDATE", change.ItemID).Scan(&total, &version)
+	if err == sql.ErrNoRows {
+		return 0, ErrInventoryNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if change.ExpectedVersion != 0 && change.ExpectedVersion != version {
+		return 0, &VersionMismatchError{Expected: change.ExpectedVersion, Current: version}
+	}
+
+	// Xoá các dòng theo location và ghi movement trả số lượng về 0 cho từng location.
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM inventory_locations
+		WHERE item_id = $1 AND ($2::VARCHAR = '' OR location_id = $2::VARCHAR)
+		RETURNING location_id, quantity, in_transit, quarantined, damaged`,
+		change.ItemID, change.LocationID)
+	if err != nil {
+		return 0, err
+	}
+	var removed []model.LocationStock
+	for rows.Next() {
+		var loc model.LocationStock
+		if err := rows.Scan(&loc.LocationID, &loc.Quantity, &loc.Stock.InTransit, &loc.Stock.Quarantined, &loc.Stock.Damaged); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		removed = append(removed, loc)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if change.LocationID != "" && len(removed) == 0 {
+		return 0, ErrInventoryNotFound
+	}
+	// Serial còn trong kho tại location bị xoá cùng tồn kho; serial đã xuất được giữ lại để tra cứu.
+	if change.LocationID != "" {
+		_, err = tx.ExecContext(ctx,
+			"DELETE FROM inventory_serials WHERE item_id = $1 AND location_id = $2 AND status <> 'shipped'",
+			change.ItemID, change.LocationID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Tổng của các trạng thái khác on-hand sau khi xoá, cộng lại phần bị xoá để ghi movement theo từng location.
+	var states model.StockBreakdown
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(in_transit), 0), COALESCE(SUM(quarantined), 0), COALESCE(SUM(damaged), 0)
+		FROM inventory_locations WHERE item_id = $1`,
+		change.ItemID).Scan(&states.InTransit, &states.Quarantined, &states.Damaged)
+	if err != nil {
+		return 0, err
+	}
+	for _, loc := range removed {
+		states.Add(loc.Stock)
+	}
+
+	removedTotal := 0
+	for _, loc := range removed {
+		removedTotal += loc.Quantity
+		total -= loc.Quantity
+		states.InTransit -= loc.Stock.InTransit
+		states.Quarantined -= loc.Stock.Quarantined
+		states.Damaged -= loc.Stock.Damaged
+		for _, m := range []model.StockMovement{
+			{StockState: model.StockStateOnHand, Delta: -loc.Quantity, TotalBalance: total},
+			{StockState: model.StockStateInTransit, Delta: -loc.Stock.InTransit, TotalBalance: states.InTransit},
+			{StockState: model.StockStateQuarantined, Delta: -loc.Stock.Quarantined, TotalBalance: states.Quarantined},
+			{StockState: model.StockStateDamaged, Delta: -loc.Stock.Damaged, TotalBalance: states.Damaged},
+		} {
+			// Movement on-hand luôn được ghi; các trạng thái khác chỉ khi có hàng.
+			if m.StockState != model.StockStateOnHand && m.Delta == 0 {
+				continue
+			}
+			m.ItemID, m.LocationID = change.ItemID, loc.LocationID
+			m.Reason = reasonOrDefault(change.Reason, model.MovementReasonDelete)
+			m.Source, m.CorrelationID = change.Source, change.CorrelationID
+			if err := insertMovementTx(ctx, tx, &m); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if change.LocationID == "" {
+		_, err = tx.ExecContext(ctx, "DELETE FROM inventory WHERE id = $1", change.ItemID)
+		return removedTotal, err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE inventory SET quantity = $1, version = version + 1, updated_at = NOW() WHERE id = $2", total, change.ItemID)
+	if err != nil {
+		return 0, err
+	}
+	return removedTotal, r.evaluateStockAlertTx(ctx, tx, change.ItemID)
+}
+
+// SetOversellPolicy đặt oversell policy cho item. Nếu locationID rỗng, policy trở thành mặc định của item
+// và được áp dụng cho mọi location hiện có; ngược lại chỉ áp dụng cho location đó.
+// Trả về ErrInsufficientStock nếu tồn kho hiện tại đã vượt quá mức policy mới cho phép.
+func (r *InventoryRepository) SetOversellPolicy(ctx context.Context, itemID, locationID string, policy model.OversellPolicy, backorderLimit int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Policy thay đổi phạm vi thay đổi hợp lệ, nên cũng tăng version của item.
+	query := "UPDATE inventory SET version = version + 1, updated_at = NOW() WHERE id = $1"
+	args := []interface{}{itemID}
+	if locationID == "" {
+		query = "UPDATE inventory SET oversell_policy = $2, backorder_limit = $3, version = version + 1, updated_at = NOW() WHERE id = $1"
+		args = append(args, policy, backorderLimit)
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return mapPQError(err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInventoryNotFound
+	}
+
+	if locationID == "" {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE inventory_locations SET oversell_policy = $2, backorder_limit = $3, updated_at = NOW()
+			WHERE item_id = $1`,
+			itemID, policy, backorderLimit)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO inventory_locations (item_id, location_id, quantity, oversell_policy, backorder_limit)
+			VALUES ($1, $2, 0, $3, $4)
+			ON CONFLICT (item_id, location_id)
+			DO UPDATE SET oversell_policy = EXCLUDED.oversell_policy, backorder_limit = EXCLUDED.backorder_limit, updated_at = NOW()`,
+			itemID, locationID, policy, backorderLimit)
+	}
+	if err != nil {
+		return mapPQError(err)
+	}
+	return tx.Commit()
+}
+
+func reasonOrDefault(reason, def model.MovementReason) model.MovementReason {
+	if reason == "" {
+		return def
+	}
+	return reason
+}
+
+// GetInventory trả về item với tổng số lượng và số lượng theo từng location.
+func (r *InventoryRepository) GetInventory(ctx context.Context, itemID string) (*model.InventoryItem, error) {
+	items, err := r.GetInventories(ctx, []string{itemID})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrInventoryNotFound
+	}
+	return items[0], nil
+}
+
+// GetInventoryTx đọc item trong transaction tx, thấy được các thay đổi chưa commit của tx.
+func (r *InventoryRepository) GetInventoryTx(ctx context.Context, tx *sql.Tx, itemID string) (*model.InventoryItem, error) {
+	items, err := getInventories(ctx, tx, []string{itemID})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrInventoryNotFound
+	}
+	return items[0], nil
+}
+
+// GetInventories trả về các item theo danh sách ID, kèm số lượng theo từng location.
+// Các ID không tồn tại bị bỏ qua.
+func (r *InventoryRepository) GetInventories(ctx context.Context, itemIDs []string) ([]*model.InventoryItem, error) {
+	return getInventories(ctx, r.db, itemIDs)
+}
+
+// queryer là phần chung của *sql.DB và *sql.Tx dùng cho các truy vấn đọc.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func getInventories(ctx context.Context, q queryer, itemIDs []string) ([]*model.InventoryItem, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT i.id, i.quantity, i.version, i.oversell_policy, i.backorder_limit, i.updated_at,
+			i.reorder_point, i.safety_stock, i.stock_alert_state, i.lot_allocation_policy, i.serialized,
+			COALESCE(l.location_id, ''), COALESCE(l.quantity, 0),
+			COALESCE(l.oversell_policy, ''), COALESCE(l.backorder_limit, 0),
+			COALESCE(l.in_transit, 0), COALESCE(l.quarantined, 0), COALESCE(l.damaged, 0),
+			`+reservationsSubquery+`, `+reservedSerialsSubquery+`
+		FROM inventory i
+		LEFT JOIN inventory_locations l ON l.item_id = i.id
+		WHERE i.id = ANY($1)
+		ORDER BY i.id, l.location_id`,
+		pq.Array(itemIDs), model.ReservationPending, model.SerialStatusReserved)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var result []*inventorypb.InventoryItem
+	var result []*model.InventoryItem
+	var current *model.InventoryItem
 
 	for rows.Next() {
-		r := &inventorypb.InventoryItem{}
-		if err := rows.Scan(&r.Id, &r.Quantity); err != nil {
+		var (
+			item                      model.InventoryItem
+			loc                       model.LocationStock
+			reorderPoint, safetyStock sql.NullInt64
+			reservations, serials     int
+		)
+		err := rows.Scan(&item.ID, &item.Quantity, &item.Version, &item.OversellPolicy, &item.BackorderLimit, &item.UpdatedAt,
+			&reorderPoint, &safetyStock, &item.StockAlertState, &item.LotAllocationPolicy, &item.Serialized,
+			&loc.LocationID, &loc.Quantity, &loc.OversellPolicy, &loc.BackorderLimit,
+			&loc.Stock.InTransit, &loc.Stock.Quarantined, &loc.Stock.Damaged, &reservations, &serials)
+		if err != nil {
 			return nil, err
 		}
-		result = append(result, r)
+		// Serial reserved không nằm trong quantity nhưng vẫn là on-hand đang được giữ.
+		loc.Stock.OnHand = loc.Quantity + serials
+		loc.Stock.Reserved = reservations + serials
+		loc.Stock.AvailableToPromise = loc.Quantity - reservations
+		item.ReorderPoint = nullIntPtr(reorderPoint)
+		item.SafetyStock = nullIntPtr(safetyStock)
+		if current == nil || current.ID != item.ID {
+			current = &item
+			result = append(result, current)
+		}
+		if loc.LocationID != "" {
+			current.Locations = append(current.Locations, loc)
+			current.Stock.Add(loc.Stock)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
+
+// Các trường có thể dùng để sắp xếp danh sách item.
+const (
+	InventorySortID        = "id"
+	InventorySortQuantity  = "quantity"
+	InventorySortUpdatedAt = "updated_at"
+)
+
+// Giới hạn kích thước trang của danh sách item.
+const (
+	DefaultInventoryPageSize = 50
+	MaxInventoryPageSize     = 500
+)
+
+// InventoryFilter lọc danh sách item; trường nil/zero nghĩa là không lọc theo trường đó.
+type InventoryFilter struct {
+	MinQuantity   *int
+	MaxQuantity   *int
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	LocationID    string // chỉ lấy item có tồn kho tại location này
+	IDPrefix      string // chỉ lấy item có ID bắt đầu bằng prefix này
+}
+
+// InventoryListOptions là bộ lọc, thứ tự và phân trang của ListInventories.
+type InventoryListOptions struct {
+	Filter   InventoryFilter
+	SortBy   string // một trong InventorySort*, rỗng = id
+	Desc     bool
+	Cursor   string
+	PageSize int
+}
+
+// inventoryCursor là vị trí của item cuối trang trước: giá trị của trường sắp xếp và id.
+type inventoryCursor struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v,omitempty"`
+	ID     string `json:"id"`
+}
+
+// ListInventories trả về các item theo bộ lọc, phân trang theo keyset (trường sắp xếp, id).
+// Cursor chỉ hợp lệ với cùng trường sắp xếp đã sinh ra nó; nextCursor rỗng nghĩa là không còn trang tiếp theo.
+func (r *InventoryRepository) ListInventories(ctx context.Context, opts InventoryListOptions) ([]*model.InventoryItem, string, error) {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = InventorySortID
+	}
+	if sortBy != InventorySortID && sortBy != InventorySortQuantity && sortBy != InventorySortUpdatedAt {
+		return nil, "", ErrInvalidSort
+	}
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = DefaultInventoryPageSize
+	}
+	if limit > MaxInventoryPageSize {
+		limit = MaxInventoryPageSize
+	}
+
+	f := opts.Filter
+	args := []interface{}{f.MinQuantity, f.MaxQuantity, f.UpdatedAfter, f.UpdatedBefore, f.LocationID, f.IDPrefix}
+	where := `
+		WHERE ($1::INT IS NULL OR i.quantity >= $1::INT)
+		  AND ($2::INT IS NULL OR i.quantity <= $2::INT)
+		  AND ($3::TIMESTAMP IS NULL OR i.updated_at >= $3::TIMESTAMP)
+		  AND ($4::TIMESTAMP IS NULL OR i.updated_at < $4::TIMESTAMP)
+		  AND ($5::VARCHAR = '' OR EXISTS (
+			SELECT 1 FROM inventory_locations l WHERE l.item_id = i.id AND l.location_id = $5::VARCHAR))
+		  AND ($6::VARCHAR = '' OR LEFT(i.id, LENGTH($6::VARCHAR)) = $6::VARCHAR)`
+
+	cmp, dir := ">", "ASC"
+	if opts.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	if opts.Cursor != "" {
+		cur, err := decodeInventoryCursor(opts.Cursor, sortBy)
+		if err != nil {
+			return nil, "", err
+		}
+		switch sortBy {
+		case InventorySortID:
+			args = append(args, cur.ID)
+			where += fmt.Sprintf(" AND i.id %s $7", cmp)
+		case InventorySortQuantity:
+			args = append(args, cur.ID, cur.Value)
+			where += fmt.Sprintf(" AND (i.quantity, i.id) %s ($8::INT, $7)", cmp)
+		case InventorySortUpdatedAt:
+			args = append(args, cur.ID, cur.Value)
+			where += fmt.Sprintf(" AND (i.updated_at, i.id) %s ($8::TIMESTAMP, $7)", cmp)
+		}
 	}
+	order := fmt.Sprintf(" ORDER BY i.id %s", dir)
+	if sortBy != InventorySortID {
+		order = fmt.Sprintf(" ORDER BY i.%s %s, i.id %s", sortBy, dir, dir)
+	}
+	args = append(args, limit+1)
 
-	response := &inventorypb.GetInventoriesResponse{
-		Data: result,
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT i.id, i.quantity, i.updated_at FROM inventory i"+where+order+fmt.Sprintf(" LIMIT $%d", len(args)),
+		args...)
+	if err != nil {
+		return nil, "", err
 	}
+	defer rows.Close()
 
-	return response, rows.Err()
+	type pageRow struct {
+		id        string
+		quantity  int
+		updatedAt time.Time
+	}
+	var page []pageRow
+	for rows.Next() {
+		var p pageRow
+		if err := rows.Scan(&p.id, &p.quantity, &p.updatedAt); err != nil {
+			return nil, "", err
+		}
+		page = append(page, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(page) > limit {
+		page = page[:limit]
+		last := page[limit-1]
+		cur := inventoryCursor{SortBy: sortBy, ID: last.id}
+		switch sortBy {
+		case InventorySortQuantity:
+			cur.Value = strconv.Itoa(last.quantity)
+		case InventorySortUpdatedAt:
+			cur.Value = last.updatedAt.Format(time.RFC3339Nano)
+		}
+		nextCursor = encodeInventoryCursor(cur)
+	}
+
+	// Nạp chi tiết theo location rồi giữ nguyên thứ tự của trang.
+	ids := make([]string, 0, len(page))
+	for _, p := range page {
+		ids = append(ids, p.id)
+	}
+	items, err := r.GetInventories(ctx, ids)
+	if err != nil {
+		return nil, "", err
+	}
+	byID := make(map[string]*model.InventoryItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	result := make([]*model.InventoryItem, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			result = append(result, item)
+		}
+	}
+	return result, nextCursor, nil
+}
+
+func encodeInventoryCursor(cur inventoryCursor) string {
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeInventoryCursor(s, sortBy string) (inventoryCursor, error) {
+	var cur inventoryCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(b, &cur) != nil || cur.SortBy != sortBy || cur.ID == "" {
+		return inventoryCursor{}, ErrInvalidPageToken
+	}
+	switch sortBy {
+	case InventorySortQuantity:
+		if _, err := strconv.Atoi(cur.Value); err != nil {
+			return inventoryCursor{}, ErrInvalidPageToken
+		}
+	case InventorySortUpdatedAt:
+		if _, err := time.Parse(time.RFC3339Nano, cur.Value); err != nil {
+			return inventoryCursor{}, ErrInvalidPageToken
+		}
+	}
+	return cur, nil
 }
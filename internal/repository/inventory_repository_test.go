@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestInventoryRepositoryGetInventories(t *testing.T) {
+	columns := []string{"id", "quantity", "version", "oversell_policy", "backorder_limit", "updated_at",
+		"reorder_point", "safety_stock", "stock_alert_state", "lot_allocation_policy", "serialized", "location_id", "location_quantity", "location_oversell_policy", "location_backorder_limit",
+		"in_transit", "quarantined", "damaged", "reservations", "reserved_serials"}
+	deny, backorder := model.OversellPolicyDeny, model.OversellPolicyBackorder
+	ok, low := model.StockAlertStateOK, model.StockAlertStateLow
+	fefo, fifo := model.LotAllocationFEFO, model.LotAllocationFIFO
+	reorderPoint, safetyStock := 8, 2
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		want []*model.InventoryItem
+	}{
+		{
+			name: "per-location stock under the item total",
+			rows: sqlmock.NewRows(columns).
+				AddRow("sku-1", 7, 4, deny, 0, updated, 8, 2, low, fefo, false, "wh-1", 5, deny, 0, 1, 2, 0, 1, 0).
+				AddRow("sku-1", 7, 4, deny, 0, updated, 8, 2, low, fefo, false, "wh-2", 2, backorder, 3, 0, 0, 1, 0, 0).
+				AddRow("sku-2", 3, 1, backorder, 2, updated, nil, nil, ok, fifo, true, "default", 3, backorder, 2, 0, 0, 0, 0, 2),
+			want: []*model.InventoryItem{
+				{ID: "sku-1", Quantity: 7, Version: 4, OversellPolicy: deny, UpdatedAt: updated,
+					ReorderPoint: &reorderPoint, SafetyStock: &safetyStock, StockAlertState: low, LotAllocationPolicy: fefo,
+					Stock: model.StockBreakdown{OnHand: 7, Reserved: 1, AvailableToPromise: 6, InTransit: 1, Quarantined: 2, Damaged: 1},
+					Locations: []model.LocationStock{
+						{LocationID: "wh-1", Quantity: 5, OversellPolicy: deny,
+							Stock: model.StockBreakdown{OnHand: 5, Reserved: 1, AvailableToPromise: 4, InTransit: 1, Quarantined: 2}},
+						{LocationID: "wh-2", Quantity: 2, OversellPolicy: backorder, BackorderLimit: 3,
+							Stock: model.StockBreakdown{OnHand: 2, AvailableToPromise: 2, Damaged: 1}},
+					}},
+				{ID: "sku-2", Quantity: 3, Version: 1, OversellPolicy: backorder, BackorderLimit: 2, UpdatedAt: updated, StockAlertState: ok, LotAllocationPolicy: fifo, Serialized: true,
+					// Serial reserved không nằm trong quantity nhưng vẫn là on-hand đang được giữ.
+					Stock: model.StockBreakdown{OnHand: 5, Reserved: 2, AvailableToPromise: 3},
+					Locations: []model.LocationStock{
+						{LocationID: "default", Quantity: 3, OversellPolicy: backorder, BackorderLimit: 2,
+							Stock: model.StockBreakdown{OnHand: 5, Reserved: 2, AvailableToPromise: 3}},
+					}},
+			},
+		},
+		{
+			name: "item without location rows",
+			rows: sqlmock.NewRows(columns).AddRow("sku-1", 0, 1, deny, 0, updated, nil, nil, ok, fefo, false, "", 0, "", 0, 0, 0, 0, 0, 0),
+			want: []*model.InventoryItem{{ID: "sku-1", Version: 1, OversellPolicy: deny, UpdatedAt: updated, StockAlertState: ok, LotAllocationPolicy: fefo}},
+		},
+		{
+			name: "unknown ids are skipped",
+			rows: sqlmock.NewRows(columns),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(regexp.QuoteMeta("FROM inventory i")).WillReturnRows(tt.rows)
+
+			got, err := NewInventoryRepository(db, StockAlertConfig{}).GetInventories(context.Background(), []string{"sku-1", "sku-2"})
+			if err != nil {
+				t.Fatalf("GetInventories() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetInventories() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryRepositoryAdjustStockExpectedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int64
+		current  *int64 // nil = item không tồn tại
+		wantErr  error
+	}{
+		{name: "stale version", expected: 3, current: ptr[int64](5), wantErr: ErrVersionMismatch},
+		{name: "missing item", expected: 3, wantErr: ErrInventoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1, version = version + 1")).
+				WithArgs(1, "sku-1", tt.expected).
+				WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}))
+			versionRows := sqlmock.NewRows([]string{"version"})
+			if tt.current != nil {
+				versionRows.AddRow(*tt.current)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM inventory WHERE id = $1")).
+				WithArgs("sku-1").
+				WillReturnRows(versionRows)
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = NewInventoryRepository(db, StockAlertConfig{}).AdjustStockTx(context.Background(), tx, StockChange{ItemID: "sku-1", Delta: 1, ExpectedVersion: tt.expected})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdjustStockTx() error = %v, want %v", err, tt.wantErr)
+			}
+			var mismatch *VersionMismatchError
+			if errors.As(err, &mismatch) && mismatch.Current != *tt.current {
+				t.Errorf("current version = %d, want %d", mismatch.Current, *tt.current)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAvailableUnderPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     int
+		policy      model.OversellPolicy
+		backorders  int
+		wantAvail   int
+		wantLimited bool
+	}{
+		{name: "deny uses on-hand", balance: 4, policy: model.OversellPolicyDeny, backorders: 10, wantAvail: 4, wantLimited: true},
+		{name: "deny with negative balance", balance: -2, policy: model.OversellPolicyDeny, wantAvail: -2, wantLimited: true},
+		{name: "backorder adds the limit", balance: 4, policy: model.OversellPolicyBackorder, backorders: 3, wantAvail: 7, wantLimited: true},
+		{name: "backorder already below zero", balance: -2, policy: model.OversellPolicyBackorder, backorders: 3, wantAvail: 1, wantLimited: true},
+		{name: "unlimited", balance: -100, policy: model.OversellPolicyUnlimited, wantLimited: false},
+		{name: "unknown policy falls back to deny", balance: 1, policy: "", wantAvail: 1, wantLimited: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			avail, limited := availableUnderPolicy(tt.balance, tt.policy, tt.backorders)
+			if avail != tt.wantAvail || limited != tt.wantLimited {
+				t.Errorf("availableUnderPolicy() = (%d, %v), want (%d, %v)", avail, limited, tt.wantAvail, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestInventoryRepositoryAdjustStockOversell(t *testing.T) {
+	tests := []struct {
+		name       string
+		delta      int
+		balance    *int // nil = location chưa có dòng tồn kho
+		reserved   int  // lượng reservation pending tại location
+		policy     model.OversellPolicy
+		backorders int
+		wantErr    error
+	}{
+		{name: "deny within on-hand", delta: -3, balance: ptr(3), policy: model.OversellPolicyDeny},
+		{name: "deny below zero", delta: -4, balance: ptr(3), policy: model.OversellPolicyDeny, wantErr: ErrInsufficientStock},
+		{name: "backorder within limit", delta: -5, balance: ptr(3), policy: model.OversellPolicyBackorder, backorders: 2},
+		{name: "backorder over limit", delta: -6, balance: ptr(3), policy: model.OversellPolicyBackorder, backorders: 2, wantErr: ErrInsufficientStock},
+		{name: "unlimited", delta: -50, balance: ptr(0), policy: model.OversellPolicyUnlimited},
+		{name: "new location uses item backorder policy", delta: -2, policy: model.OversellPolicyBackorder, backorders: 2},
+		{name: "new location uses item deny policy", delta: -1, policy: model.OversellPolicyDeny, wantErr: ErrInsufficientStock},
+		{name: "deny within available-to-promise", delta: -1, balance: ptr(3), reserved: 2, policy: model.OversellPolicyDeny},
+		{name: "pending reservations are not sellable", delta: -2, balance: ptr(3), reserved: 2, policy: model.OversellPolicyDeny, wantErr: ErrInsufficientStock},
+		{name: "backorder limit counts from available-to-promise", delta: -3, balance: ptr(3), reserved: 2, policy: model.OversellPolicyBackorder, backorders: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+				WithArgs(tt.delta, "sku-1", int64(0)).
+				WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}).
+					AddRow(10+tt.delta, 2, tt.policy, tt.backorders, model.LotAllocationFEFO, false))
+			locRows := sqlmock.NewRows([]string{"quantity", "oversell_policy", "backorder_limit"})
+			if tt.balance != nil {
+				locRows.AddRow(*tt.balance, tt.policy, tt.backorders)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, oversell_policy, backorder_limit")).
+				WithArgs("sku-1", "wh-1").
+				WillReturnRows(locRows)
+			mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations")).
+				WithArgs("sku-1", "wh-1", model.ReservationPending).
+				WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(tt.reserved))
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+					WithArgs("sku-1", "wh-1", tt.delta, tt.policy, tt.backorders).
+					WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(tt.delta))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_lots")).
+					WithArgs("sku-1", "wh-1", model.StockStateOnHand).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "lot_number", "manufactured_at", "expires_at", "quantity"}))
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = NewInventoryRepository(db, StockAlertConfig{}).AdjustStockTx(context.Background(), tx, StockChange{ItemID: "sku-1", LocationID: "wh-1", Delta: tt.delta})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdjustStockTx() error = %v, want %v", err, tt.wantErr)
+			}
+			var insufficient *InsufficientStockError
+			if errors.As(err, &insufficient) && insufficient.Requested != -tt.delta {
+				t.Errorf("requested = %d, want %d", insufficient.Requested, -tt.delta)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestInventoryCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		sortBy  string
+		want    inventoryCursor
+		wantErr error
+	}{
+		{
+			name:   "id sort round trip",
+			token:  encodeInventoryCursor(inventoryCursor{SortBy: InventorySortID, ID: "sku-9"}),
+			sortBy: InventorySortID,
+			want:   inventoryCursor{SortBy: InventorySortID, ID: "sku-9"},
+		},
+		{
+			name:   "quantity sort round trip",
+			token:  encodeInventoryCursor(inventoryCursor{SortBy: InventorySortQuantity, Value: "12", ID: "sku-9"}),
+			sortBy: InventorySortQuantity,
+			want:   inventoryCursor{SortBy: InventorySortQuantity, Value: "12", ID: "sku-9"},
+		},
+		{
+			name:    "cursor from another sort",
+			token:   encodeInventoryCursor(inventoryCursor{SortBy: InventorySortID, ID: "sku-9"}),
+			sortBy:  InventorySortQuantity,
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "bad updated_at value",
+			token:   encodeInventoryCursor(inventoryCursor{SortBy: InventorySortUpdatedAt, Value: "yesterday", ID: "sku-9"}),
+			sortBy:  InventorySortUpdatedAt,
+			wantErr: ErrInvalidPageToken,
+		},
+		{name: "not base64", token: "!!!", sortBy: InventorySortID, wantErr: ErrInvalidPageToken},
+		{
+			name:    "missing id",
+			token:   encodeInventoryCursor(inventoryCursor{SortBy: InventorySortID}),
+			sortBy:  InventorySortID,
+			wantErr: ErrInvalidPageToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeInventoryCursor(tt.token, tt.sortBy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decodeInventoryCursor() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("decodeInventoryCursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryRepositoryListInventoriesPaging(t *testing.T) {
+	pageColumns := []string{"id", "quantity", "updated_at"}
+	detailColumns := []string{"id", "quantity", "version", "oversell_policy", "backorder_limit", "updated_at",
+		"reorder_point", "safety_stock", "stock_alert_state", "lot_allocation_policy", "serialized", "location_id", "location_quantity", "location_oversell_policy", "location_backorder_limit",
+		"in_transit", "quarantined", "damaged", "reservations", "reserved_serials"}
+	now := time.Now().UTC()
+	tests := []struct {
+		name       string
+		opts       InventoryListOptions
+		rows       int
+		wantItems  int
+		wantCursor bool
+		wantErr    error
+	}{
+		{name: "last page has no cursor", opts: InventoryListOptions{PageSize: 2}, rows: 2, wantItems: 2},
+		{name: "extra row yields a cursor", opts: InventoryListOptions{PageSize: 2, SortBy: InventorySortQuantity}, rows: 3, wantItems: 2, wantCursor: true},
+		{name: "id prefix filter", opts: InventoryListOptions{PageSize: 2, Filter: InventoryFilter{IDPrefix: "sku-"}}, rows: 1, wantItems: 1},
+		{name: "unknown sort", opts: InventoryListOptions{SortBy: "name"}, wantErr: ErrInvalidSort},
+		{name: "cursor from another sort", opts: InventoryListOptions{SortBy: InventorySortQuantity, Cursor: encodeInventoryCursor(inventoryCursor{SortBy: InventorySortID, ID: "a"})}, wantErr: ErrInvalidPageToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			if tt.wantErr == nil {
+				page := sqlmock.NewRows(pageColumns)
+				details := sqlmock.NewRows(detailColumns)
+				for i := 0; i < tt.rows; i++ {
+					id := string(rune('a' + i))
+					page.AddRow(id, i, now)
+					if i < tt.wantItems {
+						details.AddRow(id, i, 1, model.OversellPolicyDeny, 0, now, nil, nil, model.StockAlertStateOK, model.LotAllocationFEFO, false, "", 0, "", 0, 0, 0, 0, 0, 0)
+					}
+				}
+				pageQuery := mock.ExpectQuery(regexp.QuoteMeta("SELECT i.id, i.quantity, i.updated_at FROM inventory i"))
+				if prefix := tt.opts.Filter.IDPrefix; prefix != "" {
+					anyArg := sqlmock.AnyArg()
+					pageQuery.WithArgs(anyArg, anyArg, anyArg, anyArg, "", prefix, tt.opts.PageSize+1)
+				}
+				pageQuery.WillReturnRows(page)
+				mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN inventory_locations l")).WillReturnRows(details)
+			}
+
+			items, next, err := NewInventoryRepository(db, StockAlertConfig{}).ListInventories(context.Background(), tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListInventories() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(items) != tt.wantItems {
+				t.Errorf("len(items) = %d, want %d", len(items), tt.wantItems)
+			}
+			if (next != "") != tt.wantCursor {
+				t.Errorf("next cursor = %q, want cursor %v", next, tt.wantCursor)
+			}
+			if tt.wantCursor {
+				cur, err := decodeInventoryCursor(next, tt.opts.SortBy)
+				if err != nil || cur.ID != "b" || cur.Value != "1" {
+					t.Errorf("next cursor = %+v (%v), want last row b with quantity 1", cur, err)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
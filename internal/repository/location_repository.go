@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"inventory-service.com/m/internal/model"
+)
+
+type LocationRepository struct {
+	db *sql.DB
+}
+
+func NewLocationRepository(db *sql.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (r *LocationRepository) CreateLocation(ctx context.Context, id, name string) (*model.Location, error) {
+	loc := &model.Location{}
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO locations (id, name) VALUES ($1, $2)
+		RETURNING id, name, created_at`,
+		id, name).Scan(&loc.ID, &loc.Name, &loc.CreatedAt)
+	if err != nil {
+		return nil, mapPQError(err)
+	}
+	return loc, nil
+}
+
+func (r *LocationRepository) UpdateLocation(ctx context.Context, id, name string) (*model.Location, error) {
+	loc := &model.Location{}
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE locations SET name = $2, updated_at = NOW() WHERE id = $1
+		RETURNING id, name, created_at`,
+		id, name).Scan(&loc.ID, &loc.Name, &loc.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrLocationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
+
+func (r *LocationRepository) ListLocations(ctx context.Context) ([]*model.Location, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, created_at FROM locations ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*model.Location
+	for rows.Next() {
+		loc := &model.Location{}
+		if err := rows.Scan(&loc.ID, &loc.Name, &loc.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, loc)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// CheckFenceTx ghi nhận fencing token của resource trong transaction tx và trả về ErrStaleFencingToken
+// nếu một owner khác đã ghi với token lớn hơn. Dòng lock_fences bị lock tới khi tx kết thúc,
+// nên các thao tác ghi trên cùng resource được tuần tự hoá theo token.
+// Dòng lock_fences cố ý không bị dọn: token của resource phải luôn tăng, giống key fence trong Redis.
+func CheckFenceTx(ctx context.Context, tx *sql.Tx, resource string, fence int64) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO lock_fences (resource, fence)
+		VALUES ($1, $2)
+		ON CONFLICT (resource) DO UPDATE
+		SET fence = EXCLUDED.fence, updated_at = CURRENT_TIMESTAMP
+		WHERE lock_fences.fence <= EXCLUDED.fence`,
+		resource, fence)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrStaleFencingToken
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCheckFenceTx(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "newer or equal token is recorded", affected: 1},
+		{name: "older token is rejected", affected: 0, wantErr: ErrStaleFencingToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO lock_fences (resource, fence)")).
+				WithArgs("{lock:inventory:sku-1}", int64(7)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckFenceTx(context.Background(), tx, "{lock:inventory:sku-1}", 7); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckFenceTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
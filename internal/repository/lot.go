@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+)
+
+// Giới hạn kích thước trang của danh sách lô sắp hết hạn.
+const (
+	DefaultLotPageSize = 100
+	MaxLotPageSize     = 1000
+)
+
+// LotReceipt là thông tin lô của một lần nhập hàng. Ngày chỉ dùng phần ngày (UTC).
+type LotReceipt struct {
+	LotNumber      string
+	ManufacturedAt *time.Time
+	ExpiresAt      *time.Time
+}
+
+// lotOrder trả về thứ tự lấy hàng của policy; FEFO đưa lô không có hạn dùng xuống cuối.
+func lotOrder(policy model.LotAllocationPolicy) string {
+	if policy == model.LotAllocationFIFO {
+		return "received_at, id"
+	}
+	return "expires_at ASC NULLS LAST, received_at, id"
+}
+
+// receiveLotTx cộng quantity vào lô lot của item ở state tại location, tạo lô nếu chưa có.
+// Nhập thêm vào lô đã có với ngày sản xuất/hạn dùng khác trả về ErrLotConflict.
+func receiveLotTx(ctx context.Context, tx *sql.Tx, itemID, locationID string, state model.StockState, lot LotReceipt, quantity int) error {
+	var manufacturedAt, expiresAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_lots (item_id, location_id, stock_state, lot_number, manufactured_at, expires_at, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (item_id, location_id, stock_state, lot_number)
+		DO UPDATE SET quantity = inventory_lots.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING manufactured_at, expires_at`,
+		itemID, locationID, state, lot.LotNumber, lot.ManufacturedAt, lot.ExpiresAt, quantity).Scan(&manufacturedAt, &expiresAt)
+	if err != nil {
+		return mapPQError(err)
+	}
+	if !sameDate(lot.ManufacturedAt, manufacturedAt) || !sameDate(lot.ExpiresAt, expiresAt) {
+		return ErrLotConflict
+	}
+	return nil
+}
+
+// receiveLotsTx ghi lại các lô đã bị trừ ở trạng thái nguồn vào state tại location, giữ nguyên số lô và ngày.
+func receiveLotsTx(ctx context.Context, tx *sql.Tx, itemID, locationID string, state model.StockState, lots []LotAllocation) error {
+	for _, lot := range lots {
+		receipt := LotReceipt{LotNumber: lot.LotNumber, ManufacturedAt: lot.ManufacturedAt, ExpiresAt: lot.ExpiresAt}
+		if err := receiveLotTx(ctx, tx, itemID, locationID, state, receipt, lot.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameDate so sánh ngày của lần nhập với ngày đã lưu; ngày không truyền thì coi như khớp.
+func sameDate(given *time.Time, stored sql.NullTime) bool {
+	if given == nil {
+		return true
+	}
+	return stored.Valid && given.UTC().Format(time.DateOnly) == stored.Time.UTC().Format(time.DateOnly)
+}
+
+// LotAllocation là phần lấy từ một lô khi tồn kho giảm.
+type LotAllocation struct {
+	LotNumber      string
+	ManufacturedAt *time.Time
+	ExpiresAt      *time.Time
+	Quantity       int
+}
+
+// allocateLotsTx trừ quantity khỏi các lô còn hàng của item ở state tại location theo policy, trả về phần
+// đã lấy từ từng lô. Phần vượt quá tổng các lô được lấy từ hàng không theo lô (hoặc backorder), nên tổng
+// các lô không bao giờ vượt quá tồn kho của state tại location. Dòng inventory của item phải đã được lock trong tx.
+func allocateLotsTx(ctx context.Context, tx *sql.Tx, itemID, locationID string, state model.StockState, quantity int, policy model.LotAllocationPolicy) ([]LotAllocation, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, lot_number, manufactured_at, expires_at, quantity FROM inventory_lots
+		WHERE item_id = $1 AND location_id = $2 AND stock_state = $3 AND quantity > 0
+		ORDER BY `+lotOrder(policy),
+		itemID, locationID, state)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ids   []int64
+		takes []LotAllocation
+	)
+	for quantity > 0 && rows.Next() {
+		var (
+			id                        int64
+			lot                       LotAllocation
+			manufacturedAt, expiresAt sql.NullTime
+			available                 int
+		)
+		if err := rows.Scan(&id, &lot.LotNumber, &manufacturedAt, &expiresAt, &available); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lot.ManufacturedAt, lot.ExpiresAt = nullTimePtr(manufacturedAt), nullTimePtr(expiresAt)
+		lot.Quantity = min(available, quantity)
+		ids = append(ids, id)
+		takes = append(takes, lot)
+		quantity -= lot.Quantity
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE inventory_lots SET quantity = quantity - $2, updated_at = NOW() WHERE id = $1",
+			id, takes[i].Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return takes, nil
+}
+
+const lotColumns = `id, item_id, location_id, stock_state, lot_number, manufactured_at, expires_at, quantity, received_at, updated_at`
+
+func scanLots(rows *sql.Rows) ([]*model.InventoryLot, error) {
+	defer rows.Close()
+	result := []*model.InventoryLot{}
+	for rows.Next() {
+		var (
+			lot                       model.InventoryLot
+			manufacturedAt, expiresAt sql.NullTime
+		)
+		err := rows.Scan(&lot.ID, &lot.ItemID, &lot.LocationID, &lot.StockState, &lot.LotNumber, &manufacturedAt, &expiresAt,
+			&lot.Quantity, &lot.ReceivedAt, &lot.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		lot.ManufacturedAt = nullTimePtr(manufacturedAt)
+		lot.ExpiresAt = nullTimePtr(expiresAt)
+		result = append(result, &lot)
+	}
+	return result, rows.Err()
+}
+
+func nullTimePtr(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
+
+// ListLots trả về các lô của item theo location, trạng thái tồn kho rồi thứ tự sẽ được lấy hàng,
+// lọc theo location (rỗng = mọi location).
+// includeEmpty = false bỏ qua các lô đã hết hàng.
+func (r *InventoryRepository) ListLots(ctx context.Context, itemID, locationID string, includeEmpty bool) ([]*model.InventoryLot, error) {
+	var policy model.LotAllocationPolicy
+	err := r.db.QueryRowContext(ctx, "SELECT lot_allocation_policy FROM inventory WHERE id = $1", itemID).Scan(&policy)
+	if err == sql.ErrNoRows {
+		return nil, ErrInventoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+lotColumns+`
+		FROM inventory_lots
+		WHERE item_id = $1
+		  AND ($2::VARCHAR = '' OR location_id = $2::VARCHAR)
+		  AND ($3 OR quantity > 0)
+		ORDER BY location_id, stock_state <> 'on_hand', stock_state, `+lotOrder(policy),
+		itemID, locationID, includeEmpty)
+	if err != nil {
+		return nil, err
+	}
+	return scanLots(rows)
+}
+
+// ExpiringLotsOptions là bộ lọc và phân trang của ListExpiringLots.
+type ExpiringLotsOptions struct {
+	ExpiresBefore time.Time // lấy các lô có hạn dùng trước hoặc đúng ngày này, kể cả lô đã hết hạn
+	LocationID    string    // rỗng = mọi location
+	Cursor        string
+	PageSize      int
+}
+
+// ListExpiringLots trả về các lô còn hàng sắp hết hạn, hạn dùng sớm nhất trước,
+// phân trang theo keyset (expires_at, id).
+func (r *InventoryRepository) ListExpiringLots(ctx context.Context, opts ExpiringLotsOptions) ([]*model.InventoryLot, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = DefaultLotPageSize
+	}
+	if limit > MaxLotPageSize {
+		limit = MaxLotPageSize
+	}
+	var (
+		afterDate *time.Time
+		afterID   int64
+	)
+	if opts.Cursor != "" {
+		date, id, err := decodeLotCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		afterDate, afterID = &date, id
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+lotColumns+`
+		FROM inventory_lots
+		WHERE quantity > 0
+		  AND expires_at <= $1::DATE
+		  AND ($2::VARCHAR = '' OR location_id = $2::VARCHAR)
+		  AND ($3::DATE IS NULL OR (expires_at, id) > ($3::DATE, $4::BIGINT))
+		ORDER BY expires_at, id
+		LIMIT $5`,
+		opts.ExpiresBefore, opts.LocationID, afterDate, afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	lots, err := scanLots(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(lots) > limit {
+		lots = lots[:limit]
+		last := lots[limit-1]
+		next = last.ExpiresAt.Format(time.DateOnly) + ":" + strconv.FormatInt(last.ID, 10)
+	}
+	return lots, next, nil
+}
+
+// decodeLotCursor đọc cursor dạng "<expires_at>:<id>".
+func decodeLotCursor(s string) (time.Time, int64, error) {
+	dateStr, idStr, ok := strings.Cut(s, ":")
+	if !ok {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+	date, err := time.Parse(time.DateOnly, dateStr)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return time.Time{}, 0, ErrInvalidPageToken
+	}
+	return date, id, nil
+}
+
+// SetLotAllocationPolicy đặt thứ tự lấy hàng từ các lô của item. Policy đổi thứ tự allocation
+// của các thay đổi sau, nên cũng tăng version của item.
+func (r *InventoryRepository) SetLotAllocationPolicy(ctx context.Context, itemID string, policy model.LotAllocationPolicy) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE inventory SET lot_allocation_policy = $2, version = version + 1, updated_at = NOW()
+		WHERE id = $1`,
+		itemID, policy)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInventoryNotFound
+	}
+	return nil
+}
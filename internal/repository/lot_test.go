@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestDecodeLotCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   string
+		wantDate time.Time
+		wantID   int64
+		wantErr  bool
+	}{
+		{name: "valid", cursor: "2026-11-30:42", wantDate: time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC), wantID: 42},
+		{name: "empty", cursor: "", wantErr: true},
+		{name: "missing separator", cursor: "2026-11-30", wantErr: true},
+		{name: "bad date", cursor: "2026-13-01:42", wantErr: true},
+		{name: "timestamp instead of date", cursor: "2026-11-30T00:00:00Z:42", wantErr: true},
+		{name: "non-numeric id", cursor: "2026-11-30:abc", wantErr: true},
+		{name: "zero id", cursor: "2026-11-30:0", wantErr: true},
+		{name: "negative id", cursor: "2026-11-30:-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDate, gotID, err := decodeLotCursor(tt.cursor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPageToken) {
+					t.Fatalf("decodeLotCursor(%q) error = %v, want ErrInvalidPageToken", tt.cursor, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeLotCursor(%q) error = %v", tt.cursor, err)
+			}
+			if !gotDate.Equal(tt.wantDate) || gotID != tt.wantID {
+				t.Errorf("decodeLotCursor(%q) = %s, %d, want %s, %d", tt.cursor, gotDate, gotID, tt.wantDate, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAllocateLotsTx(t *testing.T) {
+	type take struct {
+		id       int64
+		quantity int
+	}
+	tests := []struct {
+		name      string
+		policy    model.LotAllocationPolicy
+		wantOrder string
+		quantity  int
+		wantTakes []take
+	}{
+		{name: "fefo takes the first lots until filled", policy: model.LotAllocationFEFO, wantOrder: "ORDER BY expires_at ASC NULLS LAST, received_at, id",
+			quantity: 6, wantTakes: []take{{1, 4}, {2, 2}}},
+		{name: "fifo orders by receipt", policy: model.LotAllocationFIFO, wantOrder: "ORDER BY received_at, id",
+			quantity: 3, wantTakes: []take{{1, 3}}},
+		{name: "quantity beyond the lots comes from unlotted stock", policy: model.LotAllocationFEFO, wantOrder: "ORDER BY expires_at",
+			quantity: 20, wantTakes: []take{{1, 4}, {2, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(tt.wantOrder)).
+				WithArgs("sku-1", "wh-1", model.StockStateOnHand).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "lot_number", "manufactured_at", "expires_at", "quantity"}).
+					AddRow(1, "L1", nil, nil, 4).AddRow(2, "L2", nil, nil, 5))
+			for _, tk := range tt.wantTakes {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE inventory_lots SET quantity = quantity - $2")).
+					WithArgs(tk.id, tk.quantity).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			lots, err := allocateLotsTx(context.Background(), tx, "sku-1", "wh-1", model.StockStateOnHand, tt.quantity, tt.policy)
+			if err != nil {
+				t.Fatalf("allocateLotsTx() error = %v", err)
+			}
+			if len(lots) != len(tt.wantTakes) {
+				t.Fatalf("allocateLotsTx() = %+v, want %d lots", lots, len(tt.wantTakes))
+			}
+			for i, tk := range tt.wantTakes {
+				if want := fmt.Sprintf("L%d", tk.id); lots[i].LotNumber != want || lots[i].Quantity != tk.quantity {
+					t.Errorf("lot %d = %s x%d, want %s x%d", i, lots[i].LotNumber, lots[i].Quantity, want, tk.quantity)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReceiveLotTx(t *testing.T) {
+	day := func(s string) *time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return &d
+	}
+	tests := []struct {
+		name      string
+		lot       LotReceipt
+		storedExp interface{}
+		wantErr   error
+	}{
+		{name: "new lot", lot: LotReceipt{LotNumber: "L1", ExpiresAt: day("2026-12-01")}, storedExp: *day("2026-12-01")},
+		{name: "receipt without dates matches any lot", lot: LotReceipt{LotNumber: "L1"}, storedExp: *day("2026-12-01")},
+		{name: "different expiry for an existing lot", lot: LotReceipt{LotNumber: "L1", ExpiresAt: day("2027-01-01")}, storedExp: *day("2026-12-01"), wantErr: ErrLotConflict},
+		{name: "expiry given for a lot stored without one", lot: LotReceipt{LotNumber: "L1", ExpiresAt: day("2027-01-01")}, storedExp: nil, wantErr: ErrLotConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_lots")).
+				WithArgs("sku-1", "wh-1", model.StockStateOnHand, "L1", tt.lot.ManufacturedAt, tt.lot.ExpiresAt, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"manufactured_at", "expires_at"}).AddRow(nil, tt.storedExp))
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := receiveLotTx(context.Background(), tx, "sku-1", "wh-1", model.StockStateOnHand, tt.lot, 5); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("receiveLotTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
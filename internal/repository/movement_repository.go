@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"inventory-service.com/m/internal/model"
+)
+
+type MovementRepository struct {
+	db *sql.DB
+}
+
+func NewMovementRepository(db *sql.DB) *MovementRepository {
+	return &MovementRepository{db: db}
+}
+
+// insertMovementTx ghi một movement vào sổ cái trong cùng transaction với thay đổi số lượng.
+// StockState rỗng nghĩa là thay đổi on-hand.
+func insertMovementTx(ctx context.Context, tx *sql.Tx, m *model.StockMovement) error {
+	if m.StockState == "" {
+		m.StockState = model.StockStateOnHand
+	}
+	return tx.QueryRowContext(ctx, `
+		INSERT INTO stock_movements (item_id, location_id, stock_state, delta, balance, total_balance, reason, source, correlation_id, serial_number)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at`,
+		m.ItemID, m.LocationID, m.StockState, m.Delta, m.Balance, m.TotalBalance, m.Reason, m.Source, m.CorrelationID, m.SerialNumber,
+	).Scan(&m.ID, &m.CreatedAt)
+}
+
+const movementColumns = `id, item_id, location_id, stock_state, delta, balance, total_balance, reason, source, correlation_id, serial_number, created_at`
+
+func scanMovements(rows *sql.Rows) ([]*model.StockMovement, error) {
+	defer rows.Close()
+	var result []*model.StockMovement
+	for rows.Next() {
+		m := &model.StockMovement{}
+		err := rows.Scan(&m.ID, &m.ItemID, &m.LocationID, &m.StockState, &m.Delta, &m.Balance, &m.TotalBalance, &m.Reason, &m.Source,
+			&m.CorrelationID, &m.SerialNumber, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+const (
+	DefaultMovementPageSize = 50
+	MaxMovementPageSize     = 500
+)
+
+// ListMovements trả về các movement của item, mới nhất trước, phân trang theo keyset.
+// pageToken rỗng nghĩa là trang đầu tiên; nextToken rỗng nghĩa là không còn trang tiếp theo.
+func (r *MovementRepository) ListMovements(ctx context.Context, itemID, locationID, pageToken string, pageSize int) ([]*model.StockMovement, string, error) {
+	var afterID int64
+	if pageToken != "" {
+		id, err := strconv.ParseInt(pageToken, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, "", ErrInvalidPageToken
+		}
+		afterID = id
+	}
+	limit := pageSize
+	if limit <= 0 {
+		limit = DefaultMovementPageSize
+	}
+	if limit > MaxMovementPageSize {
+		limit = MaxMovementPageSize
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+movementColumns+`
+		FROM stock_movements
+		WHERE item_id = $1
+		  AND ($2::VARCHAR = '' OR location_id = $2::VARCHAR)
+		  AND ($3::BIGINT = 0 OR id < $3::BIGINT)
+		ORDER BY id DESC
+		LIMIT $4`,
+		itemID, locationID, afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := scanMovements(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextToken string
+	if len(result) > limit {
+		result = result[:limit]
+		nextToken = strconv.FormatInt(result[limit-1].ID, 10)
+	}
+	return result, nextToken, nil
+}
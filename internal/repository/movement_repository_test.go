@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMovementRepositoryListMovements(t *testing.T) {
+	columns := []string{"id", "item_id", "location_id", "stock_state", "delta", "balance", "total_balance", "reason", "source", "correlation_id", "serial_number", "created_at"}
+	rows := func(ids ...int64) *sqlmock.Rows {
+		r := sqlmock.NewRows(columns)
+		for _, id := range ids {
+			r.AddRow(id, "sku-1", "default", "on_hand", 1, 1, 1, "adjustment", "http", "", "", time.Now())
+		}
+		return r
+	}
+	tests := []struct {
+		name      string
+		pageToken string
+		pageSize  int
+		afterID   int64
+		limit     int // LIMIT gửi xuống DB, lớn hơn page size 1 để biết còn trang sau
+		rows      *sqlmock.Rows
+		wantIDs   []int64
+		wantNext  string
+		wantErr   error
+	}{
+		{name: "first page with more", pageSize: 2, limit: 3, rows: rows(9, 8, 7), wantIDs: []int64{9, 8}, wantNext: "8"},
+		{name: "last page", pageToken: "8", pageSize: 2, afterID: 8, limit: 3, rows: rows(7), wantIDs: []int64{7}},
+		{name: "default page size", limit: DefaultMovementPageSize + 1, rows: rows(), wantIDs: nil},
+		{name: "page size is capped", pageSize: 10000, limit: MaxMovementPageSize + 1, rows: rows(), wantIDs: nil},
+		{name: "non-numeric token", pageToken: "abc", wantErr: ErrInvalidPageToken},
+		{name: "non-positive token", pageToken: "0", wantErr: ErrInvalidPageToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			if tt.rows != nil {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM stock_movements")).
+					WithArgs("sku-1", "", tt.afterID, tt.limit).
+					WillReturnRows(tt.rows)
+			}
+
+			got, next, err := NewMovementRepository(db).ListMovements(context.Background(), "sku-1", "", tt.pageToken, tt.pageSize)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListMovements() error = %v, want %v", err, tt.wantErr)
+			}
+			var ids []int64
+			for _, m := range got {
+				ids = append(ids, m.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("ListMovements() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("ListMovements() ids = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+			if next != tt.wantNext {
+				t.Errorf("ListMovements() next = %q, want %q", next, tt.wantNext)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
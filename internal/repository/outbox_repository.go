@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+// outboxRelayLockID là khoá advisory đảm bảo chỉ một relay publish tại một thời điểm,
+// giữ đúng thứ tự event theo từng item khi chạy nhiều instance.
+const outboxRelayLockID = 7_001_004
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// EnqueueOutboxTx ghi event vào outbox trong cùng transaction tx với thay đổi dữ liệu.
+func EnqueueOutboxTx(ctx context.Context, tx *sql.Tx, topic, key string, payload []byte) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox_events (topic, message_key, payload)
+		VALUES ($1, $2, $3)`,
+		topic, key, string(payload))
+	return err
+}
+
+// ProcessPending lấy tối đa limit event pending và gọi publish trong một transaction giữ advisory lock.
+// Với mỗi message_key chỉ event cũ nhất được lấy, nên event của cùng một item luôn được publish
+// theo đúng thứ tự; event sau chỉ được xét khi event trước đã gửi thành công.
+// publish trả về lỗi theo từng event (nil = thành công). Trả về số event đã lấy.
+func (r *OutboxRepository) ProcessPending(ctx context.Context, limit int, backoff func(attempts int) time.Duration,
+	publish func(ctx context.Context, events []*model.OutboxEvent) []error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLockID).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		// Instance khác đang relay.
+		return 0, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, topic, message_key, payload, attempts
+		FROM (
+			SELECT DISTINCT ON (message_key) id, topic, message_key, payload, attempts, next_attempt_at
+			FROM outbox_events
+			WHERE status = 'pending'
+			ORDER BY message_key, id
+		) heads
+		WHERE next_attempt_at <= NOW()
+		ORDER BY id
+		LIMIT $1`, limit)
+	if err != nil {
+		return 0, err
+	}
+	var events []*model.OutboxEvent
+	for rows.Next() {
+		e := &model.OutboxEvent{}
+		if err := rows.Scan(&e.ID, &e.Topic, &e.Key, &e.Payload, &e.Attempts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	errs := publish(ctx, events)
+
+	var sent []int64
+	for i, e := range events {
+		if errs[i] == nil {
+			sent = append(sent, e.ID)
+			continue
+		}
+		delay := backoff(e.Attempts + 1)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE outbox_events
+			SET attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + make_interval(secs => $3)
+			WHERE id = $1`,
+			e.ID, errs[i].Error(), delay.Seconds())
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(sent) > 0 {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE outbox_events SET status = 'sent', sent_at = NOW()
+			WHERE id = ANY($1)`,
+			pq.Array(sent))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(events), tx.Commit()
+}
+
+// PurgeSent xoá các event đã gửi lâu hơn retention.
+func (r *OutboxRepository) PurgeSent(ctx context.Context, retention time.Duration) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM outbox_events
+		WHERE status = 'sent' AND sent_at < NOW() - make_interval(secs => $1)`,
+		retention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
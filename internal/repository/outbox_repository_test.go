@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestOutboxRepositoryProcessPending(t *testing.T) {
+	columns := []string{"id", "topic", "message_key", "payload", "attempts"}
+	backoff := func(attempts int) time.Duration { return time.Duration(attempts) * time.Second }
+	tests := []struct {
+		name      string
+		locked    bool
+		rows      [][]any
+		errs      map[int64]error // lỗi publish theo ID event
+		expect    func(mock sqlmock.Sqlmock)
+		wantCount int
+	}{
+		{
+			name:   "another relay holds the lock",
+			locked: false,
+			expect: func(mock sqlmock.Sqlmock) { mock.ExpectRollback() },
+		},
+		{
+			name:   "nothing pending",
+			locked: true,
+			expect: func(mock sqlmock.Sqlmock) { mock.ExpectRollback() },
+		},
+		{
+			name:   "sent events are marked together",
+			locked: true,
+			rows:   [][]any{{1, "t", "sku-1", "{}", 0}, {2, "t", "sku-2", "{}", 0}},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_events SET status = 'sent'")).
+					WithArgs(pq.Array([]int64{1, 2})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantCount: 2,
+		},
+		{
+			name:   "failed event is rescheduled with backoff",
+			locked: true,
+			rows:   [][]any{{1, "t", "sku-1", "{}", 2}, {2, "t", "sku-2", "{}", 0}},
+			errs:   map[int64]error{1: errors.New("broker down")},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("SET attempts = attempts + 1")).
+					WithArgs(int64(1), "broker down", float64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_events SET status = 'sent'")).
+					WithArgs(pq.Array([]int64{2})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT pg_try_advisory_xact_lock($1)")).
+				WithArgs(outboxRelayLockID).
+				WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(tt.locked))
+			if tt.locked {
+				rows := sqlmock.NewRows(columns)
+				for _, r := range tt.rows {
+					rows.AddRow(r[0], r[1], r[2], r[3], r[4])
+				}
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT ON (message_key)")).
+					WithArgs(10).
+					WillReturnRows(rows)
+			}
+			tt.expect(mock)
+
+			publish := func(_ context.Context, events []*model.OutboxEvent) []error {
+				errs := make([]error, len(events))
+				for i, e := range events {
+					errs[i] = tt.errs[e.ID]
+				}
+				return errs
+			}
+			n, err := NewOutboxRepository(db).ProcessPending(context.Background(), 10, backoff, publish)
+			if err != nil {
+				t.Fatalf("ProcessPending() error = %v", err)
+			}
+			if n != tt.wantCount {
+				t.Errorf("ProcessPending() = %d, want %d", n, tt.wantCount)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// MarkEventProcessedTx ghi nhận eventID đã được xử lý trong transaction tx.
+// Trả về false nếu event đã được xử lý trước đó (event trùng).
+func MarkEventProcessedTx(ctx context.Context, tx *sql.Tx, eventID, eventType, itemID string) (bool, error) {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO processed_events (event_id, event_type, item_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO NOTHING`,
+		eventID, eventType, itemID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// PurgeProcessedEvents xoá tối đa limit event đã xử lý từ trước retention. Event chỉ cần được giữ khi Kafka
+// còn có thể giao lại nó, nên retention phải không ngắn hơn thời gian giữ message của các topic.
+func PurgeProcessedEvents(ctx context.Context, db *sql.DB, retention time.Duration, limit int) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM processed_events
+		WHERE event_id IN (
+			SELECT event_id FROM processed_events
+			WHERE processed_at < NOW() - $1 * INTERVAL '1 second'
+			LIMIT $2
+		)`,
+		int64(retention/time.Second), limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+type ReservationRepository struct {
+	db *sql.DB
+}
+
+func NewReservationRepository(db *sql.DB) *ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+// StockAdjuster áp dụng thay đổi tồn kho trong transaction tx, kể cả ghi event vào outbox,
+// để thay đổi từ reservation đi cùng đường với các thay đổi tồn kho khác.
+type StockAdjuster func(ctx context.Context, tx *sql.Tx, change StockChange) error
+
+const reservationColumns = `id, item_id, location_id, order_id, quantity, status, expires_at, created_at, updated_at`
+
+func scanReservation(row interface{ Scan(dest ...any) error }) (*model.Reservation, error) {
+	res := &model.Reservation{}
+	err := row.Scan(&res.ID, &res.ItemID, &res.LocationID, &res.OrderID, &res.Quantity, &res.Status, &res.ExpiresAt, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Reserve giữ chỗ quantity đơn vị của itemID tại locationID trong khoảng ttl mà không trừ quantity.
+// Dòng inventory được lock (FOR UPDATE) để hai checkout đồng thời không thể oversell.
+func (r *ReservationRepository) Reserve(ctx context.Context, itemID, locationID, orderID string, quantity int, ttl time.Duration) (*model.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	locationID = locationOrDefault(locationID)
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM inventory WHERE id = $1 FOR UPDATE", itemID); err != nil {
+		return nil, err
+	}
+
+	var (
+		onHand     int
+		policy     model.OversellPolicy
+		backorders int
+		serialized bool
+	)
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(l.quantity, 0), COALESCE(l.oversell_policy, i.oversell_policy), COALESCE(l.backorder_limit, i.backorder_limit), i.serialized
+		FROM inventory i
+		LEFT JOIN inventory_locations l ON l.item_id = i.id AND l.location_id = $2
+		WHERE i.id = $1`,
+		itemID, locationID).Scan(&onHand, &policy, &backorders, &serialized)
+	if err == sql.ErrNoRows {
+		return nil, ErrInventoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Item serialized được giữ chỗ theo từng serial.
+	if serialized {
+		return nil, ErrSerializedItem
+	}
+
+	reserved, err := reservedTx(ctx, tx, itemID, locationID)
+	if err != nil {
+		return nil, err
+	}
+	// Reservation cũng tuân theo oversell policy của location: backorder được giữ chỗ tới hạn mức.
+	available, limited := availableUnderPolicy(onHand-reserved, policy, backorders)
+	if limited && available < quantity {
+		return nil, &InsufficientStockError{
+			ItemID:     itemID,
+			LocationID: locationID,
+			Available:  available,
+			Requested:  quantity,
+		}
+	}
+
+	row := tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_reservations (id, item_id, location_id, order_id, quantity, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW() + make_interval(secs => $7))
+		RETURNING `+reservationColumns,
+		idUtils.NewID(), itemID, locationID, orderID, quantity, model.ReservationPending, ttl.Seconds())
+	res, err := scanReservation(row)
+	if err != nil {
+		return nil, mapPQError(err)
+	}
+	return res, tx.Commit()
+}
+
+// Confirm chốt reservation: trừ quantity thực tế của item qua adjust và đánh dấu confirmed,
+// tất cả trong một transaction. Movement được ghi với correlation ID là ID của reservation.
+func (r *ReservationRepository) Confirm(ctx context.Context, reservationID string, source model.MovementSource, adjust StockAdjuster) (*model.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := lockPendingReservationTx(ctx, tx, reservationID)
+	if err == ErrReservationExpired {
+		// Lưu lại trạng thái expired trước khi báo lỗi cho client.
+		if commitErr := tx.Commit(); commitErr != nil {
+			return nil, commitErr
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Đánh dấu confirmed trước khi trừ tồn kho để lượng đang giữ không bị tính hai lần vào reserved
+	// khi kiểm tra available-to-promise.
+	confirmed, err := setReservationStatusTx(ctx, tx, reservationID, model.ReservationConfirmed)
+	if err != nil {
+		return nil, err
+	}
+	err = adjust(ctx, tx, StockChange{
+		ItemID:        res.ItemID,
+		LocationID:    res.LocationID,
+		Delta:         -res.Quantity,
+		Reason:        model.MovementReasonReservationConfirm,
+		Source:        source,
+		CorrelationID: res.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return confirmed, tx.Commit()
+}
+
+// Release huỷ reservation đang pending, trả lại lượng đã giữ chỗ.
+func (r *ReservationRepository) Release(ctx context.Context, reservationID string) (*model.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockPendingReservationTx(ctx, tx, reservationID); err != nil {
+		if err == ErrReservationExpired {
+			if commitErr := tx.Commit(); commitErr != nil {
+				return nil, commitErr
+			}
+		}
+		return nil, err
+	}
+
+	res, err := setReservationStatusTx(ctx, tx, reservationID, model.ReservationReleased)
+	if err != nil {
+		return nil, err
+	}
+	return res, tx.Commit()
+}
+
+// GetReservation trả về reservation theo ID.
+func (r *ReservationRepository) GetReservation(ctx context.Context, reservationID string) (*model.Reservation, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+reservationColumns+" FROM inventory_reservations WHERE id = $1", reservationID)
+	res, err := scanReservation(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationNotFound
+	}
+	return res, err
+}
+
+// ExpireReservations đánh dấu expired cho mọi reservation pending đã quá hạn
+// và trả về ID các item có reservation vừa hết hạn.
+func (r *ReservationRepository) ExpireReservations(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH expired AS (
+			UPDATE inventory_reservations SET status = $1, updated_at = NOW()
+			WHERE status = $2 AND expires_at <= NOW()
+			RETURNING item_id
+		)
+		SELECT DISTINCT item_id FROM expired`,
+		model.ReservationExpired, model.ReservationPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var itemIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		itemIDs = append(itemIDs, id)
+	}
+	return itemIDs, rows.Err()
+}
+
+// lockPendingReservationTx lock reservation và kiểm tra nó vẫn còn pending và chưa hết hạn.
+// Nếu reservation đã quá hạn, nó được chuyển sang expired trong cùng transaction.
+func lockPendingReservationTx(ctx context.Context, tx *sql.Tx, reservationID string) (*model.Reservation, error) {
+	row := tx.QueryRowContext(ctx, "SELECT "+reservationColumns+", expires_at <= NOW() FROM inventory_reservations WHERE id = $1 FOR UPDATE", reservationID)
+	res := &model.Reservation{}
+	var expired bool
+	err := row.Scan(&res.ID, &res.ItemID, &res.LocationID, &res.OrderID, &res.Quantity, &res.Status, &res.ExpiresAt, &res.CreatedAt, &res.UpdatedAt, &expired)
+	if err == sql.ErrNoRows {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.Status != model.ReservationPending {
+		return nil, ErrReservationNotPending
+	}
+	if expired {
+		if _, err := setReservationStatusTx(ctx, tx, reservationID, model.ReservationExpired); err != nil {
+			return nil, err
+		}
+		return nil, ErrReservationExpired
+	}
+	return res, nil
+}
+
+func setReservationStatusTx(ctx context.Context, tx *sql.Tx, reservationID string, status model.ReservationStatus) (*model.Reservation, error) {
+	row := tx.QueryRowContext(ctx, `
+		UPDATE inventory_reservations SET status = $1, updated_at = NOW()
+		WHERE id = $2
+		RETURNING `+reservationColumns,
+		status, reservationID)
+	return scanReservation(row)
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+var errOutboxDown = errors.New("outbox down")
+
+var reservationTestColumns = []string{"id", "item_id", "location_id", "order_id", "quantity", "status", "expires_at", "created_at", "updated_at"}
+
+func reservationRow(status model.ReservationStatus) []driver.Value {
+	now := time.Now()
+	return []driver.Value{"res-1", "sku-1", "wh-1", "order-1", 2, string(status), now.Add(time.Minute), now, now}
+}
+
+// expectLockReservation mong đợi lockPendingReservationTx đọc reservation với trạng thái status và cờ expired.
+func expectLockReservation(mock sqlmock.Sqlmock, status model.ReservationStatus, expired bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations WHERE id = $1 FOR UPDATE")).
+		WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(append(reservationTestColumns, "expired")).
+			AddRow(append(reservationRow(status), expired)...))
+}
+
+func expectSetReservationStatus(mock sqlmock.Sqlmock, status model.ReservationStatus) {
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory_reservations SET status = $1")).
+		WithArgs(status, "res-1").
+		WillReturnRows(sqlmock.NewRows(reservationTestColumns).AddRow(reservationRow(status)...))
+}
+
+func TestReservationRepositoryReserve(t *testing.T) {
+	tests := []struct {
+		name       string
+		onHand     int
+		policy     model.OversellPolicy
+		backorders int
+		serialized bool
+		reserved   int
+		quantity   int
+		wantErr    error
+	}{
+		{name: "fits in available stock", onHand: 10, policy: model.OversellPolicyDeny, reserved: 8, quantity: 2},
+		{name: "pending reservations hold stock", onHand: 10, policy: model.OversellPolicyDeny, reserved: 8, quantity: 3, wantErr: ErrInsufficientStock},
+		{name: "nothing reserved", onHand: 5, policy: model.OversellPolicyDeny, reserved: 0, quantity: 5},
+		{name: "backorder within limit", onHand: 2, policy: model.OversellPolicyBackorder, backorders: 5, reserved: 1, quantity: 6},
+		{name: "backorder over limit", onHand: 2, policy: model.OversellPolicyBackorder, backorders: 5, reserved: 1, quantity: 7, wantErr: ErrInsufficientStock},
+		{name: "unlimited ignores stock", onHand: 0, policy: model.OversellPolicyUnlimited, reserved: 4, quantity: 100},
+		{name: "serialized items are reserved by serial", onHand: 5, policy: model.OversellPolicyDeny, serialized: true, quantity: 1, wantErr: ErrSerializedItem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("SELECT 1 FROM inventory WHERE id = $1 FOR UPDATE")).
+				WithArgs("sku-1").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta("LEFT JOIN inventory_locations l")).
+				WithArgs("sku-1", "wh-1").
+				WillReturnRows(sqlmock.NewRows([]string{"quantity", "oversell_policy", "backorder_limit", "serialized"}).
+					AddRow(tt.onHand, tt.policy, tt.backorders, tt.serialized))
+			if !tt.serialized {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(quantity), 0) FROM inventory_reservations")).
+					WithArgs("sku-1", "wh-1", model.ReservationPending).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(tt.reserved))
+			}
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_reservations")).
+					WithArgs(sqlmock.AnyArg(), "sku-1", "wh-1", "order-1", tt.quantity, model.ReservationPending, float64(60)).
+					WillReturnRows(sqlmock.NewRows(reservationTestColumns).AddRow(reservationRow(model.ReservationPending)...))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			res, err := NewReservationRepository(db).Reserve(context.Background(), "sku-1", "wh-1", "order-1", tt.quantity, time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reserve() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && res.Status != model.ReservationPending {
+				t.Errorf("Reserve() status = %s, want pending", res.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// outboxAdjuster trừ tồn kho và ghi event vào outbox như reservation service.
+func outboxAdjuster(db *sql.DB) StockAdjuster {
+	return func(ctx context.Context, tx *sql.Tx, change StockChange) error {
+		if _, err := NewInventoryRepository(db, StockAlertConfig{}).AdjustStockTx(ctx, tx, change); err != nil {
+			return err
+		}
+		return EnqueueOutboxTx(ctx, tx, "inventory-events", change.ItemID, []byte(`{}`))
+	}
+}
+
+// expectConfirmDeduct mong đợi AdjustStockTx trừ 2 đơn vị của sku-1 tại wh-1 (policy deny, còn 5, reservation
+// đang confirm không còn được tính là pending),
+// lấy 1 từ lô 1 và 1 từ lô 2 theo FEFO.
+func expectConfirmDeduct(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+		WithArgs(-2, "sku-1", int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}).
+			AddRow(8, 2, model.OversellPolicyDeny, 0, model.LotAllocationFEFO, false))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, oversell_policy, backorder_limit")).
+		WithArgs("sku-1", "wh-1").
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "oversell_policy", "backorder_limit"}).
+			AddRow(5, model.OversellPolicyDeny, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations")).
+		WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+		WithArgs("sku-1", "wh-1", -2, model.OversellPolicyDeny, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_lots")).
+		WithArgs("sku-1", "wh-1", model.StockStateOnHand).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lot_number", "manufactured_at", "expires_at", "quantity"}).
+			AddRow(1, "L1", nil, nil, 1).AddRow(2, "L2", nil, nil, 5))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE inventory_lots SET quantity = quantity - $2")).
+		WithArgs(int64(1), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE inventory_lots SET quantity = quantity - $2")).
+		WithArgs(int64(2), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestReservationRepositoryConfirmRelease(t *testing.T) {
+	type op func(r *ReservationRepository, db *sql.DB) (*model.Reservation, error)
+	confirm := func(r *ReservationRepository, db *sql.DB) (*model.Reservation, error) {
+		return r.Confirm(context.Background(), "res-1", model.MovementSourceGRPC, outboxAdjuster(db))
+	}
+	release := func(r *ReservationRepository, _ *sql.DB) (*model.Reservation, error) {
+		return r.Release(context.Background(), "res-1")
+	}
+	tests := []struct {
+		name       string
+		op         op
+		status     model.ReservationStatus
+		expired    bool
+		expect     func(mock sqlmock.Sqlmock)
+		wantStatus model.ReservationStatus
+		wantErr    error
+	}{
+		{
+			name:   "confirm deducts on-hand at the reserved location",
+			op:     confirm,
+			status: model.ReservationPending,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectSetReservationStatus(mock, model.ReservationConfirmed)
+				expectConfirmDeduct(mock)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+					WithArgs("sku-1", "wh-1", model.StockStateOnHand, -2, 3, 8, model.MovementReasonReservationConfirm, model.MovementSourceGRPC, "res-1", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+					WithArgs("inventory-events", "sku-1", `{}`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantStatus: model.ReservationConfirmed,
+		},
+		{
+			name:   "confirm rolls back when the outbox write fails",
+			op:     confirm,
+			status: model.ReservationPending,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectSetReservationStatus(mock, model.ReservationConfirmed)
+				expectConfirmDeduct(mock)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+					WillReturnError(errOutboxDown)
+				mock.ExpectRollback()
+			},
+			wantErr: errOutboxDown,
+		},
+		{
+			name:   "release keeps on-hand",
+			op:     release,
+			status: model.ReservationPending,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectSetReservationStatus(mock, model.ReservationReleased)
+				mock.ExpectCommit()
+			},
+			wantStatus: model.ReservationReleased,
+		},
+		{
+			name:    "confirm after ttl persists expired status",
+			op:      confirm,
+			status:  model.ReservationPending,
+			expired: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectSetReservationStatus(mock, model.ReservationExpired)
+				mock.ExpectCommit()
+			},
+			wantErr: ErrReservationExpired,
+		},
+		{
+			name:    "release after ttl persists expired status",
+			op:      release,
+			status:  model.ReservationPending,
+			expired: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectSetReservationStatus(mock, model.ReservationExpired)
+				mock.ExpectCommit()
+			},
+			wantErr: ErrReservationExpired,
+		},
+		{
+			name:    "confirm twice",
+			op:      confirm,
+			status:  model.ReservationConfirmed,
+			expect:  func(mock sqlmock.Sqlmock) { mock.ExpectRollback() },
+			wantErr: ErrReservationNotPending,
+		},
+		{
+			name:    "release after confirm",
+			op:      release,
+			status:  model.ReservationConfirmed,
+			expect:  func(mock sqlmock.Sqlmock) { mock.ExpectRollback() },
+			wantErr: ErrReservationNotPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			expectLockReservation(mock, tt.status, tt.expired)
+			tt.expect(mock)
+
+			res, err := tt.op(NewReservationRepository(db), db)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && res.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", res.Status, tt.wantStatus)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReservationRepositoryNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations WHERE id = $1 FOR UPDATE")).
+		WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(append(reservationTestColumns, "expired")))
+	mock.ExpectRollback()
+
+	if _, err := NewReservationRepository(db).Confirm(context.Background(), "res-1", model.MovementSourceGRPC, outboxAdjuster(db)); !errors.Is(err, ErrReservationNotFound) {
+		t.Fatalf("Confirm() error = %v, want ErrReservationNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
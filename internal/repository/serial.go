@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"inventory-service.com/m/internal/model"
+)
+
+// Giới hạn kích thước trang của danh sách serial.
+const (
+	DefaultSerialPageSize = 100
+	MaxSerialPageSize     = 1000
+)
+
+const serialColumns = `item_id, serial_number, location_id, status, order_id, created_at, updated_at`
+
+func scanSerial(row interface{ Scan(dest ...any) error }) (*model.InventorySerial, error) {
+	serial := &model.InventorySerial{}
+	err := row.Scan(&serial.ItemID, &serial.SerialNumber, &serial.LocationID, &serial.Status, &serial.OrderID,
+		&serial.CreatedAt, &serial.UpdatedAt)
+	return serial, err
+}
+
+// LockSerialTx đọc và lock serial trong transaction tx.
+func (r *InventoryRepository) LockSerialTx(ctx context.Context, tx *sql.Tx, itemID, serialNumber string) (*model.InventorySerial, error) {
+	serial, err := scanSerial(tx.QueryRowContext(ctx,
+		"SELECT "+serialColumns+" FROM inventory_serials WHERE item_id = $1 AND serial_number = $2 FOR UPDATE",
+		itemID, serialNumber))
+	if err == sql.ErrNoRows {
+		return nil, ErrSerialNotFound
+	}
+	return serial, err
+}
+
+// InsertSerialTx tạo serial mới trong transaction tx; serial đã có trả về ErrSerialAlreadyExists.
+func (r *InventoryRepository) InsertSerialTx(ctx context.Context, tx *sql.Tx, serial *model.InventorySerial) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_serials (item_id, serial_number, location_id, status, order_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (item_id, serial_number) DO NOTHING
+		RETURNING created_at, updated_at`,
+		serial.ItemID, serial.SerialNumber, serial.LocationID, serial.Status, serial.OrderID).Scan(&serial.CreatedAt, &serial.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSerialAlreadyExists
+	}
+	return mapPQError(err)
+}
+
+// UpdateSerialTx ghi location, trạng thái và order của serial trong transaction tx.
+func (r *InventoryRepository) UpdateSerialTx(ctx context.Context, tx *sql.Tx, serial *model.InventorySerial) error {
+	err := tx.QueryRowContext(ctx, `
+		UPDATE inventory_serials SET location_id = $3, status = $4, order_id = $5, updated_at = NOW()
+		WHERE item_id = $1 AND serial_number = $2
+		RETURNING updated_at`,
+		serial.ItemID, serial.SerialNumber, serial.LocationID, serial.Status, serial.OrderID).Scan(&serial.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrSerialNotFound
+	}
+	return mapPQError(err)
+}
+
+// ListSerials trả về các serial của item theo serial_number, lọc theo trạng thái và location (rỗng = tất cả),
+// phân trang theo keyset; cursor là serial_number cuối trang trước.
+func (r *InventoryRepository) ListSerials(ctx context.Context, itemID string, status model.SerialStatus, locationID, cursor string, pageSize int) ([]*model.InventorySerial, string, error) {
+	limit := pageSize
+	if limit <= 0 {
+		limit = DefaultSerialPageSize
+	}
+	if limit > MaxSerialPageSize {
+		limit = MaxSerialPageSize
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+serialColumns+`
+		FROM inventory_serials
+		WHERE item_id = $1
+		  AND ($2::VARCHAR = '' OR status = $2::VARCHAR)
+		  AND ($3::VARCHAR = '' OR location_id = $3::VARCHAR)
+		  AND serial_number > $4
+		ORDER BY serial_number
+		LIMIT $5`,
+		itemID, status, locationID, cursor, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	result := []*model.InventorySerial{}
+	for rows.Next() {
+		serial, err := scanSerial(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, serial)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(result) > limit {
+		result = result[:limit]
+		next = result[limit-1].SerialNumber
+	}
+	return result, next, nil
+}
+
+// TraceSerial trả về trạng thái hiện tại của serial cùng toàn bộ movement của nó trong sổ cái, cũ nhất trước.
+// Sổ cái được giữ cả sau khi item bị xoá, khi đó Serial là nil nhưng lịch sử vẫn được trả về.
+func (r *InventoryRepository) TraceSerial(ctx context.Context, itemID, serialNumber string) (*model.SerialTrace, error) {
+	serial, err := scanSerial(r.db.QueryRowContext(ctx,
+		"SELECT "+serialColumns+" FROM inventory_serials WHERE item_id = $1 AND serial_number = $2",
+		itemID, serialNumber))
+	if err == sql.ErrNoRows {
+		serial = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+movementColumns+`
+		FROM stock_movements
+		WHERE item_id = $1 AND serial_number = $2
+		ORDER BY id`,
+		itemID, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := scanMovements(rows)
+	if err != nil {
+		return nil, err
+	}
+	if serial == nil && len(movements) == 0 {
+		return nil, ErrSerialNotFound
+	}
+	if movements == nil {
+		movements = []*model.StockMovement{}
+	}
+	return &model.SerialTrace{Serial: serial, Movements: movements}, nil
+}
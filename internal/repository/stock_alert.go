@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// StockAlertConfig cấu hình cảnh báo tồn kho thấp của InventoryRepository.
+type StockAlertConfig struct {
+	Topic string // topic Kafka nhận StockAlertEvent; rỗng = không phát cảnh báo
+	// HysteresisPercent là vùng đệm, tính theo % reorder point và tối thiểu 1 đơn vị, mà tồn kho phải vượt
+	// qua ngưỡng trước khi item thoát trạng thái low/out.
+	HysteresisPercent int
+}
+
+// nextStockAlertState trả về trạng thái cảnh báo của item có quantity, với trạng thái hiện tại current.
+// Vào trạng thái khi chạm ngưỡng (0 với out, reorderPoint với low), nhưng chỉ thoát khi vượt ngưỡng quá band,
+// nên tồn kho dao động quanh ngưỡng không làm cảnh báo bật tắt liên tục.
+func nextStockAlertState(current model.StockAlertState, quantity, reorderPoint, band int) model.StockAlertState {
+	switch {
+	case quantity <= 0:
+		return model.StockAlertStateOut
+	case current == model.StockAlertStateOut && quantity <= band:
+		return model.StockAlertStateOut
+	case quantity <= reorderPoint:
+		return model.StockAlertStateLow
+	case current != model.StockAlertStateOK && quantity <= reorderPoint+band:
+		return model.StockAlertStateLow
+	default:
+		return model.StockAlertStateOK
+	}
+}
+
+func stockAlertType(previous, next model.StockAlertState) model.StockAlertType {
+	switch {
+	case next == model.StockAlertStateOut:
+		return model.StockAlertOutOfStock
+	case next == model.StockAlertStateLow && previous == model.StockAlertStateOK:
+		return model.StockAlertLowStock
+	default:
+		return model.StockAlertBackInStock
+	}
+}
+
+// evaluateStockAlertTx so tồn kho hiện tại của item với ngưỡng cảnh báo; nếu trạng thái cảnh báo thay đổi
+// thì lưu trạng thái mới và ghi StockAlertEvent vào outbox trong cùng transaction tx.
+// Dòng inventory của item phải đã được lock trong tx.
+func (r *InventoryRepository) evaluateStockAlertTx(ctx context.Context, tx *sql.Tx, itemID string) error {
+	if r.alerts.Topic == "" {
+		return nil
+	}
+
+	var (
+		quantity     int
+		reorderPoint sql.NullInt64
+		safetyStock  sql.NullInt64
+		current      model.StockAlertState
+	)
+	err := tx.QueryRowContext(ctx,
+		"SELECT quantity, reorder_point, safety_stock, stock_alert_state FROM inventory WHERE id = $1",
+		itemID).Scan(&quantity, &reorderPoint, &safetyStock, &current)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !reorderPoint.Valid {
+		return nil
+	}
+
+	rp := int(reorderPoint.Int64)
+	band := rp * r.alerts.HysteresisPercent / 100
+	if band < 1 {
+		band = 1
+	}
+	next := nextStockAlertState(current, quantity, rp, band)
+	if next == current {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE inventory SET stock_alert_state = $2 WHERE id = $1", itemID, next); err != nil {
+		return err
+	}
+
+	event := model.StockAlertEvent{
+		EventID:       idUtils.NewID(),
+		Type:          stockAlertType(current, next),
+		Id:            itemID,
+		Quantity:      quantity,
+		ReorderPoint:  rp,
+		State:         next,
+		PreviousState: current,
+		DateTime:      time.Now(),
+	}
+	if safetyStock.Valid {
+		ss := int(safetyStock.Int64)
+		event.SafetyStock = &ss
+		event.BelowSafetyStock = quantity <= ss
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return EnqueueOutboxTx(ctx, tx, r.alerts.Topic, itemID, payload)
+}
+
+// SetReorderPoint đặt ngưỡng cảnh báo của item (nil = bỏ ngưỡng) và đánh giá lại ngay trạng thái cảnh báo
+// theo tồn kho hiện tại. Bỏ reorder point đưa trạng thái về ok mà không phát event.
+func (r *InventoryRepository) SetReorderPoint(ctx context.Context, itemID string, reorderPoint, safetyStock *int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE inventory
+		SET reorder_point = $2, safety_stock = $3,
+			stock_alert_state = CASE WHEN $2::INT IS NULL THEN 'ok' ELSE stock_alert_state END,
+			version = version + 1, updated_at = NOW()
+		WHERE id = $1`,
+		itemID, reorderPoint, safetyStock)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInventoryNotFound
+	}
+	if err := r.evaluateStockAlertTx(ctx, tx, itemID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+func TestNextStockAlertState(t *testing.T) {
+	const (
+		ok  = model.StockAlertStateOK
+		low = model.StockAlertStateLow
+		out = model.StockAlertStateOut
+	)
+	tests := []struct {
+		name         string
+		current      model.StockAlertState
+		quantity     int
+		reorderPoint int
+		band         int
+		want         model.StockAlertState
+	}{
+		{"ok stays ok above reorder point", ok, 11, 10, 2, ok},
+		{"ok enters low at reorder point", ok, 10, 10, 2, low},
+		{"ok enters out at zero", ok, 0, 10, 2, out},
+		{"negative quantity is out", low, -3, 10, 2, out},
+		{"low stays low inside band", low, 12, 10, 2, low},
+		{"low leaves past band", low, 13, 10, 2, ok},
+		{"out stays out inside band", out, 2, 10, 2, out},
+		{"out moves to low past band", out, 3, 10, 2, low},
+		{"out moves to ok past reorder band", out, 13, 10, 2, ok},
+		{"zero reorder point only alerts on out", ok, 1, 0, 1, ok},
+		{"zero reorder point out stays inside band", out, 1, 0, 1, out},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextStockAlertState(tt.current, tt.quantity, tt.reorderPoint, tt.band)
+			if got != tt.want {
+				t.Errorf("nextStockAlertState(%q, %d, %d, %d) = %q, want %q",
+					tt.current, tt.quantity, tt.reorderPoint, tt.band, got, tt.want)
+			}
+		})
+	}
+}
+
+// alertPayload so khớp payload outbox là StockAlertEvent loại want.
+type alertPayload struct {
+	t    *testing.T
+	want model.StockAlertType
+}
+
+func (p alertPayload) Match(v driver.Value) bool {
+	var raw []byte
+	switch b := v.(type) {
+	case []byte:
+		raw = b
+	case string:
+		raw = []byte(b)
+	default:
+		return false
+	}
+	var event model.StockAlertEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		p.t.Errorf("payload is not a StockAlertEvent: %v", err)
+		return false
+	}
+	return event.EventID != "" && event.Id == "sku-1" && event.Type == p.want
+}
+
+func TestEvaluateStockAlertTx(t *testing.T) {
+	tests := []struct {
+		name         string
+		topic        string
+		quantity     int
+		reorderPoint interface{}
+		current      model.StockAlertState
+		wantState    model.StockAlertState // rỗng = không đổi trạng thái, không phát event
+		wantType     model.StockAlertType
+	}{
+		{name: "alerts disabled without topic", quantity: 0, reorderPoint: 10, current: model.StockAlertStateOK},
+		{name: "item without reorder point", topic: "stock-alerts", quantity: 0, reorderPoint: nil, current: model.StockAlertStateOK},
+		{name: "unchanged state emits nothing", topic: "stock-alerts", quantity: 5, reorderPoint: 10, current: model.StockAlertStateLow},
+		{name: "falling to reorder point", topic: "stock-alerts", quantity: 10, reorderPoint: 10, current: model.StockAlertStateOK,
+			wantState: model.StockAlertStateLow, wantType: model.StockAlertLowStock},
+		{name: "running out", topic: "stock-alerts", quantity: 0, reorderPoint: 10, current: model.StockAlertStateLow,
+			wantState: model.StockAlertStateOut, wantType: model.StockAlertOutOfStock},
+		{name: "restocked past the band", topic: "stock-alerts", quantity: 20, reorderPoint: 10, current: model.StockAlertStateOut,
+			wantState: model.StockAlertStateOK, wantType: model.StockAlertBackInStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tt.topic != "" {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, reorder_point, safety_stock, stock_alert_state FROM inventory")).
+					WithArgs("sku-1").
+					WillReturnRows(sqlmock.NewRows([]string{"quantity", "reorder_point", "safety_stock", "stock_alert_state"}).
+						AddRow(tt.quantity, tt.reorderPoint, nil, tt.current))
+			}
+			if tt.wantState != "" {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE inventory SET stock_alert_state = $2")).
+					WithArgs("sku-1", tt.wantState).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+					WithArgs(tt.topic, "sku-1", alertPayload{t, tt.wantType}).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo := NewInventoryRepository(db, StockAlertConfig{Topic: tt.topic, HysteresisPercent: 10})
+			if err := repo.evaluateStockAlertTx(context.Background(), tx, "sku-1"); err != nil {
+				t.Fatalf("evaluateStockAlertTx() error = %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
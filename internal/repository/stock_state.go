@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"inventory-service.com/m/internal/model"
+)
+
+// reservationsSubquery tính lượng đang được giữ bởi reservation pending chưa hết hạn ($2 = pending)
+// tại dòng inventory_locations l.
+const reservationsSubquery = `COALESCE((
+				SELECT SUM(r.quantity) FROM inventory_reservations r
+				WHERE r.item_id = l.item_id AND r.location_id = l.location_id AND r.status = $2 AND r.expires_at > NOW()
+			), 0)`
+
+// reservedSerialsSubquery đếm serial reserved ($3 = reserved) tại dòng inventory_locations l.
+const reservedSerialsSubquery = `(
+				SELECT COUNT(*) FROM inventory_serials s
+				WHERE s.item_id = l.item_id AND s.location_id = l.location_id AND s.status = $3
+			)`
+
+// reservedTx tính lượng on-hand đang được giữ bởi reservation pending chưa hết hạn của item tại location.
+// Available-to-promise là on-hand trừ phần này. Serial reserved không được tính: chúng đã rời quantity
+// khi được giữ, vì quantity của item serialized là số serial available.
+func reservedTx(ctx context.Context, tx *sql.Tx, itemID, locationID string) (int, error) {
+	var reserved int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(quantity), 0) FROM inventory_reservations
+		WHERE item_id = $1 AND location_id = $2 AND status = $3 AND expires_at > NOW()`,
+		itemID, locationID, model.ReservationPending).Scan(&reserved)
+	return reserved, err
+}
+
+// stockStateColumn trả về cột của inventory_locations lưu state.
+func stockStateColumn(state model.StockState) string {
+	switch state {
+	case model.StockStateInTransit:
+		return "in_transit"
+	case model.StockStateQuarantined:
+		return "quarantined"
+	case model.StockStateDamaged:
+		return "damaged"
+	default:
+		return "quantity"
+	}
+}
+
+// StockTransition chuyển Quantity đơn vị của item từ trạng thái From tại LocationID sang trạng thái To
+// tại ToLocationID. From rỗng là hàng từ ngoài vào, To rỗng là hàng bị loại khỏi tồn kho.
+type StockTransition struct {
+	ItemID        string
+	LocationID    string // rỗng = location mặc định
+	ToLocationID  string // rỗng = LocationID
+	From          model.StockState
+	To            model.StockState
+	Quantity      int
+	Reason        model.MovementReason
+	Source        model.MovementSource
+	CorrelationID string
+	// ExpectedVersion khác 0 thì chỉ chuyển khi version hiện tại của item khớp.
+	ExpectedVersion int64
+}
+
+// stockLeg là một vế của StockTransition: Delta cộng vào State tại LocationID.
+type stockLeg struct {
+	State      model.StockState
+	LocationID string
+	Delta      int
+}
+
+// legs trả về các vế của t, bỏ qua vế có trạng thái rỗng.
+func (t StockTransition) legs() []stockLeg {
+	from := locationOrDefault(t.LocationID)
+	to := from
+	if t.ToLocationID != "" {
+		to = t.ToLocationID
+	}
+	var legs []stockLeg
+	if t.From != "" {
+		legs = append(legs, stockLeg{State: t.From, LocationID: from, Delta: -t.Quantity})
+	}
+	if t.To != "" {
+		legs = append(legs, stockLeg{State: t.To, LocationID: to, Delta: t.Quantity})
+	}
+	return legs
+}
+
+// TransitionStockTx thực hiện t trong transaction tx và ghi một movement cho mỗi vế. Hàng chỉ được lấy từ
+// phần đang có của trạng thái nguồn (với on-hand là available-to-promise), bất kể oversell policy.
+// Vế on-hand đi qua AdjustStockTx nên cảnh báo tồn kho được cập nhật như mọi thay đổi khác. Các lô bị trừ
+// ở trạng thái nguồn (theo lot allocation policy của item) được ghi lại ở trạng thái đích với cùng số lô
+// và hạn dùng; hàng bị loại khỏi tồn kho (To rỗng) thì mất lô theo.
+func (r *InventoryRepository) TransitionStockTx(ctx context.Context, tx *sql.Tx, t StockTransition) (StockResult, error) {
+	reason := reasonOrDefault(t.Reason, model.MovementReasonStateTransition)
+
+	var (
+		result     StockResult
+		serialized bool
+		policy     model.LotAllocationPolicy
+	)
+	err := tx.QueryRowContext(ctx, "SELECT quantity, version, serialized, lot_allocation_policy FROM inventory WHERE id = $1 FOR UPDATE", t.ItemID).
+		Scan(&result.Total, &result.Version, &serialized, &policy)
+	if err == sql.ErrNoRows {
+		return StockResult{}, ErrInventoryNotFound
+	}
+	if err != nil {
+		return StockResult{}, err
+	}
+	if t.ExpectedVersion != 0 && t.ExpectedVersion != result.Version {
+		return StockResult{}, &VersionMismatchError{Expected: t.ExpectedVersion, Current: result.Version}
+	}
+	// Trạng thái của hàng serialized đi theo từng serial.
+	if serialized {
+		return StockResult{}, ErrSerializedItem
+	}
+
+	legs := t.legs()
+	if t.From != "" {
+		from := legs[0]
+		available, err := stateAvailableTx(ctx, tx, t.ItemID, from.LocationID, from.State)
+		if err != nil {
+			return StockResult{}, err
+		}
+		if available < t.Quantity {
+			return StockResult{}, &InsufficientStockError{
+				ItemID:     t.ItemID,
+				LocationID: from.LocationID,
+				Available:  available,
+				Requested:  t.Quantity,
+			}
+		}
+	}
+
+	// Vế nguồn (nếu có) đứng trước nên lots là các lô đã bị trừ khi tới vế đích.
+	var (
+		lots   []LotAllocation
+		onHand bool
+	)
+	for _, leg := range legs {
+		if leg.State == model.StockStateOnHand {
+			onHand = true
+			legResult, err := r.AdjustStockTx(ctx, tx, StockChange{
+				ItemID:        t.ItemID,
+				LocationID:    leg.LocationID,
+				Delta:         leg.Delta,
+				Reason:        reason,
+				Source:        t.Source,
+				CorrelationID: t.CorrelationID,
+			})
+			if err != nil {
+				return StockResult{}, err
+			}
+			result.Total, result.Balance, result.Version = legResult.Total, legResult.Balance, legResult.Version
+			if leg.Delta < 0 {
+				lots = legResult.Lots
+			}
+		} else {
+			if leg.Delta < 0 {
+				if lots, err = allocateLotsTx(ctx, tx, t.ItemID, leg.LocationID, leg.State, -leg.Delta, policy); err != nil {
+					return StockResult{}, err
+				}
+			}
+			err := adjustStockStateTx(ctx, tx, &model.StockMovement{
+				ItemID:        t.ItemID,
+				LocationID:    leg.LocationID,
+				StockState:    leg.State,
+				Delta:         leg.Delta,
+				Reason:        reason,
+				Source:        t.Source,
+				CorrelationID: t.CorrelationID,
+			})
+			if err != nil {
+				return StockResult{}, err
+			}
+		}
+		if leg.Delta > 0 {
+			if err := receiveLotsTx(ctx, tx, t.ItemID, leg.LocationID, leg.State, lots); err != nil {
+				return StockResult{}, err
+			}
+		}
+	}
+	if !onHand {
+		err = tx.QueryRowContext(ctx,
+			"UPDATE inventory SET version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING version",
+			t.ItemID).Scan(&result.Version)
+		if err != nil {
+			return StockResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// OnHandChanges trả về các thay đổi on-hand của t, dùng để ghi event.
+func (t StockTransition) OnHandChanges() []StockChange {
+	var changes []StockChange
+	for _, leg := range t.legs() {
+		if leg.State == model.StockStateOnHand {
+			changes = append(changes, StockChange{
+				ItemID:        t.ItemID,
+				LocationID:    leg.LocationID,
+				Delta:         leg.Delta,
+				Reason:        reasonOrDefault(t.Reason, model.MovementReasonStateTransition),
+				Source:        t.Source,
+				CorrelationID: t.CorrelationID,
+			})
+		}
+	}
+	return changes
+}
+
+// stateAvailableTx trả về số lượng có thể lấy ra khỏi state của item tại location.
+func stateAvailableTx(ctx context.Context, tx *sql.Tx, itemID, locationID string, state model.StockState) (int, error) {
+	var available int
+	err := tx.QueryRowContext(ctx,
+		"SELECT "+stockStateColumn(state)+" FROM inventory_locations WHERE item_id = $1 AND location_id = $2",
+		itemID, locationID).Scan(&available)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if state == model.StockStateOnHand {
+		reserved, err := reservedTx(ctx, tx, itemID, locationID)
+		if err != nil {
+			return 0, err
+		}
+		available -= reserved
+	}
+	return available, nil
+}
+
+// adjustStockStateTx cộng m.Delta vào trạng thái m.StockState (khác on-hand) của item tại location, điền
+// Balance/TotalBalance và ghi m vào sổ movement. Location mới nhận chính sách mặc định của item.
+// Dòng inventory của item phải đã được lock trong tx.
+func adjustStockStateTx(ctx context.Context, tx *sql.Tx, m *model.StockMovement) error {
+	col := stockStateColumn(m.StockState)
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO inventory_locations (item_id, location_id, quantity, oversell_policy, backorder_limit, `+col+`)
+		SELECT id, $2, 0, oversell_policy, backorder_limit, $3 FROM inventory WHERE id = $1
+		ON CONFLICT (item_id, location_id)
+		DO UPDATE SET `+col+` = inventory_locations.`+col+` + EXCLUDED.`+col+`, updated_at = NOW()
+		RETURNING `+col,
+		m.ItemID, m.LocationID, m.Delta).Scan(&m.Balance)
+	if err == sql.ErrNoRows {
+		return ErrInventoryNotFound
+	}
+	if err != nil {
+		return mapPQError(err)
+	}
+	err = tx.QueryRowContext(ctx,
+		"SELECT COALESCE(SUM("+col+"), 0) FROM inventory_locations WHERE item_id = $1",
+		m.ItemID).Scan(&m.TotalBalance)
+	if err != nil {
+		return err
+	}
+	return insertMovementTx(ctx, tx, m)
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+// expectStockStateLeg mong đợi adjustStockStateTx cộng delta vào cột state của sku-1 tại wh-1.
+func expectStockStateLeg(mock sqlmock.Sqlmock, state string, delta, balance int) {
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+		WithArgs("sku-1", "wh-1", delta).
+		WillReturnRows(sqlmock.NewRows([]string{state}).AddRow(balance))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(" + state + "), 0) FROM inventory_locations")).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(balance))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+		WithArgs("sku-1", "wh-1", model.StockState(state), delta, balance, balance,
+			model.MovementReasonStateTransition, model.MovementSourceHTTP, "", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+}
+
+func TestInventoryRepositoryTransitionStockTx(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition StockTransition
+		serialized bool
+		expect     func(mock sqlmock.Sqlmock)
+		wantErr    error
+	}{
+		{
+			name:       "serialized items move by serial",
+			transition: StockTransition{From: model.StockStateOnHand, To: model.StockStateDamaged, Quantity: 1},
+			serialized: true,
+			wantErr:    ErrSerializedItem,
+		},
+		{
+			name:       "stale version",
+			transition: StockTransition{From: model.StockStateOnHand, To: model.StockStateDamaged, Quantity: 1, ExpectedVersion: 1},
+			wantErr:    ErrVersionMismatch,
+		},
+		{
+			name:       "reserved stock cannot leave on-hand",
+			transition: StockTransition{From: model.StockStateOnHand, To: model.StockStateQuarantined, Quantity: 3},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity FROM inventory_locations")).
+					WithArgs("sku-1", "wh-1").
+					WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(4))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations")).
+					WithArgs("sku-1", "wh-1", model.ReservationPending).
+					WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(2))
+			},
+			wantErr: ErrInsufficientStock,
+		},
+		{
+			name:       "quarantined to damaged carries the lots along",
+			transition: StockTransition{From: model.StockStateQuarantined, To: model.StockStateDamaged, Quantity: 2},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT quarantined FROM inventory_locations")).
+					WillReturnRows(sqlmock.NewRows([]string{"quarantined"}).AddRow(5))
+				expires := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+				mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_lots")).
+					WithArgs("sku-1", "wh-1", model.StockStateQuarantined).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "lot_number", "manufactured_at", "expires_at", "quantity"}).
+						AddRow(7, "L1", nil, expires, 4))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE inventory_lots SET quantity = quantity - $2")).
+					WithArgs(int64(7), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectStockStateLeg(mock, "quarantined", -2, 3)
+				expectStockStateLeg(mock, "damaged", 2, 2)
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_lots")).
+					WithArgs("sku-1", "wh-1", model.StockStateDamaged, "L1", nil, &expires, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"manufactured_at", "expires_at"}).AddRow(nil, expires))
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET version = version + 1")).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, version, serialized, lot_allocation_policy FROM inventory WHERE id = $1 FOR UPDATE")).
+				WithArgs("sku-1").
+				WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "serialized", "lot_allocation_policy"}).
+					AddRow(10, 2, tt.serialized, model.LotAllocationFEFO))
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			transition := tt.transition
+			transition.ItemID, transition.LocationID, transition.Source = "sku-1", "wh-1", model.MovementSourceHTTP
+			result, err := NewInventoryRepository(db, StockAlertConfig{}).TransitionStockTx(context.Background(), tx, transition)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransitionStockTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (result.Total != 10 || result.Version != 3) {
+				t.Errorf("result = %+v, want on-hand 10 at version 3", result)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestStockTransitionLegs(t *testing.T) {
+	tests := []struct {
+		name string
+		t    StockTransition
+		want []stockLeg
+	}{
+		{
+			name: "state change at one location",
+			t:    StockTransition{LocationID: "wh-1", From: model.StockStateOnHand, To: model.StockStateQuarantined, Quantity: 3},
+			want: []stockLeg{
+				{State: model.StockStateOnHand, LocationID: "wh-1", Delta: -3},
+				{State: model.StockStateQuarantined, LocationID: "wh-1", Delta: 3},
+			},
+		},
+		{
+			name: "ship to another location",
+			t:    StockTransition{LocationID: "wh-1", ToLocationID: "wh-2", From: model.StockStateOnHand, To: model.StockStateInTransit, Quantity: 5},
+			want: []stockLeg{
+				{State: model.StockStateOnHand, LocationID: "wh-1", Delta: -5},
+				{State: model.StockStateInTransit, LocationID: "wh-2", Delta: 5},
+			},
+		},
+		{
+			name: "empty location is the default location",
+			t:    StockTransition{From: model.StockStateQuarantined, To: model.StockStateOnHand, Quantity: 2},
+			want: []stockLeg{
+				{State: model.StockStateQuarantined, LocationID: model.DefaultLocationID, Delta: -2},
+				{State: model.StockStateOnHand, LocationID: model.DefaultLocationID, Delta: 2},
+			},
+		},
+		{
+			name: "incoming stock has no source leg",
+			t:    StockTransition{LocationID: "wh-1", To: model.StockStateInTransit, Quantity: 4},
+			want: []stockLeg{{State: model.StockStateInTransit, LocationID: "wh-1", Delta: 4}},
+		},
+		{
+			name: "written off stock has no target leg",
+			t:    StockTransition{LocationID: "wh-2", ToLocationID: "wh-3", From: model.StockStateDamaged, Quantity: 1},
+			want: []stockLeg{{State: model.StockStateDamaged, LocationID: "wh-2", Delta: -1}},
+		},
+		{
+			name: "no states",
+			t:    StockTransition{LocationID: "wh-1", Quantity: 1},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.legs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("legs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
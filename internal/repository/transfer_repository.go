@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+// Giới hạn kích thước trang của danh sách lệnh chuyển kho.
+const (
+	DefaultTransferPageSize = 50
+	MaxTransferPageSize     = 500
+)
+
+type TransferRepository struct {
+	db *sql.DB
+}
+
+func NewTransferRepository(db *sql.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+const transferColumns = `id, source_location_id, destination_location_id, status, note, created_at, updated_at, shipped_at, closed_at`
+
+func scanTransfer(row interface{ Scan(dest ...any) error }) (*model.TransferOrder, error) {
+	t := &model.TransferOrder{}
+	var shippedAt, closedAt sql.NullTime
+	err := row.Scan(&t.ID, &t.SourceLocationID, &t.DestinationLocationID, &t.Status, &t.Note,
+		&t.CreatedAt, &t.UpdatedAt, &shippedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+	if shippedAt.Valid {
+		t.ShippedAt = &shippedAt.Time
+	}
+	if closedAt.Valid {
+		t.ClosedAt = &closedAt.Time
+	}
+	return t, nil
+}
+
+// InsertTransferTx tạo lệnh chuyển kho cùng các dòng của nó trong transaction tx.
+func (r *TransferRepository) InsertTransferTx(ctx context.Context, tx *sql.Tx, t *model.TransferOrder) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO transfer_orders (id, source_location_id, destination_location_id, status, note)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at`,
+		t.ID, t.SourceLocationID, t.DestinationLocationID, t.Status, t.Note).Scan(&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return mapPQError(err)
+	}
+	for _, line := range t.Lines {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO transfer_order_lines (transfer_id, item_id, quantity) VALUES ($1, $2, $3)",
+			t.ID, line.ItemID, line.Quantity)
+		if err != nil {
+			return mapPQError(err)
+		}
+	}
+	return nil
+}
+
+// LockTransferTx đọc và lock lệnh chuyển kho trong transaction tx.
+func (r *TransferRepository) LockTransferTx(ctx context.Context, tx *sql.Tx, id string) (*model.TransferOrder, error) {
+	t, err := scanTransfer(tx.QueryRowContext(ctx, "SELECT "+transferColumns+" FROM transfer_orders WHERE id = $1 FOR UPDATE", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines, err := transferLines(ctx, tx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	t.Lines = lines[id]
+	return t, nil
+}
+
+// UpdateTransferTx ghi trạng thái, thời điểm xuất/đóng và số lượng đã nhận của các dòng trong transaction tx.
+func (r *TransferRepository) UpdateTransferTx(ctx context.Context, tx *sql.Tx, t *model.TransferOrder) error {
+	err := tx.QueryRowContext(ctx, `
+		UPDATE transfer_orders SET status = $2, shipped_at = $3, closed_at = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		t.ID, t.Status, t.ShippedAt, t.ClosedAt).Scan(&t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrTransferNotFound
+	}
+	if err != nil {
+		return err
+	}
+	for _, line := range t.Lines {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE transfer_order_lines SET received_quantity = $3, discrepancy = $4
+			WHERE transfer_id = $1 AND item_id = $2`,
+			t.ID, line.ItemID, line.ReceivedQuantity, line.Discrepancy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTransfer trả về lệnh chuyển kho theo ID.
+func (r *TransferRepository) GetTransfer(ctx context.Context, id string) (*model.TransferOrder, error) {
+	t, err := scanTransfer(r.db.QueryRowContext(ctx, "SELECT "+transferColumns+" FROM transfer_orders WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines, err := transferLines(ctx, r.db, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	t.Lines = lines[id]
+	return t, nil
+}
+
+// TransferListOptions là bộ lọc và phân trang của ListTransfers.
+type TransferListOptions struct {
+	Status     model.TransferStatus // rỗng = mọi trạng thái
+	LocationID string               // rỗng = mọi location; khác rỗng = lệnh có nguồn hoặc đích là location này
+	Cursor     string
+	PageSize   int
+}
+
+// ListTransfers trả về các lệnh chuyển kho, mới nhất trước, phân trang theo keyset (created_at, id).
+func (r *TransferRepository) ListTransfers(ctx context.Context, opts TransferListOptions) ([]*model.TransferOrder, string, error) {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = DefaultTransferPageSize
+	}
+	if limit > MaxTransferPageSize {
+		limit = MaxTransferPageSize
+	}
+	var (
+		before   *time.Time
+		beforeID string
+	)
+	if opts.Cursor != "" {
+		createdAt, id, err := decodeCreatedAtCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		before, beforeID = &createdAt, id
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+transferColumns+`
+		FROM transfer_orders
+		WHERE ($1::VARCHAR = '' OR status = $1::VARCHAR)
+		  AND ($2::VARCHAR = '' OR source_location_id = $2::VARCHAR OR destination_location_id = $2::VARCHAR)
+		  AND ($3::TIMESTAMP IS NULL OR (created_at, id) < ($3::TIMESTAMP, $4::VARCHAR))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5`,
+		opts.Status, opts.LocationID, before, beforeID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	result := []*model.TransferOrder{}
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(result) > limit {
+		result = result[:limit]
+		last := result[limit-1]
+		next = encodeCreatedAtCursor(last.CreatedAt, last.ID)
+	}
+
+	ids := make([]string, 0, len(result))
+	for _, t := range result {
+		ids = append(ids, t.ID)
+	}
+	lines, err := transferLines(ctx, r.db, ids)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, t := range result {
+		t.Lines = lines[t.ID]
+	}
+	return result, next, nil
+}
+
+// transferLines đọc các dòng của các lệnh chuyển kho, theo item_id trong từng lệnh.
+func transferLines(ctx context.Context, q queryer, ids []string) (map[string][]model.TransferLine, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT transfer_id, item_id, quantity, received_quantity, discrepancy
+		FROM transfer_order_lines
+		WHERE transfer_id = ANY($1)
+		ORDER BY transfer_id, item_id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make(map[string][]model.TransferLine, len(ids))
+	for rows.Next() {
+		var (
+			id   string
+			line model.TransferLine
+		)
+		if err := rows.Scan(&id, &line.ItemID, &line.Quantity, &line.ReceivedQuantity, &line.Discrepancy); err != nil {
+			return nil, err
+		}
+		result[id] = append(result[id], line)
+	}
+	return result, rows.Err()
+}
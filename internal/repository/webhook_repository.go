@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-service.com/m/internal/model"
+)
+
+const (
+	DefaultWebhookDeliveryPageSize = 50
+	MaxWebhookDeliveryPageSize     = 500
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Secret không nằm trong các cột đọc ra để không lộ qua API.
+const webhookSubscriptionColumns = `id, url, event_types, active, created_at, updated_at`
+
+func scanWebhookSubscription(row interface{ Scan(dest ...any) error }) (*model.WebhookSubscription, error) {
+	sub := &model.WebhookSubscription{}
+	var eventTypes []string
+	if err := row.Scan(&sub.ID, &sub.URL, pq.Array(&eventTypes), &sub.Active, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sub.EventTypes = make([]model.InventoryEventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = model.InventoryEventType(t)
+	}
+	return sub, nil
+}
+
+func eventTypeStrings(types []model.InventoryEventType) []string {
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result
+}
+
+// CreateWebhookSubscription lưu subscription mới; sub.ID và sub.Secret do caller sinh.
+func (r *WebhookRepository) CreateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (id, url, event_types, secret, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at, updated_at`,
+		sub.ID, sub.URL, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Secret, sub.Active).Scan(&sub.CreatedAt, &sub.UpdatedAt)
+}
+
+// GetWebhookSubscription trả về subscription theo ID (không kèm secret).
+func (r *WebhookRepository) GetWebhookSubscription(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	sub, err := scanWebhookSubscription(r.db.QueryRowContext(ctx,
+		"SELECT "+webhookSubscriptionColumns+" FROM webhook_subscriptions WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	return sub, err
+}
+
+// ListWebhookSubscriptions trả về mọi subscription, cũ nhất trước.
+func (r *WebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+webhookSubscriptionColumns+" FROM webhook_subscriptions ORDER BY created_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*model.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sub)
+	}
+	return result, rows.Err()
+}
+
+// UpdateWebhookSubscription cập nhật URL, loại event và trạng thái active; secret chỉ được đổi nếu sub.Secret khác rỗng.
+func (r *WebhookRepository) UpdateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, active = $4,
+			secret = CASE WHEN $5::TEXT = '' THEN secret ELSE $5::TEXT END, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at`,
+		sub.ID, sub.URL, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Active, sub.Secret).Scan(&sub.CreatedAt, &sub.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrWebhookSubscriptionNotFound
+	}
+	return err
+}
+
+// DeleteWebhookSubscription xoá subscription cùng nhật ký gửi của nó.
+func (r *WebhookRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveriesTx tạo delivery cho event tới mọi subscription active nhận loại event này,
+// trong cùng transaction tx với thay đổi tồn kho sinh ra event.
+func EnqueueWebhookDeliveriesTx(ctx context.Context, tx *sql.Tx, eventID string, eventType model.InventoryEventType, payload []byte) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3
+		FROM webhook_subscriptions
+		WHERE active AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))`,
+		eventID, string(eventType), payload)
+	return err
+}
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.updated_at, d.delivered_at`
+
+func scanWebhookDelivery(row interface{ Scan(dest ...any) error }, extra ...any) (*model.WebhookDelivery, error) {
+	d := &model.WebhookDelivery{}
+	var (
+		payload     []byte
+		statusCode  sql.NullInt64
+		deliveredAt sql.NullTime
+	)
+	dest := append([]any{&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &statusCode, &d.LastError, &d.CreatedAt, &d.UpdatedAt, &deliveredAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	if statusCode.Valid {
+		code := int(statusCode.Int64)
+		d.LastStatusCode = &code
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return d, nil
+}
+
+// GetWebhookDelivery trả về delivery theo ID, thuộc subscription subscriptionID.
+func (r *WebhookRepository) GetWebhookDelivery(ctx context.Context, subscriptionID string, id int64) (*model.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.db.QueryRowContext(ctx,
+		"SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries d WHERE d.id = $1 AND d.subscription_id = $2",
+		id, subscriptionID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	return d, err
+}
+
+// ListWebhookDeliveries trả về nhật ký gửi của subscription, mới nhất trước, lọc theo status (rỗng = tất cả).
+func (r *WebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID, status, cursor string, limit int) ([]*model.WebhookDelivery, string, error) {
+	var afterID int64
+	if cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, "", ErrInvalidPageToken
+		}
+		afterID = id
+	}
+	if limit <= 0 {
+		limit = DefaultWebhookDeliveryPageSize
+	}
+	if limit > MaxWebhookDeliveryPageSize {
+		limit = MaxWebhookDeliveryPageSize
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.subscription_id = $1
+		  AND ($2::VARCHAR = '' OR d.status = $2::VARCHAR)
+		  AND ($3::BIGINT = 0 OR d.id < $3::BIGINT)
+		ORDER BY d.id DESC
+		LIMIT $4`,
+		subscriptionID, status, afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	result := []*model.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(result) > limit {
+		result = result[:limit]
+		next = strconv.FormatInt(result[limit-1].ID, 10)
+	}
+	return result, next, nil
+}
+
+// RedeliverWebhookDelivery đặt lại delivery về pending để worker gửi lại ngay, với số lần thử đếm lại từ đầu.
+func (r *WebhookRepository) RedeliverWebhookDelivery(ctx context.Context, subscriptionID string, id int64) (*model.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries d
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE d.id = $1 AND d.subscription_id = $2
+		RETURNING `+webhookDeliveryColumns,
+		id, subscriptionID))
+	if err == sql.ErrNoRows {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	return d, err
+}
+
+// WebhookAttempt là kết quả một lần gửi webhook; StatusCode = 0 nếu không nhận được response.
+type WebhookAttempt struct {
+	StatusCode int
+	Err        error
+}
+
+// ProcessDueDeliveries lấy tối đa limit delivery pending đã tới hạn, gọi deliver rồi ghi kết quả.
+// Các dòng được claim bằng một câu lệnh ngắn (SKIP LOCKED) đặt next_attempt_at = NOW() + lease nên
+// nhiều instance có thể gửi song song mà không gửi trùng, và không có lock nào bị giữ trong lúc gọi HTTP.
+// Nếu instance chết giữa chừng, delivery tự tới hạn lại khi lease hết. lease phải lớn hơn thời gian deliver.
+// Delivery thất bại được thử lại sau backoff(attempts); sau maxAttempts lần chuyển sang failed.
+// deliver trả về kết quả theo từng delivery. Trả về số delivery đã lấy.
+func (r *WebhookRepository) ProcessDueDeliveries(ctx context.Context, limit, maxAttempts int, lease time.Duration,
+	backoff func(attempts int) time.Duration,
+	deliver func(ctx context.Context, deliveries []*model.WebhookDelivery) []WebhookAttempt) (int, error) {
+	deliveries, err := r.claimDueDeliveries(ctx, limit, lease)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	results := deliver(ctx, deliveries)
+
+	return len(deliveries), r.recordDeliveryAttempts(ctx, deliveries, results, maxAttempts, backoff)
+}
+
+// claimDueDeliveries đẩy next_attempt_at của các delivery tới hạn ra sau lease và trả về chúng.
+// next_attempt_at trả về là dấu claim: recordDeliveryAttempts chỉ ghi đè khi nó chưa đổi.
+// Delivery của subscription đã tắt không được gửi và giữ nguyên pending cho tới khi subscription được bật lại.
+func (r *WebhookRepository) claimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2), updated_at = NOW()
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND s.active AND d.id IN (
+			SELECT pd.id FROM webhook_deliveries pd
+			JOIN webhook_subscriptions ps ON ps.id = pd.subscription_id
+			WHERE pd.status = 'pending' AND pd.next_attempt_at <= NOW() AND ps.active
+			ORDER BY pd.next_attempt_at, pd.id
+			LIMIT $1
+			FOR UPDATE OF pd SKIP LOCKED)
+		RETURNING `+webhookDeliveryColumns+`, s.url, s.secret`, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		var url, secret string
+		d, err := scanWebhookDelivery(rows, &url, &secret)
+		if err != nil {
+			return nil, err
+		}
+		d.URL, d.Secret = url, secret
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// recordDeliveryAttempts ghi kết quả gửi trong một transaction. Delivery đã bị thay đổi kể từ lúc claim
+// (ví dụ được redeliver) thì bỏ qua để không ghi đè trạng thái mới.
+func (r *WebhookRepository) recordDeliveryAttempts(ctx context.Context, deliveries []*model.WebhookDelivery, results []WebhookAttempt,
+	maxAttempts int, backoff func(attempts int) time.Duration) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, d := range deliveries {
+		res := results[i]
+		var statusCode sql.NullInt64
+		if res.StatusCode != 0 {
+			statusCode = sql.NullInt64{Int64: int64(res.StatusCode), Valid: true}
+		}
+		if res.Err == nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE webhook_deliveries
+				SET status = 'delivered', attempts = attempts + 1, last_status_code = $3, last_error = '',
+					delivered_at = NOW(), updated_at = NOW()
+				WHERE id = $1 AND status = 'pending' AND next_attempt_at = $2`,
+				d.ID, d.NextAttemptAt, statusCode)
+		} else {
+			status := model.WebhookDeliveryPending
+			if d.Attempts+1 >= maxAttempts {
+				status = model.WebhookDeliveryFailed
+			}
+			_, err = tx.ExecContext(ctx, `
+				UPDATE webhook_deliveries
+				SET status = $3, attempts = attempts + 1, last_status_code = $4, last_error = $5,
+					next_attempt_at = NOW() + make_interval(secs => $6), updated_at = NOW()
+				WHERE id = $1 AND status = 'pending' AND next_attempt_at = $2`,
+				d.ID, d.NextAttemptAt, status, statusCode, res.Err.Error(), backoff(d.Attempts+1).Seconds())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
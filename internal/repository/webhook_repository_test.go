@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+)
+
+var webhookDeliveryTestColumns = []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts",
+	"next_attempt_at", "last_status_code", "last_error", "created_at", "updated_at", "delivered_at", "url", "secret"}
+
+func TestWebhookRepositoryProcessDueDeliveries(t *testing.T) {
+	claimedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	backoff := func(attempts int) time.Duration { return time.Duration(attempts) * time.Minute }
+	tests := []struct {
+		name        string
+		attempts    int // số lần đã gửi trước lần này
+		result      WebhookAttempt
+		wantStatus  model.WebhookDeliveryStatus
+		wantBackoff float64
+	}{
+		{name: "delivered", result: WebhookAttempt{StatusCode: 204}, wantStatus: model.WebhookDeliveryDelivered},
+		{name: "failure is retried after backoff", attempts: 1, result: WebhookAttempt{StatusCode: 500, Err: errors.New("status 500")},
+			wantStatus: model.WebhookDeliveryPending, wantBackoff: 120},
+		{name: "last attempt fails the delivery", attempts: 4, result: WebhookAttempt{Err: errors.New("timeout")},
+			wantStatus: model.WebhookDeliveryFailed, wantBackoff: 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			// Chỉ claim delivery của subscription còn active.
+			mock.ExpectQuery(`WHERE s\.id = d\.subscription_id AND s\.active AND d\.id IN \(.*AND ps\.active`).
+				WithArgs(10, float64(60)).
+				WillReturnRows(sqlmock.NewRows(webhookDeliveryTestColumns).
+					AddRow(7, "sub-1", "evt-1", "update", []byte(`{}`), "pending", tt.attempts,
+						claimedAt, nil, "", claimedAt, claimedAt, nil, "https://example.com/hook", "secret"))
+			mock.ExpectBegin()
+			if tt.wantStatus == model.WebhookDeliveryDelivered {
+				mock.ExpectExec(regexp.QuoteMeta("SET status = 'delivered'")).
+					WithArgs(int64(7), claimedAt, int64(tt.result.StatusCode)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta("SET status = $3")).
+					WithArgs(int64(7), claimedAt, tt.wantStatus, sqlmock.AnyArg(), tt.result.Err.Error(), tt.wantBackoff).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectCommit()
+
+			var delivered []*model.WebhookDelivery
+			n, err := NewWebhookRepository(db).ProcessDueDeliveries(context.Background(), 10, 5, time.Minute, backoff,
+				func(_ context.Context, deliveries []*model.WebhookDelivery) []WebhookAttempt {
+					delivered = deliveries
+					return []WebhookAttempt{tt.result}
+				})
+			if err != nil || n != 1 {
+				t.Fatalf("ProcessDueDeliveries() = %d, %v", n, err)
+			}
+			if d := delivered[0]; d.URL != "https://example.com/hook" || d.Secret != "secret" {
+				t.Errorf("delivery target = %q/%q, want the subscription url and secret", d.URL, d.Secret)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
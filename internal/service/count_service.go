@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// MaxCountSessionItems là số item tối đa của một phiên kiểm kê.
+const MaxCountSessionItems = 1000
+
+// ErrInvalidCount được trả về khi phiên kiểm kê hoặc số lượng đếm không hợp lệ.
+var ErrInvalidCount = errors.New("invalid count")
+
+// CountService quản lý phiên kiểm kê: open → (submit) → posted, hoặc pending_approval → (approve) → posted
+// khi có chênh lệch vượt ngưỡng; phiên chưa post có thể bị cancelled. Khi post, mỗi chênh lệch được ghi thành
+// movement cycle_count cộng vào tồn kho hiện tại, không ghi đè quantity.
+type CountService struct {
+	repo             *repository.CountRepository
+	inventory        *InventoryService
+	thresholdPercent int
+}
+
+// NewCountService tạo service; chênh lệch vượt thresholdPercent% số lượng kỳ vọng cần được duyệt.
+func NewCountService(repo *repository.CountRepository, inventory *InventoryService, thresholdPercent int) *CountService {
+	return &CountService{repo: repo, inventory: inventory, thresholdPercent: max(thresholdPercent, 0)}
+}
+
+// CountSessionInput là nội dung của phiên kiểm kê mới.
+type CountSessionInput struct {
+	LocationID string // rỗng = location mặc định
+	Note       string
+	ItemIDs    []string
+}
+
+// CreateCountSessionTx tạo phiên kiểm kê ở trạng thái open trong transaction tx, chụp on-hand hiện tại của các
+// item tại location làm số lượng kỳ vọng. Item serialized không được kiểm kê qua phiên.
+func (s *CountService) CreateCountSessionTx(ctx context.Context, tx *sql.Tx, in CountSessionInput) (*model.CountSession, error) {
+	if len(in.ItemIDs) == 0 || len(in.ItemIDs) > MaxCountSessionItems {
+		return nil, fmt.Errorf("%w: cần từ 1 đến %d item", ErrInvalidCount, MaxCountSessionItems)
+	}
+	seen := make(map[string]struct{}, len(in.ItemIDs))
+	itemIDs := make([]string, 0, len(in.ItemIDs))
+	for _, itemID := range in.ItemIDs {
+		if strings.TrimSpace(itemID) == "" {
+			return nil, fmt.Errorf("%w: item_id không được rỗng", ErrInvalidCount)
+		}
+		if _, dup := seen[itemID]; dup {
+			return nil, fmt.Errorf("%w: item %s bị lặp", ErrInvalidCount, itemID)
+		}
+		seen[itemID] = struct{}{}
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+
+	session := &model.CountSession{
+		ID:         idUtils.NewID(),
+		LocationID: locationOrDefault(in.LocationID),
+		Status:     model.CountSessionOpen,
+		Note:       in.Note,
+	}
+	if err := s.inventory.repo.LockItemsTx(ctx, tx, itemIDs); err != nil {
+		return nil, err
+	}
+	if err := s.repo.InsertCountSessionTx(ctx, tx, session, itemIDs); err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	return session, nil
+}
+
+// RecordCountsTx ghi số lượng đếm được của các item trong phiên open; đếm lại một item ghi đè số trước đó.
+func (s *CountService) RecordCountsTx(ctx context.Context, tx *sql.Tx, id string, entries []model.CountEntry) (*model.CountSession, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: cần ít nhất một dòng số lượng đếm", ErrInvalidCount)
+	}
+	session, err := s.lockSessionTx(ctx, tx, id, "record counts", model.CountSessionOpen)
+	if err != nil {
+		return nil, err
+	}
+	lineIndex := make(map[string]int, len(session.Lines))
+	for i, line := range session.Lines {
+		lineIndex[line.ItemID] = i
+	}
+	seen := make(map[string]struct{}, len(entries))
+	now := time.Now()
+	for _, e := range entries {
+		i, ok := lineIndex[e.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: item %s không có trong phiên", ErrInvalidCount, e.ItemID)
+		}
+		if e.CountedQuantity < 0 {
+			return nil, fmt.Errorf("%w: counted_quantity không được âm", ErrInvalidCount)
+		}
+		if _, dup := seen[e.ItemID]; dup {
+			return nil, fmt.Errorf("%w: item %s bị lặp", ErrInvalidCount, e.ItemID)
+		}
+		seen[e.ItemID] = struct{}{}
+		counted := e.CountedQuantity
+		session.Lines[i].CountedQuantity, session.Lines[i].CountedAt = &counted, &now
+	}
+	if err := s.repo.UpdateCountSessionTx(ctx, tx, session); err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	return session, nil
+}
+
+// SubmitCountSessionTx kết thúc việc đếm trong transaction tx. Nếu không dòng nào có chênh lệch vượt ngưỡng,
+// phiên được post ngay; ngược lại phiên chuyển sang pending_approval và tồn kho chưa thay đổi.
+// Caller commit tx rồi gọi InvalidateCache.
+func (s *CountService) SubmitCountSessionTx(ctx context.Context, tx *sql.Tx, id string, source model.MovementSource) (*model.CountSession, error) {
+	session, err := s.lockSessionTx(ctx, tx, id, "submit", model.CountSessionOpen)
+	if err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	counted, needsApproval := false, false
+	for _, line := range session.Lines {
+		counted = counted || line.CountedQuantity != nil
+		needsApproval = needsApproval || line.RequiresApproval
+	}
+	if !counted {
+		return nil, fmt.Errorf("%w: phiên chưa có item nào được đếm", ErrInvalidCount)
+	}
+	now := time.Now()
+	session.SubmittedAt = &now
+	if needsApproval {
+		session.Status = model.CountSessionPendingApproval
+		if err := s.repo.UpdateCountSessionTx(ctx, tx, session); err != nil {
+			return nil, err
+		}
+		return session, nil
+	}
+	return session, s.postTx(ctx, tx, session, source)
+}
+
+// ApproveCountSessionTx duyệt phiên pending_approval và post các chênh lệch trong transaction tx.
+// Caller commit tx rồi gọi InvalidateCache.
+func (s *CountService) ApproveCountSessionTx(ctx context.Context, tx *sql.Tx, id, approvedBy string, source model.MovementSource) (*model.CountSession, error) {
+	if strings.TrimSpace(approvedBy) == "" {
+		return nil, fmt.Errorf("%w: approved_by là bắt buộc", ErrInvalidCount)
+	}
+	session, err := s.lockSessionTx(ctx, tx, id, "approve", model.CountSessionPendingApproval)
+	if err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	session.ApprovedBy = approvedBy
+	return session, s.postTx(ctx, tx, session, source)
+}
+
+// CancelCountSessionTx huỷ phiên chưa post trong transaction tx; tồn kho không thay đổi.
+func (s *CountService) CancelCountSessionTx(ctx context.Context, tx *sql.Tx, id string) (*model.CountSession, error) {
+	session, err := s.lockSessionTx(ctx, tx, id, "cancel", model.CountSessionOpen, model.CountSessionPendingApproval)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session.Status, session.ClosedAt = model.CountSessionCancelled, &now
+	if err := s.repo.UpdateCountSessionTx(ctx, tx, session); err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	return session, nil
+}
+
+// GetCountSession trả về phiên kiểm kê theo ID.
+func (s *CountService) GetCountSession(ctx context.Context, id string) (*model.CountSession, error) {
+	session, err := s.repo.GetCountSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.annotate(session)
+	return session, nil
+}
+
+// ListCountSessions trả về các phiên kiểm kê, mới nhất trước.
+func (s *CountService) ListCountSessions(ctx context.Context, opts repository.CountSessionListOptions) ([]*model.CountSession, string, error) {
+	switch opts.Status {
+	case "", model.CountSessionOpen, model.CountSessionPendingApproval, model.CountSessionPosted, model.CountSessionCancelled:
+	default:
+		return nil, "", fmt.Errorf("%w: status %q không hợp lệ", ErrInvalidCount, opts.Status)
+	}
+	sessions, next, err := s.repo.ListCountSessions(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, session := range sessions {
+		s.annotate(session)
+	}
+	return sessions, next, nil
+}
+
+// InvalidateCache xoá cache của các item trong phiên kiểm kê.
+func (s *CountService) InvalidateCache(ctx context.Context, session *model.CountSession) {
+	for _, line := range session.Lines {
+		s.inventory.InvalidateCache(ctx, line.ItemID)
+	}
+}
+
+// ParseCountsCSV đọc số lượng đếm từ CSV hai cột item_id,counted_quantity; dòng tiêu đề là tuỳ chọn.
+func ParseCountsCSV(r io.Reader) ([]model.CountEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	var entries []model.CountEntry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: CSV không hợp lệ: %v", ErrInvalidCount, err)
+		}
+		itemID, quantityStr := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if line == 1 && strings.EqualFold(itemID, "item_id") {
+			continue
+		}
+		quantity, err := strconv.Atoi(quantityStr)
+		if err != nil || itemID == "" {
+			return nil, fmt.Errorf("%w: dòng %d của CSV không hợp lệ", ErrInvalidCount, line)
+		}
+		if len(entries) == MaxCountSessionItems {
+			return nil, fmt.Errorf("%w: CSV có quá %d dòng", ErrInvalidCount, MaxCountSessionItems)
+		}
+		entries = append(entries, model.CountEntry{ItemID: itemID, CountedQuantity: quantity})
+	}
+	return entries, nil
+}
+
+// postTx ghi chênh lệch của các dòng đã đếm thành movement cycle_count tại location của phiên, với correlation ID
+// là ID của phiên, rồi đóng phiên. Số đếm là số lượng thực tế nên không bị chặn bởi available-to-promise.
+func (s *CountService) postTx(ctx context.Context, tx *sql.Tx, session *model.CountSession, source model.MovementSource) error {
+	itemIDs := make([]string, 0, len(session.Lines))
+	for _, line := range session.Lines {
+		itemIDs = append(itemIDs, line.ItemID)
+	}
+	if err := s.inventory.repo.LockItemsTx(ctx, tx, itemIDs); err != nil {
+		return err
+	}
+	for _, line := range session.Lines {
+		if line.Variance == nil || *line.Variance == 0 {
+			continue
+		}
+		_, err := s.inventory.UpdateInventoryTx(ctx, tx, repository.StockChange{
+			ItemID:                line.ItemID,
+			LocationID:            session.LocationID,
+			Delta:                 *line.Variance,
+			Reason:                model.MovementReasonCycleCount,
+			Source:                source,
+			CorrelationID:         session.ID,
+			SkipAvailabilityCheck: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	session.Status, session.ClosedAt = model.CountSessionPosted, &now
+	return s.repo.UpdateCountSessionTx(ctx, tx, session)
+}
+
+// lockSessionTx lock phiên và kiểm tra trạng thái hiện tại thuộc allowed.
+func (s *CountService) lockSessionTx(ctx context.Context, tx *sql.Tx, id, operation string, allowed ...model.CountSessionStatus) (*model.CountSession, error) {
+	session, err := s.repo.LockCountSessionTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range allowed {
+		if session.Status == status {
+			return session, nil
+		}
+	}
+	return nil, &repository.CountSessionStateError{SessionID: id, Status: session.Status, Operation: operation}
+}
+
+// annotate tính chênh lệch của các dòng đã đếm và đánh dấu dòng vượt ngưỡng duyệt. Item có kỳ vọng 0 thì
+// mọi chênh lệch đều cần duyệt.
+func (s *CountService) annotate(session *model.CountSession) {
+	for i := range session.Lines {
+		line := &session.Lines[i]
+		line.Variance, line.RequiresApproval = nil, false
+		if line.CountedQuantity == nil {
+			continue
+		}
+		variance := *line.CountedQuantity - line.ExpectedQuantity
+		line.Variance = &variance
+		abs := max(variance, -variance)
+		line.RequiresApproval = abs*100 > s.thresholdPercent*max(line.ExpectedQuantity, 0)
+	}
+}
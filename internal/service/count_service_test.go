@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"inventory-service.com/m/internal/model"
+)
+
+func TestParseCountsCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []model.CountEntry
+		wantErr bool
+	}{
+		{
+			name:  "with header",
+			input: "item_id,counted_quantity\nsku-1,5\nsku-2,0\n",
+			want:  []model.CountEntry{{ItemID: "sku-1", CountedQuantity: 5}, {ItemID: "sku-2", CountedQuantity: 0}},
+		},
+		{
+			name:  "without header",
+			input: "sku-1,5\n",
+			want:  []model.CountEntry{{ItemID: "sku-1", CountedQuantity: 5}},
+		},
+		{
+			name:  "header is case insensitive and spaces are trimmed",
+			input: "Item_ID, Counted_Quantity\n sku-1 , 7 \n",
+			want:  []model.CountEntry{{ItemID: "sku-1", CountedQuantity: 7}},
+		},
+		{
+			name:  "empty input",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "header only on first line",
+			input: "sku-1,1\nitem_id,2\n",
+			want:  []model.CountEntry{{ItemID: "sku-1", CountedQuantity: 1}, {ItemID: "item_id", CountedQuantity: 2}},
+		},
+		{name: "non-numeric quantity", input: "sku-1,abc\n", wantErr: true},
+		{name: "empty item id", input: ",3\n", wantErr: true},
+		{name: "wrong column count", input: "sku-1,1,2\n", wantErr: true},
+		{name: "too many rows", input: strings.Repeat("sku-1,1\n", MaxCountSessionItems+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCountsCSV(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCount) {
+					t.Fatalf("ParseCountsCSV() error = %v, want ErrInvalidCount", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCountsCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCountsCSV() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountServiceAnnotate(t *testing.T) {
+	counted := func(n int) *int { return &n }
+	tests := []struct {
+		name             string
+		threshold        int
+		expected         int
+		counted          *int
+		wantVariance     *int
+		wantNeedApproval bool
+	}{
+		{"not counted", 10, 100, nil, nil, false},
+		{"exact count", 10, 100, counted(100), counted(0), false},
+		{"shortage at threshold", 10, 100, counted(90), counted(-10), false},
+		{"shortage over threshold", 10, 100, counted(89), counted(-11), true},
+		{"surplus over threshold", 10, 100, counted(111), counted(11), true},
+		{"zero expected with surplus", 10, 0, counted(1), counted(1), true},
+		{"zero expected counted zero", 10, 0, counted(0), counted(0), false},
+		{"zero threshold approves any variance", 0, 100, counted(101), counted(1), true},
+		{"negative expected treated as zero", 10, -5, counted(0), counted(5), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &CountService{thresholdPercent: tt.threshold}
+			session := &model.CountSession{Lines: []model.CountLine{{
+				ItemID:           "sku-1",
+				ExpectedQuantity: tt.expected,
+				CountedQuantity:  tt.counted,
+				// Giá trị cũ phải bị tính lại.
+				Variance:         counted(42),
+				RequiresApproval: true,
+			}}}
+			s.annotate(session)
+			line := session.Lines[0]
+			if !reflect.DeepEqual(line.Variance, tt.wantVariance) {
+				t.Errorf("Variance = %v, want %v", deref(line.Variance), deref(tt.wantVariance))
+			}
+			if line.RequiresApproval != tt.wantNeedApproval {
+				t.Errorf("RequiresApproval = %v, want %v", line.RequiresApproval, tt.wantNeedApproval)
+			}
+		})
+	}
+}
+
+func deref(p *int) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// ErrIdempotencyKeyReused được trả về khi Idempotency-Key đã được dùng cho một payload khác.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different payload")
+
+// IdempotencyService lưu và replay response của các request mutation có Idempotency-Key.
+type IdempotencyService struct {
+	repo *repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo *repository.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{repo: repo, ttl: ttl}
+}
+
+// Fingerprint trả về hash SHA-256 (hex) của payload request.
+func Fingerprint(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup trả về response đã lưu nếu request là bản lặp lại của một request trước đó,
+// nil nếu key chưa được dùng, hoặc ErrIdempotencyKeyReused nếu key đã dùng với payload khác.
+func (s *IdempotencyService) Lookup(ctx context.Context, scope, key, fingerprint string) (*model.IdempotencyRecord, error) {
+	rec, err := s.repo.GetIdempotencyRecord(ctx, scope, key)
+	if errors.Is(err, repository.ErrIdempotencyKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rec.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return rec, nil
+}
+
+// SaveTx lưu response của request trong transaction tx, giữ trong TTL đã cấu hình.
+func (s *IdempotencyService) SaveTx(ctx context.Context, tx *sql.Tx, rec *model.IdempotencyRecord) error {
+	return repository.SaveIdempotencyRecordTx(ctx, tx, rec, s.ttl)
+}
+
+// StartPurgeWorker định kỳ xoá các idempotency key hết hạn cho tới khi ctx bị hủy.
+func (s *IdempotencyService) StartPurgeWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context bị hủy, dừng idempotency purge worker")
+			return
+		case <-ticker.C:
+			n, err := s.repo.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("Lỗi dọn idempotency key: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("Đã dọn %d idempotency key", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"inventory-service.com/m/internal/repository"
+)
+
+// MaxBatchLines là số dòng tối đa của một lần BatchAdjust.
+const MaxBatchLines = 500
+
+// ErrInvalidBatch được trả về khi batch rỗng hoặc vượt quá MaxBatchLines.
+var ErrInvalidBatch = fmt.Errorf("batch must have between 1 and %d lines", MaxBatchLines)
+
+// BatchLineError là lỗi của một dòng làm hỏng cả batch ở chế độ all-or-nothing.
+type BatchLineError struct {
+	Index int // vị trí của dòng trong request
+	Err   error
+}
+
+func (e *BatchLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchLineError) Unwrap() error {
+	return e.Err
+}
+
+// BatchLineResult là kết quả của một dòng trong batch; Err khác nil nếu dòng không được áp dụng.
+type BatchLineResult struct {
+	Index  int
+	Change repository.StockChange
+	Result repository.StockResult
+	Err    error
+}
+
+// BatchAdjustTx áp dụng các thay đổi trong transaction tx, mỗi dòng ghi một event vào outbox.
+// Dòng inventory của các item được lock trước theo thứ tự ID, các dòng được áp dụng theo thứ tự
+// (item, location), nên hai batch chạy song song không thể deadlock.
+// Ở chế độ all-or-nothing (bestEffort = false), dòng lỗi đầu tiên được trả về dưới dạng BatchLineError
+// và caller phải rollback tx. Ở chế độ best-effort, mỗi dòng chạy trong một savepoint; dòng lỗi được
+// rollback riêng và ghi lỗi vào kết quả. Kết quả theo thứ tự dòng trong request.
+// Caller commit tx rồi gọi InvalidateCache cho các item.
+func (s *InventoryService) BatchAdjustTx(ctx context.Context, tx *sql.Tx, changes []repository.StockChange, bestEffort bool) ([]BatchLineResult, error) {
+	if len(changes) == 0 || len(changes) > MaxBatchLines {
+		return nil, ErrInvalidBatch
+	}
+
+	results := make([]BatchLineResult, len(changes))
+	order := make([]int, len(changes))
+	itemIDs := make([]string, 0, len(changes))
+	for i, change := range changes {
+		results[i] = BatchLineResult{Index: i, Change: change}
+		order[i] = i
+		itemIDs = append(itemIDs, change.ItemID)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ca, cb := changes[order[a]], changes[order[b]]
+		if ca.ItemID != cb.ItemID {
+			return ca.ItemID < cb.ItemID
+		}
+		return ca.LocationID < cb.LocationID
+	})
+
+	if err := s.repo.LockItemsTx(ctx, tx, itemIDs); err != nil {
+		return nil, err
+	}
+
+	for _, i := range order {
+		if !bestEffort {
+			result, err := s.UpdateInventoryTx(ctx, tx, changes[i])
+			if err != nil {
+				return nil, &BatchLineError{Index: i, Err: err}
+			}
+			results[i].Result = result
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_line"); err != nil {
+			return nil, err
+		}
+		result, err := s.UpdateInventoryTx(ctx, tx, changes[i])
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_line"); rbErr != nil {
+				return nil, errors.Join(err, rbErr)
+			}
+			results[i].Err = err
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_line"); err != nil {
+			return nil, err
+		}
+		results[i].Result = result
+	}
+	return results, nil
+}
+
+// BatchAdjust áp dụng batch trong một transaction riêng và invalidate cache các item sau khi commit.
+// Xem BatchAdjustTx.
+func (s *InventoryService) BatchAdjust(ctx context.Context, changes []repository.StockChange, bestEffort bool) ([]BatchLineResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results, err := s.BatchAdjustTx(ctx, tx, changes, bestEffort)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.InvalidateBatchCache(ctx, results)
+	return results, nil
+}
+
+// InvalidateBatchCache xoá cache của các item có dòng được áp dụng.
+func (s *InventoryService) InvalidateBatchCache(ctx context.Context, results []BatchLineResult) {
+	seen := make(map[string]bool, len(results))
+	for _, r := range results {
+		if r.Err == nil && !seen[r.Change.ItemID] {
+			seen[r.Change.ItemID] = true
+			s.InvalidateCache(ctx, r.Change.ItemID)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// expectBatchLine mong đợi một dòng batch: thành công ghi location, movement và outbox;
+// thất bại khi location chỉ còn 2 với policy deny.
+func expectBatchLine(mock sqlmock.Sqlmock, itemID string, delta int, ok bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+		WithArgs(delta, itemID, int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}).
+			AddRow(2+delta, 2, model.OversellPolicyDeny, 0, model.LotAllocationFEFO, false))
+	if delta < 0 {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, oversell_policy, backorder_limit")).
+			WithArgs(itemID, model.DefaultLocationID).
+			WillReturnRows(sqlmock.NewRows([]string{"quantity", "oversell_policy", "backorder_limit"}).
+				AddRow(2, model.OversellPolicyDeny, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations")).
+			WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(0))
+	}
+	if !ok {
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+		WithArgs(itemID, model.DefaultLocationID, delta, model.OversellPolicyDeny, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(2 + delta))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs("inventory-events", itemID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WithArgs(sqlmock.AnyArg(), model.EventTypeUpdate, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestInventoryServiceBatchAdjustTx(t *testing.T) {
+	// Dòng 1 thiếu tồn kho. Các dòng được áp dụng theo thứ tự item: 1, 2 (sku-a) rồi 0 (sku-b).
+	changes := []repository.StockChange{
+		{ItemID: "sku-b", Delta: 1},
+		{ItemID: "sku-a", Delta: -5},
+		{ItemID: "sku-a", Delta: 2},
+	}
+	tests := []struct {
+		name        string
+		changes     []repository.StockChange
+		bestEffort  bool
+		expect      func(mock sqlmock.Sqlmock)
+		wantErr     error
+		wantLine    int   // vị trí dòng trong BatchLineError, -1 = không có
+		wantFailed  []int // các dòng có Err ở chế độ best-effort
+		wantApplied int
+	}{
+		{
+			name:    "all or nothing stops at the first failing line",
+			changes: changes,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectBatchLine(mock, "sku-a", -5, false)
+			},
+			wantErr:  repository.ErrInsufficientStock,
+			wantLine: 1,
+		},
+		{
+			name:       "best effort rolls back only the failing line",
+			changes:    changes,
+			bestEffort: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectBatchLine(mock, "sku-a", -5, false)
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectBatchLine(mock, "sku-a", 2, true)
+				mock.ExpectExec("RELEASE SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectBatchLine(mock, "sku-b", 1, true)
+				mock.ExpectExec("RELEASE SAVEPOINT batch_line").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantLine:    -1,
+			wantFailed:  []int{1},
+			wantApplied: 2,
+		},
+		{
+			name:     "empty batch",
+			wantErr:  ErrInvalidBatch,
+			wantLine: -1,
+		},
+		{
+			name:     "too many lines",
+			changes:  make([]repository.StockChange, MaxBatchLines+1),
+			wantErr:  ErrInvalidBatch,
+			wantLine: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tt.expect != nil {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM inventory WHERE id = ANY($1) ORDER BY id FOR UPDATE")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("sku-a").AddRow("sku-b"))
+				tt.expect(mock)
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			svc := NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), nil, "inventory-events")
+			results, err := svc.BatchAdjustTx(context.Background(), tx, tt.changes, tt.bestEffort)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchAdjustTx() error = %v, want %v", err, tt.wantErr)
+			}
+			var lineErr *BatchLineError
+			if got := errors.As(err, &lineErr); got != (tt.wantLine >= 0) || (got && lineErr.Index != tt.wantLine) {
+				t.Errorf("BatchLineError = %v, want line %d", lineErr, tt.wantLine)
+			}
+
+			applied := 0
+			var failed []int
+			for i, r := range results {
+				if r.Index != i {
+					t.Errorf("results[%d].Index = %d, results must follow request order", i, r.Index)
+				}
+				if r.Err != nil {
+					failed = append(failed, i)
+				} else {
+					applied++
+				}
+			}
+			if applied != tt.wantApplied || len(failed) != len(tt.wantFailed) || (len(failed) > 0 && failed[0] != tt.wantFailed[0]) {
+				t.Errorf("applied = %d, failed = %v, want %d, %v", applied, failed, tt.wantApplied, tt.wantFailed)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
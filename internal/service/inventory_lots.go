@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// ErrInvalidLot được trả về khi thông tin lô, lot allocation policy hoặc truy vấn lô không hợp lệ.
+var ErrInvalidLot = errors.New("invalid lot")
+
+// maxLotNumberLength khớp với độ dài cột inventory_lots.lot_number.
+const maxLotNumberLength = 128
+
+// validateLotReceipt kiểm tra lô đi kèm một thay đổi tồn kho: chỉ thay đổi nhập hàng (Delta > 0)
+// mới được ghi vào lô, và hạn dùng không được trước ngày sản xuất.
+func validateLotReceipt(change repository.StockChange) error {
+	lot := change.Lot
+	if lot == nil {
+		return nil
+	}
+	if change.Delta <= 0 {
+		return fmt.Errorf("%w: lô chỉ dùng cho thay đổi nhập hàng (change > 0)", ErrInvalidLot)
+	}
+	if strings.TrimSpace(lot.LotNumber) == "" || len(lot.LotNumber) > maxLotNumberLength {
+		return fmt.Errorf("%w: lot_number bắt buộc, tối đa %d ký tự", ErrInvalidLot, maxLotNumberLength)
+	}
+	if lot.ManufacturedAt != nil && lot.ExpiresAt != nil && lot.ExpiresAt.Before(*lot.ManufacturedAt) {
+		return fmt.Errorf("%w: expires_at trước manufactured_at", ErrInvalidLot)
+	}
+	return nil
+}
+
+// ParseLotDate đọc ngày dạng YYYY-MM-DD; chuỗi rỗng trả về nil.
+func ParseLotDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ngày %q phải có dạng YYYY-MM-DD", ErrInvalidLot, s)
+	}
+	return &t, nil
+}
+
+// SetLotAllocationPolicy đặt thứ tự lấy hàng từ các lô của item và invalidate cache.
+func (s *InventoryService) SetLotAllocationPolicy(ctx context.Context, itemID string, policy model.LotAllocationPolicy) (*model.InventoryItem, error) {
+	if !policy.Valid() {
+		return nil, fmt.Errorf("%w: lot allocation policy phải là fefo hoặc fifo", ErrInvalidLot)
+	}
+	if err := s.repo.SetLotAllocationPolicy(ctx, itemID, policy); err != nil {
+		return nil, err
+	}
+	s.InvalidateCache(ctx, itemID)
+	return s.repo.GetInventory(ctx, itemID)
+}
+
+// ListLots trả về các lô của item theo thứ tự sẽ được lấy hàng.
+func (s *InventoryService) ListLots(ctx context.Context, itemID, locationID string, includeEmpty bool) ([]*model.InventoryLot, error) {
+	return s.repo.ListLots(ctx, itemID, locationID, includeEmpty)
+}
+
+// ListExpiringLots trả về các lô còn hàng hết hạn trong vòng withinDays ngày tới (tính theo ngày UTC),
+// kể cả các lô đã hết hạn.
+func (s *InventoryService) ListExpiringLots(ctx context.Context, withinDays int, locationID, cursor string, pageSize int) ([]*model.InventoryLot, string, error) {
+	if withinDays < 0 {
+		return nil, "", fmt.Errorf("%w: số ngày không được âm", ErrInvalidLot)
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return s.repo.ListExpiringLots(ctx, repository.ExpiringLotsOptions{
+		ExpiresBefore: today.AddDate(0, 0, withinDays),
+		LocationID:    locationID,
+		Cursor:        cursor,
+		PageSize:      pageSize,
+	})
+}
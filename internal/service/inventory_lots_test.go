@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestValidateLotReceipt(t *testing.T) {
+	made := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := made.AddDate(1, 0, 0)
+	tests := []struct {
+		name    string
+		change  repository.StockChange
+		wantErr error
+	}{
+		{name: "no lot", change: repository.StockChange{Delta: -2}},
+		{name: "receipt into a lot", change: repository.StockChange{Delta: 5, Lot: &repository.LotReceipt{LotNumber: "L1", ManufacturedAt: &made, ExpiresAt: &expires}}},
+		{name: "lot on a decrease", change: repository.StockChange{Delta: -5, Lot: &repository.LotReceipt{LotNumber: "L1"}}, wantErr: ErrInvalidLot},
+		{name: "blank lot number", change: repository.StockChange{Delta: 5, Lot: &repository.LotReceipt{LotNumber: "  "}}, wantErr: ErrInvalidLot},
+		{name: "lot number too long", change: repository.StockChange{Delta: 5, Lot: &repository.LotReceipt{LotNumber: strings.Repeat("x", maxLotNumberLength+1)}}, wantErr: ErrInvalidLot},
+		{name: "expiry before manufacture", change: repository.StockChange{Delta: 5, Lot: &repository.LotReceipt{LotNumber: "L1", ManufacturedAt: &expires, ExpiresAt: &made}}, wantErr: ErrInvalidLot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateLotReceipt(tt.change); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateLotReceipt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseLotDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "2026-12-31", want: "2026-12-31"},
+		{in: "31/12/2026", wantErr: true},
+		{in: "2026-12-31T00:00:00Z", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLotDate(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidLot) {
+				t.Errorf("ParseLotDate(%q) error = %v, want ErrInvalidLot", tt.in, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseLotDate(%q) error = %v", tt.in, err)
+		}
+		if (got == nil) != (tt.want == "") || (got != nil && got.Format(time.DateOnly) != tt.want) {
+			t.Errorf("ParseLotDate(%q) = %v, want %q", tt.in, got, tt.want)
+		}
+	}
+}
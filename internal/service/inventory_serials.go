@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// MaxSerialsPerRequest là số serial tối đa của một thao tác serial.
+const MaxSerialsPerRequest = 500
+
+// maxSerialNumberLength khớp với độ dài cột inventory_serials.serial_number.
+const maxSerialNumberLength = 128
+
+// ErrInvalidSerial được trả về khi danh sách serial hoặc tham số của thao tác serial không hợp lệ.
+var ErrInvalidSerial = errors.New("invalid serial request")
+
+// SerialOperation là một thao tác làm đổi trạng thái serial.
+type SerialOperation string
+
+const (
+	SerialReceive SerialOperation = "receive" // nhập serial mới: → available
+	SerialReserve SerialOperation = "reserve" // available → reserved
+	SerialShip    SerialOperation = "ship"    // available/reserved → shipped
+	SerialReturn  SerialOperation = "return"  // shipped (khách trả) hoặc reserved (huỷ giữ) → available
+)
+
+// SerialRequest là một thao tác trên nhiều serial của cùng một item.
+type SerialRequest struct {
+	ItemID        string
+	LocationID    string // receive: nơi nhập; return: nơi nhận lại (rỗng = location cũ của serial)
+	SerialNumbers []string
+	OrderID       string // reserve/ship: đơn hàng giữ/nhận serial
+	Source        model.MovementSource
+	CorrelationID string
+}
+
+func validateSerialRequest(req SerialRequest) error {
+	if len(req.SerialNumbers) == 0 || len(req.SerialNumbers) > MaxSerialsPerRequest {
+		return fmt.Errorf("%w: cần từ 1 đến %d serial", ErrInvalidSerial, MaxSerialsPerRequest)
+	}
+	seen := make(map[string]struct{}, len(req.SerialNumbers))
+	for _, sn := range req.SerialNumbers {
+		if strings.TrimSpace(sn) == "" || len(sn) > maxSerialNumberLength {
+			return fmt.Errorf("%w: serial_number bắt buộc, tối đa %d ký tự", ErrInvalidSerial, maxSerialNumberLength)
+		}
+		if _, dup := seen[sn]; dup {
+			return fmt.Errorf("%w: serial %s bị lặp", ErrInvalidSerial, sn)
+		}
+		seen[sn] = struct{}{}
+	}
+	return nil
+}
+
+// ApplySerialsTx thực hiện op trên các serial trong transaction tx: tất cả cùng thành công hoặc không serial nào
+// thay đổi. Mỗi serial chuyển vào/ra trạng thái available cộng/trừ 1 vào tồn kho tại location của serial và
+// ghi event vào outbox; mọi bước đều được ghi movement kèm serial để tra cứu vòng đời.
+// Caller commit tx rồi gọi InvalidateCache.
+func (s *InventoryService) ApplySerialsTx(ctx context.Context, tx *sql.Tx, op SerialOperation, req SerialRequest) ([]*model.InventorySerial, error) {
+	if err := validateSerialRequest(req); err != nil {
+		return nil, err
+	}
+	// Lock item trước các serial để các thao tác song song trên cùng item luôn lấy lock theo cùng thứ tự.
+	if err := s.repo.LockItemsTx(ctx, tx, []string{req.ItemID}); err != nil {
+		return nil, err
+	}
+
+	serialNumbers := append([]string(nil), req.SerialNumbers...)
+	sort.Strings(serialNumbers)
+	result := make([]*model.InventorySerial, 0, len(serialNumbers))
+	for _, sn := range serialNumbers {
+		serial, err := s.applySerialTx(ctx, tx, op, req, sn)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, serial)
+	}
+	return result, nil
+}
+
+func (s *InventoryService) applySerialTx(ctx context.Context, tx *sql.Tx, op SerialOperation, req SerialRequest, serialNumber string) (*model.InventorySerial, error) {
+	var (
+		serial *model.InventorySerial
+		reason model.MovementReason
+		err    error
+	)
+	if op == SerialReceive {
+		serial = &model.InventorySerial{
+			ItemID:       req.ItemID,
+			SerialNumber: serialNumber,
+			LocationID:   req.LocationID,
+			Status:       model.SerialStatusAvailable,
+		}
+		if serial.LocationID == "" {
+			serial.LocationID = model.DefaultLocationID
+		}
+		// Thay đổi tồn kho chạy trước để kiểm tra item có serialized không và tạo dòng location nếu cần.
+		delta := serialQuantityDelta("", serial.Status)
+		if err := s.applySerialStockTx(ctx, tx, req, serial, delta, model.MovementReasonSerialReceive); err != nil {
+			return nil, err
+		}
+		return serial, s.repo.InsertSerialTx(ctx, tx, serial)
+	}
+
+	serial, err = s.repo.LockSerialTx(ctx, tx, req.ItemID, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	from := serial.Status
+	stateErr := &repository.SerialStateError{SerialNumber: serialNumber, Status: from, Operation: string(op)}
+
+	switch op {
+	case SerialReserve:
+		if serial.Status != model.SerialStatusAvailable {
+			return nil, stateErr
+		}
+		reason = model.MovementReasonSerialReserve
+		serial.Status, serial.OrderID = model.SerialStatusReserved, req.OrderID
+	case SerialShip:
+		switch serial.Status {
+		case model.SerialStatusAvailable:
+		case model.SerialStatusReserved:
+			// Chỉ đơn đang giữ mới được nhận serial; request không ghi order_id không được lấy serial đang giữ.
+			if serial.OrderID != "" && req.OrderID != serial.OrderID {
+				return nil, stateErr
+			}
+		default:
+			return nil, stateErr
+		}
+		reason = model.MovementReasonSerialShip
+		serial.Status = model.SerialStatusShipped
+		if req.OrderID != "" {
+			serial.OrderID = req.OrderID
+		}
+	case SerialReturn:
+		switch serial.Status {
+		case model.SerialStatusShipped:
+			if req.LocationID != "" {
+				serial.LocationID = req.LocationID
+			}
+		case model.SerialStatusReserved:
+		default:
+			return nil, stateErr
+		}
+		reason = model.MovementReasonSerialReturn
+		serial.Status, serial.OrderID = model.SerialStatusAvailable, ""
+	default:
+		return nil, fmt.Errorf("%w: thao tác %q không hợp lệ", ErrInvalidSerial, op)
+	}
+
+	if err := s.applySerialStockTx(ctx, tx, req, serial, serialQuantityDelta(from, serial.Status), reason); err != nil {
+		return nil, err
+	}
+	return serial, s.repo.UpdateSerialTx(ctx, tx, serial)
+}
+
+// serialQuantityDelta là thay đổi số lượng khi một serial chuyển từ trạng thái from sang to (from rỗng = serial mới).
+// Số lượng của item serialized là số serial available, nên chỉ bước vào hoặc ra khỏi available mới đổi số lượng;
+// serial reserved và shipped không được tính.
+func serialQuantityDelta(from, to model.SerialStatus) int {
+	switch {
+	case from != model.SerialStatusAvailable && to == model.SerialStatusAvailable:
+		return 1
+	case from == model.SerialStatusAvailable && to != model.SerialStatusAvailable:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// applySerialStockTx ghi thay đổi tồn kho của một serial. Bước không đổi số lượng (ship serial đã giữ)
+// vẫn được ghi movement để vòng đời serial đầy đủ, nhưng không phát event.
+func (s *InventoryService) applySerialStockTx(ctx context.Context, tx *sql.Tx, req SerialRequest, serial *model.InventorySerial, delta int, reason model.MovementReason) error {
+	change := repository.StockChange{
+		ItemID:        req.ItemID,
+		LocationID:    serial.LocationID,
+		Delta:         delta,
+		Reason:        reason,
+		Source:        req.Source,
+		CorrelationID: req.CorrelationID,
+		SerialNumber:  serial.SerialNumber,
+	}
+	if delta == 0 {
+		_, err := s.repo.AdjustStockTx(ctx, tx, change)
+		return err
+	}
+	_, err := s.UpdateInventoryTx(ctx, tx, change)
+	return err
+}
+
+// ListSerials trả về các serial của item, lọc theo trạng thái (rỗng = tất cả) và location.
+func (s *InventoryService) ListSerials(ctx context.Context, itemID string, status model.SerialStatus, locationID, cursor string, pageSize int) ([]*model.InventorySerial, string, error) {
+	switch status {
+	case "", model.SerialStatusAvailable, model.SerialStatusReserved, model.SerialStatusShipped:
+	default:
+		return nil, "", fmt.Errorf("%w: status %q không hợp lệ", ErrInvalidSerial, status)
+	}
+	return s.repo.ListSerials(ctx, itemID, status, locationID, cursor, pageSize)
+}
+
+// TraceSerial trả về vòng đời của serial từ sổ cái movement.
+func (s *InventoryService) TraceSerial(ctx context.Context, itemID, serialNumber string) (*model.SerialTrace, error) {
+	return s.repo.TraceSerial(ctx, itemID, serialNumber)
+}
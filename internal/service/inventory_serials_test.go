@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestValidateSerialRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		serials []string
+		wantErr error
+	}{
+		{name: "distinct serials", serials: []string{"SN-1", "SN-2"}},
+		{name: "no serials", wantErr: ErrInvalidSerial},
+		{name: "too many serials", serials: make([]string, MaxSerialsPerRequest+1), wantErr: ErrInvalidSerial},
+		{name: "blank serial", serials: []string{" "}, wantErr: ErrInvalidSerial},
+		{name: "serial too long", serials: []string{strings.Repeat("x", maxSerialNumberLength+1)}, wantErr: ErrInvalidSerial},
+		{name: "duplicate serial", serials: []string{"SN-1", "SN-1"}, wantErr: ErrInvalidSerial},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSerialRequest(SerialRequest{ItemID: "sku-1", SerialNumbers: tt.serials}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateSerialRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSerialQuantityDelta(t *testing.T) {
+	available, reserved, shipped := model.SerialStatusAvailable, model.SerialStatusReserved, model.SerialStatusShipped
+	tests := []struct {
+		from, to model.SerialStatus
+		want     int
+	}{
+		{from: "", to: available, want: 1},
+		{from: available, to: reserved, want: -1},
+		{from: available, to: shipped, want: -1},
+		{from: reserved, to: shipped, want: 0},
+		{from: reserved, to: available, want: 1},
+		{from: shipped, to: available, want: 1},
+	}
+	for _, tt := range tests {
+		if got := serialQuantityDelta(tt.from, tt.to); got != tt.want {
+			t.Errorf("serialQuantityDelta(%q, %q) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestInventoryServiceApplySerialsTxTransitions(t *testing.T) {
+	// errStock dừng thao tác ở bước ghi tồn kho, sau khi trạng thái serial đã được kiểm tra.
+	errStock := errors.New("stock update reached")
+	tests := []struct {
+		name      string
+		op        SerialOperation
+		status    model.SerialStatus
+		heldBy    string // đơn đang giữ serial
+		orderID   string // đơn của request
+		wantErr   error
+		wantDelta int // thay đổi số lượng gửi xuống DB khi thao tác hợp lệ
+	}{
+		{name: "reserve available", op: SerialReserve, status: model.SerialStatusAvailable, orderID: "order-1", wantErr: errStock, wantDelta: -1},
+		{name: "reserve reserved", op: SerialReserve, status: model.SerialStatusReserved, heldBy: "order-1", wantErr: repository.ErrSerialState},
+		{name: "ship available", op: SerialShip, status: model.SerialStatusAvailable, wantErr: errStock, wantDelta: -1},
+		{name: "ship reserved by the holding order", op: SerialShip, status: model.SerialStatusReserved, heldBy: "order-1", orderID: "order-1", wantErr: errStock},
+		{name: "ship reserved by another order", op: SerialShip, status: model.SerialStatusReserved, heldBy: "order-1", orderID: "order-2", wantErr: repository.ErrSerialState},
+		{name: "ship reserved without order", op: SerialShip, status: model.SerialStatusReserved, heldBy: "order-1", wantErr: repository.ErrSerialState},
+		{name: "ship shipped", op: SerialShip, status: model.SerialStatusShipped, wantErr: repository.ErrSerialState},
+		{name: "return shipped", op: SerialReturn, status: model.SerialStatusShipped, wantErr: errStock, wantDelta: 1},
+		{name: "release reserved", op: SerialReturn, status: model.SerialStatusReserved, heldBy: "order-1", wantErr: errStock, wantDelta: 1},
+		{name: "return available", op: SerialReturn, status: model.SerialStatusAvailable, wantErr: repository.ErrSerialState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM inventory WHERE id = ANY($1) ORDER BY id FOR UPDATE")).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("sku-1"))
+			mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_serials WHERE item_id = $1 AND serial_number = $2 FOR UPDATE")).
+				WithArgs("sku-1", "SN-1").
+				WillReturnRows(sqlmock.NewRows([]string{"item_id", "serial_number", "location_id", "status", "order_id", "created_at", "updated_at"}).
+					AddRow("sku-1", "SN-1", "wh-1", tt.status, tt.heldBy, now, now))
+			if errors.Is(tt.wantErr, errStock) {
+				mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+					WithArgs(tt.wantDelta, "sku-1", int64(0)).
+					WillReturnError(errStock)
+			}
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			svc := NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), nil, "inventory-events")
+			_, err = svc.ApplySerialsTx(context.Background(), tx, tt.op, SerialRequest{
+				ItemID:        "sku-1",
+				SerialNumbers: []string{"SN-1"},
+				OrderID:       tt.orderID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplySerialsTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -2,22 +2,242 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"inventory-service.com/m/internal/cache"
+	"inventory-service.com/m/internal/model"
 	"inventory-service.com/m/internal/repository"
+	idUtils "inventory-service.com/m/internal/utils/id"
 )
 
+// ErrInvalidReorderPoint được trả về khi ngưỡng âm hoặc safety stock lớn hơn reorder point.
+var ErrInvalidReorderPoint = errors.New("reorder_point and safety_stock must not be negative, safety_stock must not exceed reorder_point")
+
+// InventoryService là điểm chung cho các thay đổi tồn kho từ HTTP và gRPC: áp dụng thay đổi,
+// ghi event vào outbox trong cùng transaction và invalidate cache sau khi commit.
+// Các lệnh đọc item đi qua cache Redis (cache-aside).
 type InventoryService struct {
-	repo *repository.InventoryRepository
+	db         *sql.DB
+	repo       *repository.InventoryRepository
+	cache      *cache.InventoryCache
+	eventTopic string // topic nhận InventoryUpdateEvent qua outbox
+}
+
+func NewInventoryService(db *sql.DB, repo *repository.InventoryRepository, inventoryCache *cache.InventoryCache, eventTopic string) *InventoryService {
+	return &InventoryService{db: db, repo: repo, cache: inventoryCache, eventTopic: eventTopic}
+}
+
+// CreateInventory tạo item mới, ghi event và invalidate cache.
+func (s *InventoryService) CreateInventory(ctx context.Context, change repository.StockChange) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.CreateInventoryTx(ctx, tx, change); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.InvalidateCache(ctx, change.ItemID)
+	return nil
+}
+
+// CreateInventoryTx tạo item mới và ghi event vào outbox trong transaction tx.
+// Caller commit tx rồi gọi InvalidateCache.
+func (s *InventoryService) CreateInventoryTx(ctx context.Context, tx *sql.Tx, change repository.StockChange) error {
+	if change.Serialized && change.Delta != 0 {
+		return fmt.Errorf("%w: item serialized phải được tạo với quantity 0, rồi nhập hàng theo serial", ErrInvalidSerial)
+	}
+	if err := s.repo.CreateInventoryTx(ctx, tx, change); err != nil {
+		return err
+	}
+	if change.Reason == "" {
+		change.Reason = model.MovementReasonCreate
+	}
+	return s.enqueueUpdateEventTx(ctx, tx, model.EventTypeCreate, change)
+}
+
+// UpdateInventory cộng change.Delta vào tồn kho, ghi event và invalidate cache.
+func (s *InventoryService) UpdateInventory(ctx context.Context, change repository.StockChange) (repository.StockResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return repository.StockResult{}, err
+	}
+	defer tx.Rollback()
+
+	result, err := s.UpdateInventoryTx(ctx, tx, change)
+	if err != nil {
+		return repository.StockResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return repository.StockResult{}, err
+	}
+	s.InvalidateCache(ctx, change.ItemID)
+	return result, nil
+}
+
+// UpdateInventoryTx cộng change.Delta vào tồn kho và ghi event vào outbox trong transaction tx.
+// Caller commit tx rồi gọi InvalidateCache.
+func (s *InventoryService) UpdateInventoryTx(ctx context.Context, tx *sql.Tx, change repository.StockChange) (repository.StockResult, error) {
+	if err := validateLotReceipt(change); err != nil {
+		return repository.StockResult{}, err
+	}
+	result, err := s.repo.AdjustStockTx(ctx, tx, change)
+	if err != nil {
+		return repository.StockResult{}, err
+	}
+	return result, s.enqueueUpdateEventTx(ctx, tx, model.EventTypeUpdate, change)
+}
+
+// DeleteInventory xoá item (hoặc tồn kho tại change.LocationID), ghi event và invalidate cache.
+func (s *InventoryService) DeleteInventory(ctx context.Context, change repository.StockChange) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.DeleteInventoryTx(ctx, tx, change); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.InvalidateCache(ctx, change.ItemID)
+	return nil
+}
+
+// DeleteInventoryTx xoá item (hoặc tồn kho tại change.LocationID) và ghi event vào outbox trong transaction tx,
+// với Change là số lượng đã bị xoá (số âm). Caller commit tx rồi gọi InvalidateCache.
+func (s *InventoryService) DeleteInventoryTx(ctx context.Context, tx *sql.Tx, change repository.StockChange) error {
+	removed, err := s.repo.DeleteInventoryTx(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+	change.Delta = -removed
+	if change.Reason == "" {
+		change.Reason = model.MovementReasonDelete
+	}
+	return s.enqueueUpdateEventTx(ctx, tx, model.EventTypeDelete, change)
+}
+
+// enqueueUpdateEventTx ghi InventoryUpdateEvent của change vào outbox, key theo item để giữ thứ tự,
+// và đưa thay đổi vào hàng đợi webhook trong cùng transaction tx.
+// Event outbox không có Type nên consumer không áp dụng lại; webhook nhận InventoryEvent với loại eventType
+// và cùng EventID, giống các thay đổi đến từ Kafka.
+func (s *InventoryService) enqueueUpdateEventTx(ctx context.Context, tx *sql.Tx, eventType model.InventoryEventType, change repository.StockChange) error {
+	event := model.InventoryUpdateEvent{
+		EventID:  idUtils.NewID(),
+		Id:       change.ItemID,
+		Location: change.LocationID,
+		Change:   change.Delta,
+		DateTime: time.Now(),
+
+		Reason:        string(change.Reason),
+		CorrelationID: change.CorrelationID,
+		SerialNumber:  change.SerialNumber,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := repository.EnqueueOutboxTx(ctx, tx, s.eventTopic, change.ItemID, payload); err != nil {
+		return err
+	}
+
+	webhookPayload, err := json.Marshal(model.InventoryEvent{
+		EventID:  event.EventID,
+		Type:     eventType,
+		Id:       event.Id,
+		Location: event.Location,
+		Quantity: event.Change,
+		DateTime: event.DateTime,
+
+		Reason:        event.Reason,
+		CorrelationID: event.CorrelationID,
+	})
+	if err != nil {
+		return err
+	}
+	return repository.EnqueueWebhookDeliveriesTx(ctx, tx, event.EventID, eventType, webhookPayload)
+}
+
+// SetOversellPolicy đặt oversell policy cho item hoặc một location của item và invalidate cache.
+func (s *InventoryService) SetOversellPolicy(ctx context.Context, itemID, locationID string, policy model.OversellPolicy, backorderLimit int) (*model.InventoryItem, error) {
+	if err := s.repo.SetOversellPolicy(ctx, itemID, locationID, policy, backorderLimit); err != nil {
+		return nil, err
+	}
+	s.InvalidateCache(ctx, itemID)
+	return s.repo.GetInventory(ctx, itemID)
+}
+
+// SetReorderPoint đặt ngưỡng cảnh báo tồn kho của item (nil = bỏ ngưỡng) và invalidate cache.
+// Nếu tồn kho hiện tại đã dưới ngưỡng mới, cảnh báo được phát ngay.
+func (s *InventoryService) SetReorderPoint(ctx context.Context, itemID string, reorderPoint, safetyStock *int) (*model.InventoryItem, error) {
+	if !validReorderPoint(reorderPoint, safetyStock) {
+		return nil, ErrInvalidReorderPoint
+	}
+	if err := s.repo.SetReorderPoint(ctx, itemID, reorderPoint, safetyStock); err != nil {
+		return nil, err
+	}
+	s.InvalidateCache(ctx, itemID)
+	return s.repo.GetInventory(ctx, itemID)
+}
+
+func validReorderPoint(reorderPoint, safetyStock *int) bool {
+	if reorderPoint != nil && *reorderPoint < 0 {
+		return false
+	}
+	if safetyStock != nil && *safetyStock < 0 {
+		return false
+	}
+	return reorderPoint == nil || safetyStock == nil || *safetyStock <= *reorderPoint
+}
+
+// InvalidateCache xoá cache của item. Lỗi chỉ được log, không ảnh hưởng thay đổi đã commit.
+func (s *InventoryService) InvalidateCache(ctx context.Context, itemID string) {
+	if err := s.cache.Invalidate(ctx, itemID); err != nil {
+		log.Printf("Lỗi xóa cache của item %s: %v", itemID, err)
+	}
+}
+
+// GetInventory đọc item qua cache; item không tồn tại cũng được cache trong thời gian ngắn.
+func (s *InventoryService) GetInventory(ctx context.Context, itemID string) (*model.InventoryItem, error) {
+	item, err := s.cache.Get(ctx, itemID, func(ctx context.Context) (*model.InventoryItem, error) {
+		item, err := s.repo.GetInventory(ctx, itemID)
+		if errors.Is(err, repository.ErrInventoryNotFound) {
+			return nil, nil
+		}
+		return item, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, repository.ErrInventoryNotFound
+	}
+	return item, nil
 }
 
-func NewInventoryService(repo *repository.InventoryRepository) *InventoryService {
-	return &InventoryService{repo: repo}
+// GetInventoryTx đọc item trong transaction tx, dùng để trả về trạng thái mới trước khi commit.
+func (s *InventoryService) GetInventoryTx(ctx context.Context, tx *sql.Tx, itemID string) (*model.InventoryItem, error) {
+	return s.repo.GetInventoryTx(ctx, tx, itemID)
 }
 
-func (s *InventoryService) CreateInventory(ctx context.Context, itemID string, quantity int32) error {
-	return s.repo.CreateInventory(itemID, quantity)
+func (s *InventoryService) ListInventories(ctx context.Context, opts repository.InventoryListOptions) ([]*model.InventoryItem, string, error) {
+	return s.repo.ListInventories(ctx, opts)
 }
 
-func (s *InventoryService) GetInventory(ctx context.Context, itemID string) (int32, error) {
-	return s.repo.GetInventory(itemID)
+// GetInventories đọc các item qua cache, chỉ truy vấn DB cho các item cache miss.
+// Kết quả theo thứ tự itemIDs, bỏ qua item không tồn tại.
+func (s *InventoryService) GetInventories(ctx context.Context, itemIDs []string) ([]*model.InventoryItem, error) {
+	return s.cache.GetMany(ctx, itemIDs, s.repo.GetInventories)
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestValidReorderPoint(t *testing.T) {
+	n := func(v int) *int { return &v }
+	tests := []struct {
+		name         string
+		reorderPoint *int
+		safetyStock  *int
+		want         bool
+	}{
+		{name: "no thresholds", want: true},
+		{name: "reorder point only", reorderPoint: n(10), want: true},
+		{name: "safety stock below reorder point", reorderPoint: n(10), safetyStock: n(4), want: true},
+		{name: "safety stock equal to reorder point", reorderPoint: n(10), safetyStock: n(10), want: true},
+		{name: "safety stock above reorder point", reorderPoint: n(10), safetyStock: n(11)},
+		{name: "negative reorder point", reorderPoint: n(-1)},
+		{name: "negative safety stock", safetyStock: n(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validReorderPoint(tt.reorderPoint, tt.safetyStock); got != tt.want {
+				t.Errorf("validReorderPoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryServiceEnqueueUpdateEventTx(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType model.InventoryEventType
+	}{
+		{name: "create", eventType: model.EventTypeCreate},
+		{name: "update", eventType: model.EventTypeUpdate},
+		{name: "delete", eventType: model.EventTypeDelete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+				WithArgs("inventory-events", "sku-1", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			// Webhook nhận loại event thật để subscription lọc theo event_types.
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+				WithArgs(sqlmock.AnyArg(), tt.eventType, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			svc := NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), nil, "inventory-events")
+			if err := svc.enqueueUpdateEventTx(context.Background(), tx, tt.eventType, repository.StockChange{ItemID: "sku-1", Delta: 3}); err != nil {
+				t.Fatal(err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
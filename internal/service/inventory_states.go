@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// ErrInvalidTransition được trả về khi thao tác chuyển trạng thái tồn kho không hợp lệ.
+var ErrInvalidTransition = errors.New("invalid stock state transition")
+
+func validateTransition(t repository.StockTransition) error {
+	if !t.From.Transitionable() || !t.To.Transitionable() {
+		return fmt.Errorf("%w: from/to phải là on_hand, quarantined hoặc damaged", ErrInvalidTransition)
+	}
+	if t.From == t.To {
+		return fmt.Errorf("%w: from và to phải khác nhau", ErrInvalidTransition)
+	}
+	if t.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity phải lớn hơn 0", ErrInvalidTransition)
+	}
+	return nil
+}
+
+// TransitionStockTx chuyển hàng giữa các trạng thái on-hand, quarantined và damaged tại một location
+// trong transaction tx (ví dụ quarantined → on_hand sau khi QA đạt). Caller commit tx rồi gọi InvalidateCache.
+func (s *InventoryService) TransitionStockTx(ctx context.Context, tx *sql.Tx, t repository.StockTransition) (repository.StockResult, error) {
+	if err := validateTransition(t); err != nil {
+		return repository.StockResult{}, err
+	}
+	t.ToLocationID = ""
+	if t.Reason == "" {
+		t.Reason = model.MovementReasonStateTransition
+	}
+	return s.moveStockTx(ctx, tx, t)
+}
+
+// moveStockTx thực hiện t và ghi event vào outbox cho mỗi thay đổi on-hand; thay đổi chỉ giữa các trạng thái
+// khác on-hand không phát event.
+func (s *InventoryService) moveStockTx(ctx context.Context, tx *sql.Tx, t repository.StockTransition) (repository.StockResult, error) {
+	result, err := s.repo.TransitionStockTx(ctx, tx, t)
+	if err != nil {
+		return repository.StockResult{}, err
+	}
+	for _, change := range t.OnHandChanges() {
+		if err := s.enqueueUpdateEventTx(ctx, tx, model.EventTypeUpdate, change); err != nil {
+			return repository.StockResult{}, err
+		}
+	}
+	return result, nil
+}
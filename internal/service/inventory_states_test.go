@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    model.StockState
+		to      model.StockState
+		qty     int
+		wantErr error
+	}{
+		{name: "quarantine release", from: model.StockStateQuarantined, to: model.StockStateOnHand, qty: 5},
+		{name: "on-hand to damaged", from: model.StockStateOnHand, to: model.StockStateDamaged, qty: 1},
+		{name: "in-transit only moves by transfer", from: model.StockStateInTransit, to: model.StockStateOnHand, qty: 1, wantErr: ErrInvalidTransition},
+		{name: "unknown state", from: "lost", to: model.StockStateOnHand, qty: 1, wantErr: ErrInvalidTransition},
+		{name: "same state", from: model.StockStateDamaged, to: model.StockStateDamaged, qty: 1, wantErr: ErrInvalidTransition},
+		{name: "non-positive quantity", from: model.StockStateOnHand, to: model.StockStateQuarantined, wantErr: ErrInvalidTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTransition(repository.StockTransition{ItemID: "sku-1", From: tt.from, To: tt.to, Quantity: tt.qty})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateTransition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
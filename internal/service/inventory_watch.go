@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"inventory-service.com/m/internal/cache"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// MaxWatchItems là tổng số item ID và prefix tối đa của một lần watch.
+const MaxWatchItems = 200
+
+// resumeClockSkew là khoảng lùi của mốc thời gian trong resume token khi tìm các item khớp prefix
+// đã thay đổi, bù cho các transaction commit chậm hơn NOW() của chúng. Item bị gửi lặp vẫn an toàn.
+const resumeClockSkew = 2 * time.Second
+
+var (
+	// ErrInvalidWatch được trả về khi watch không có item ID và prefix nào, hoặc vượt quá MaxWatchItems.
+	ErrInvalidWatch = fmt.Errorf("watch must have between 1 and %d item ids and prefixes", MaxWatchItems)
+	// ErrInvalidResumeToken được trả về khi resume token không giải mã được.
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrWatcherStopped được trả về cho các watch đang mở khi watcher dừng (service shutdown).
+	ErrWatcherStopped = errors.New("inventory watcher stopped")
+)
+
+// InventoryWatcher đẩy thay đổi tồn kho tới các client đang watch mà không cần polling.
+// Mỗi instance subscribe kênh Redis cache.InventoryChangesChannel, nơi mọi thay đổi đã commit được publish
+// ID item, rồi đọc lại trạng thái item thẳng từ Postgres (không qua cache) cho các watch liên quan.
+//
+// Resume token là version cuối cùng client đã nhận của từng item ID, cùng mốc updated_at lớn nhất đã gửi
+// của các item khớp prefix, nên watch lại chỉ gửi các item đã thay đổi. Message pub/sub có thể bị mất khi
+// Redis reconnect hoặc khi process dừng giữa lúc commit và publish; mọi watch được resync sau mỗi lần
+// subscribe lại và theo chu kỳ resyncInterval, nên việc mất message chỉ làm chậm cập nhật.
+type InventoryWatcher struct {
+	client         *redis.Client
+	inventory      *InventoryService
+	resyncInterval time.Duration // chu kỳ đọc lại toàn bộ item của mỗi watch; 0 = không resync
+
+	mu          sync.RWMutex
+	subscribers map[string]map[*watchSubscriber]struct{} // item ID -> các watch đang mở
+	prefixes    map[*watchSubscriber][]string            // các watch theo prefix
+	done        chan struct{}
+}
+
+// WatchRequest là các item một watch theo dõi.
+type WatchRequest struct {
+	ItemIDs  []string
+	Prefixes []string // mọi item có ID bắt đầu bằng một trong các prefix, kể cả item tạo sau khi bắt đầu watch
+	// ResumeToken là ResumeToken của change cuối cùng client đã nhận; rỗng = nhận snapshot của ItemIDs.
+	ResumeToken string
+}
+
+func NewInventoryWatcher(client *redis.Client, inventory *InventoryService, resyncInterval time.Duration) *InventoryWatcher {
+	return &InventoryWatcher{
+		client:         client,
+		inventory:      inventory,
+		resyncInterval: resyncInterval,
+		subscribers:    make(map[string]map[*watchSubscriber]struct{}),
+		prefixes:       make(map[*watchSubscriber][]string),
+		done:           make(chan struct{}),
+	}
+}
+
+// watchSubscriber gom các item thay đổi của một watch. Nhiều thay đổi của cùng item trước khi watch kịp
+// xử lý được gộp làm một, nên watch chậm không chặn dispatch và không làm tràn bộ nhớ.
+type watchSubscriber struct {
+	mu      sync.Mutex
+	pending map[string]struct{}
+	resync  bool // cần đọc lại mọi item của watch, ví dụ sau khi pub/sub reconnect
+	notify  chan struct{}
+}
+
+func (s *watchSubscriber) mark(itemID string) {
+	s.mu.Lock()
+	s.pending[itemID] = struct{}{}
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *watchSubscriber) markResync() {
+	s.mu.Lock()
+	s.resync = true
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *watchSubscriber) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// drain trả về các item đã thay đổi và việc có cần resync toàn bộ watch hay không.
+func (s *watchSubscriber) drain() ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	resync := s.resync
+	s.pending, s.resync = make(map[string]struct{}), false
+	return ids, resync
+}
+
+// pubsubHealthCheckInterval là thời gian không có message trước khi watcher ping Redis để phát hiện kết nối hỏng.
+const pubsubHealthCheckInterval = 30 * time.Second
+
+// pubsubErrorBackoff là thời gian chờ trước khi đọc lại sau lỗi pub/sub; go-redis tự kết nối lại ở lần đọc sau.
+const pubsubErrorBackoff = time.Second
+
+// Start subscribe kênh thay đổi và phân phối ID item tới các watch cho tới khi ctx bị hủy.
+// Mỗi lần go-redis subscribe lại sau khi mất kết nối, mọi watch đang mở được resync vì các thay đổi
+// publish trong lúc mất kết nối đã bị lỡ. Khi Start trả về, các watch đang mở kết thúc với ErrWatcherStopped.
+func (w *InventoryWatcher) Start(ctx context.Context) {
+	defer close(w.done)
+
+	pubsub := cache.SubscribeInventoryChanges(ctx, w.client)
+	defer pubsub.Close()
+
+	subscribed := false
+	for {
+		msg, err := pubsub.ReceiveTimeout(ctx, pubsubHealthCheckInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Println("Inventory watcher đang dừng...")
+				return
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				// Không có message: ping để lỗi kết nối (nếu có) lộ ra ở lần đọc sau.
+				if err := pubsub.Ping(ctx); err == nil {
+					continue
+				}
+			}
+			log.Printf("Lỗi đọc kênh thay đổi tồn kho: %v", err)
+			select {
+			case <-ctx.Done():
+				log.Println("Inventory watcher đang dừng...")
+				return
+			case <-time.After(pubsubErrorBackoff):
+			}
+			continue
+		}
+
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if subscribed {
+				log.Printf("Đã subscribe lại %s, resync mọi watch đang mở", m.Channel)
+				w.resyncAll()
+			}
+			subscribed = true
+		case *redis.Message:
+			w.dispatch(m.Payload)
+		}
+	}
+}
+
+// resyncAll yêu cầu mọi watch đang mở đọc lại toàn bộ item và prefix của nó.
+func (w *InventoryWatcher) resyncAll() {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	subs := make(map[*watchSubscriber]struct{})
+	for _, byID := range w.subscribers {
+		for sub := range byID {
+			subs[sub] = struct{}{}
+		}
+	}
+	for sub := range w.prefixes {
+		subs[sub] = struct{}{}
+	}
+	for sub := range subs {
+		sub.markResync()
+	}
+}
+
+func (w *InventoryWatcher) dispatch(itemID string) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for sub := range w.subscribers[itemID] {
+		sub.mark(itemID)
+	}
+	for sub, prefixes := range w.prefixes {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(itemID, prefix) {
+				sub.mark(itemID)
+				break
+			}
+		}
+	}
+}
+
+func (w *InventoryWatcher) subscribe(itemIDs, prefixes []string) *watchSubscriber {
+	sub := &watchSubscriber{pending: make(map[string]struct{}), notify: make(chan struct{}, 1)}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(prefixes) > 0 {
+		w.prefixes[sub] = prefixes
+	}
+	for _, id := range itemIDs {
+		if w.subscribers[id] == nil {
+			w.subscribers[id] = make(map[*watchSubscriber]struct{})
+		}
+		w.subscribers[id][sub] = struct{}{}
+	}
+	return sub
+}
+
+func (w *InventoryWatcher) unsubscribe(itemIDs []string, sub *watchSubscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.prefixes, sub)
+	for _, id := range itemIDs {
+		delete(w.subscribers[id], sub)
+		if len(w.subscribers[id]) == 0 {
+			delete(w.subscribers, id)
+		}
+	}
+}
+
+// Validate kiểm tra số item/prefix và resume token, để caller báo lỗi trước khi mở stream.
+func (req WatchRequest) Validate() error {
+	n := len(uniqueIDs(req.ItemIDs)) + len(uniqueIDs(req.Prefixes))
+	if n == 0 || n > MaxWatchItems {
+		return ErrInvalidWatch
+	}
+	if req.ResumeToken != "" {
+		if _, err := decodeResumeToken(req.ResumeToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch gửi trạng thái của req.ItemIDs qua send, rồi gửi mỗi thay đổi sau đó của các item trong req,
+// cho tới khi ctx bị hủy, watcher dừng hoặc send trả lỗi.
+// Không có resume token: mỗi item ID được gửi một change snapshot; item khớp prefix chỉ được gửi khi thay đổi.
+// Có resume token: chỉ gửi các item đã thay đổi so với token, dưới dạng updated hoặc deleted.
+func (w *InventoryWatcher) Watch(ctx context.Context, req WatchRequest, send func(model.InventoryChange) error) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	ids := uniqueIDs(req.ItemIDs)
+	prefixes := uniqueIDs(req.Prefixes)
+
+	state := &watchState{ids: make(map[string]bool, len(ids)), prefixes: prefixes, versions: make(map[string]int64, len(ids))}
+	for _, id := range ids {
+		state.ids[id] = true
+	}
+	snapshot := req.ResumeToken == ""
+	if snapshot {
+		state.since = time.Now().UTC() // updated_at trong DB là TIMESTAMP theo UTC
+	} else {
+		token, err := decodeResumeToken(req.ResumeToken)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			state.versions[id] = token.Versions[id]
+		}
+		state.since = token.Since
+	}
+
+	// Đăng ký trước khi đọc trạng thái ban đầu để không bỏ lỡ thay đổi xảy ra giữa hai bước.
+	sub := w.subscribe(ids, prefixes)
+	defer w.unsubscribe(ids, sub)
+
+	if err := w.sendChanges(ctx, state, ids, snapshot, send); err != nil {
+		return err
+	}
+	if !snapshot {
+		if err := w.sendPrefixChanges(ctx, state, send); err != nil {
+			return err
+		}
+	}
+
+	var resync <-chan time.Time
+	if w.resyncInterval > 0 {
+		ticker := time.NewTicker(w.resyncInterval)
+		defer ticker.Stop()
+		resync = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.done:
+			return ErrWatcherStopped
+		case <-sub.notify:
+			changed, resyncAll := sub.drain()
+			if resyncAll {
+				if err := w.resync(ctx, state, ids, send); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := w.sendChanges(ctx, state, changed, false, send); err != nil {
+				return err
+			}
+		case <-resync:
+			if err := w.resync(ctx, state, ids, send); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resync đọc lại mọi item ID và các item khớp prefix đã thay đổi từ mốc state.since, gửi những gì client chưa có.
+func (w *InventoryWatcher) resync(ctx context.Context, st *watchState, ids []string, send func(model.InventoryChange) error) error {
+	if err := w.sendChanges(ctx, st, ids, false, send); err != nil {
+		return err
+	}
+	return w.sendPrefixChanges(ctx, st, send)
+}
+
+// watchState là những gì một watch đã gửi cho client.
+type watchState struct {
+	ids      map[string]bool // item ID được watch trực tiếp, version của chúng nằm trong resume token
+	prefixes []string
+	versions map[string]int64 // version đã gửi của từng item, 0 = không tồn tại
+	since    time.Time        // updated_at lớn nhất đã gửi của item khớp prefix
+}
+
+func (st *watchState) resumeToken() (string, error) {
+	token := resumeToken{Versions: make(map[string]int64, len(st.ids)), Since: st.since}
+	for id := range st.ids {
+		token.Versions[id] = st.versions[id]
+	}
+	raw, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// sendChanges đọc trạng thái hiện tại của ids và gửi các item có version khác với đã gửi (hoặc tất cả
+// nếu snapshot), cập nhật state theo những gì đã gửi. Item được đọc thẳng từ repository: bản cache cũ
+// có thể trùng version đã gửi và làm watch bỏ lỡ thay đổi mãi mãi.
+func (w *InventoryWatcher) sendChanges(ctx context.Context, st *watchState, ids []string, snapshot bool, send func(model.InventoryChange) error) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	items, err := w.inventory.repo.GetInventories(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*model.InventoryItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	for _, id := range ids {
+		if err := w.sendChange(st, id, byID[id], snapshot, send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendPrefixChanges gửi các item khớp prefix có updated_at từ mốc st.since, dùng khi resume và resync
+// vì thông báo pub/sub cho các item này có thể đã bị lỡ. Item bị xoá trong lúc đó không được phát hiện.
+func (w *InventoryWatcher) sendPrefixChanges(ctx context.Context, st *watchState, send func(model.InventoryChange) error) error {
+	after := st.since.Add(-resumeClockSkew)
+	for _, prefix := range st.prefixes {
+		opts := repository.InventoryListOptions{
+			Filter:   repository.InventoryFilter{IDPrefix: prefix, UpdatedAfter: &after},
+			SortBy:   repository.InventorySortUpdatedAt,
+			PageSize: repository.MaxInventoryPageSize,
+		}
+		for {
+			items, next, err := w.inventory.ListInventories(ctx, opts)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := w.sendChange(st, item.ID, item, false, send); err != nil {
+					return err
+				}
+			}
+			if next == "" {
+				break
+			}
+			opts.Cursor = next
+		}
+	}
+	return nil
+}
+
+// sendChange gửi trạng thái item (nil = không tồn tại) nếu khác version đã gửi hoặc nếu snapshot.
+func (w *InventoryWatcher) sendChange(st *watchState, id string, item *model.InventoryItem, snapshot bool, send func(model.InventoryChange) error) error {
+	var version int64
+	if item != nil {
+		version = item.Version
+	}
+	if !snapshot && st.versions[id] == version {
+		return nil
+	}
+	st.versions[id] = version
+	if item != nil && !st.ids[id] && item.UpdatedAt.After(st.since) {
+		st.since = item.UpdatedAt
+	}
+
+	change := model.InventoryChange{ItemID: id, Item: item}
+	switch {
+	case snapshot:
+		change.Type = model.InventoryChangeSnapshot
+	case item == nil:
+		change.Type = model.InventoryChangeDeleted
+	default:
+		change.Type = model.InventoryChangeUpdated
+	}
+	var err error
+	if change.ResumeToken, err = st.resumeToken(); err != nil {
+		return err
+	}
+	return send(change)
+}
+
+// resumeToken được mã hoá base64 JSON trong InventoryChange.ResumeToken.
+type resumeToken struct {
+	Versions map[string]int64 `json:"v,omitempty"`
+	Since    time.Time        `json:"t"`
+}
+
+func decodeResumeToken(s string) (resumeToken, error) {
+	var token resumeToken
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return token, ErrInvalidResumeToken
+	}
+	if err := json.Unmarshal(raw, &token); err != nil {
+		return token, ErrInvalidResumeToken
+	}
+	return token, nil
+}
+
+// uniqueIDs bỏ ID rỗng và ID trùng, giữ thứ tự xuất hiện đầu tiên.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"inventory-service.com/m/internal/cache"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+var watchItemColumns = []string{"id", "quantity", "version", "oversell_policy", "backorder_limit", "updated_at",
+	"reorder_point", "safety_stock", "stock_alert_state", "lot_allocation_policy", "serialized", "location_id", "location_quantity", "location_oversell_policy", "location_backorder_limit",
+	"in_transit", "quarantined", "damaged", "reservations", "reserved_serials"}
+
+// expectWatchRead mong đợi một lần đọc item từ repository, trả về sku-1 với version cho trước (0 = không tồn tại).
+func expectWatchRead(mock sqlmock.Sqlmock, version int64) {
+	rows := sqlmock.NewRows(watchItemColumns)
+	if version > 0 {
+		rows.AddRow("sku-1", 5, version, model.OversellPolicyDeny, 0, time.Now(), nil, nil, model.StockAlertStateOK, model.LotAllocationFEFO, false, "", 0, "", 0, 0, 0, 0, 0, 0)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory i")).WillReturnRows(rows)
+}
+
+func newTestWatcher(t *testing.T) (*InventoryWatcher, sqlmock.Sqlmock, *redis.Client) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	inventoryCache := cache.NewInventoryCache(client, cache.InventoryCacheConfig{TTL: time.Minute, NegativeTTL: time.Second, LockTTL: time.Second})
+	inventory := NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), inventoryCache, "inventory-events")
+	return NewInventoryWatcher(client, inventory, 0), mock, client
+}
+
+func TestInventoryWatcherSendChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot bool
+		sent     int64 // version client đã nhận, theo resume token
+		current  int64 // version hiện tại trong DB, 0 = đã xoá
+		want     model.InventoryChangeType
+	}{
+		{name: "snapshot is sent even when unchanged", snapshot: true, sent: 0, current: 3, want: model.InventoryChangeSnapshot},
+		{name: "snapshot of a missing item", snapshot: true, current: 0, want: model.InventoryChangeSnapshot},
+		{name: "resume skips items the client has", sent: 3, current: 3},
+		{name: "resume sends newer version", sent: 2, current: 3, want: model.InventoryChangeUpdated},
+		{name: "resume reports deletion", sent: 3, current: 0, want: model.InventoryChangeDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, mock, _ := newTestWatcher(t)
+			expectWatchRead(mock, tt.current)
+
+			st := &watchState{ids: map[string]bool{"sku-1": true}, versions: map[string]int64{"sku-1": tt.sent}}
+			var got []model.InventoryChange
+			err := w.sendChanges(context.Background(), st, []string{"sku-1"}, tt.snapshot, func(c model.InventoryChange) error {
+				got = append(got, c)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if len(got) != 0 {
+					t.Fatalf("sent %+v, want nothing", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Type != tt.want {
+				t.Fatalf("sent %+v, want one %s change", got, tt.want)
+			}
+			token, err := decodeResumeToken(got[0].ResumeToken)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.Versions["sku-1"] != tt.current {
+				t.Errorf("resume token version = %d, want %d", token.Versions["sku-1"], tt.current)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestInventoryWatcherWatch(t *testing.T) {
+	w, mock, client := newTestWatcher(t)
+	expectWatchRead(mock, 3)
+	expectWatchRead(mock, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	changes := make(chan model.InventoryChange, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Watch(ctx, WatchRequest{ItemIDs: []string{"sku-1"}}, func(c model.InventoryChange) error {
+			changes <- c
+			return nil
+		})
+	}()
+
+	if c := receiveChange(t, changes); c.Type != model.InventoryChangeSnapshot || c.Item.Version != 3 {
+		t.Fatalf("first change = %+v, want snapshot of version 3", c)
+	}
+	// Chờ watcher subscribe xong để message không bị mất.
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		subs, err := client.PubSubNumSub(ctx, cache.InventoryChangesChannel).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subs[cache.InventoryChangesChannel] > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("watcher did not subscribe")
+		}
+	}
+	if err := cache.PublishInventoryChange(ctx, client, "sku-1"); err != nil {
+		t.Fatal(err)
+	}
+	if c := receiveChange(t, changes); c.Type != model.InventoryChangeUpdated || c.Item.Version != 4 {
+		t.Errorf("change after publish = %+v, want updated to version 4", c)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) && !errors.Is(err, ErrWatcherStopped) {
+		t.Errorf("Watch() error = %v", err)
+	}
+}
+
+func TestWatchSubscriberDrain(t *testing.T) {
+	sub := &watchSubscriber{pending: make(map[string]struct{}), notify: make(chan struct{}, 1)}
+	sub.mark("sku-1")
+	sub.mark("sku-1")
+	sub.markResync()
+
+	ids, resync := sub.drain()
+	if len(ids) != 1 || ids[0] != "sku-1" || !resync {
+		t.Fatalf("drain() = %v, %v, want [sku-1] with resync", ids, resync)
+	}
+	if ids, resync = sub.drain(); len(ids) != 0 || resync {
+		t.Errorf("second drain() = %v, %v, want nothing pending", ids, resync)
+	}
+}
+
+func receiveChange(t *testing.T, changes <-chan model.InventoryChange) model.InventoryChange {
+	t.Helper()
+	select {
+	case c := <-changes:
+		return c
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change received")
+		return model.InventoryChange{}
+	}
+}
+
+func TestWatchRequestValidate(t *testing.T) {
+	tooMany := make([]string, MaxWatchItems+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("sku-%d", i)
+	}
+	tests := []struct {
+		name    string
+		req     WatchRequest
+		wantErr error
+	}{
+		{name: "item ids", req: WatchRequest{ItemIDs: []string{"sku-1"}}},
+		{name: "prefix only", req: WatchRequest{Prefixes: []string{"sku-"}}},
+		{name: "empty ids do not count", req: WatchRequest{ItemIDs: []string{""}}, wantErr: ErrInvalidWatch},
+		{name: "too many items", req: WatchRequest{ItemIDs: tooMany}, wantErr: ErrInvalidWatch},
+		{name: "bad resume token", req: WatchRequest{ItemIDs: []string{"sku-1"}, ResumeToken: "%%%"}, wantErr: ErrInvalidResumeToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInventoryWatcherDispatchPrefix(t *testing.T) {
+	w := NewInventoryWatcher(nil, nil, 0)
+	byID := w.subscribe([]string{"sku-1"}, nil)
+	byPrefix := w.subscribe(nil, []string{"sku-", "bin-"})
+	other := w.subscribe(nil, []string{"pallet-"})
+	defer w.unsubscribe([]string{"sku-1"}, byID)
+	defer w.unsubscribe(nil, byPrefix)
+	defer w.unsubscribe(nil, other)
+
+	w.dispatch("sku-1")
+	w.dispatch("sku-2")
+	w.resyncAll()
+
+	tests := []struct {
+		name       string
+		sub        *watchSubscriber
+		wantIDs    []string
+		wantResync bool
+	}{
+		{name: "item id watch", sub: byID, wantIDs: []string{"sku-1"}, wantResync: true},
+		{name: "matching prefix", sub: byPrefix, wantIDs: []string{"sku-1", "sku-2"}, wantResync: true},
+		{name: "other prefix", sub: other, wantResync: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, resync := tt.sub.drain()
+			sort.Strings(ids)
+			if len(ids) != len(tt.wantIDs) || (len(ids) > 0 && !reflect.DeepEqual(ids, tt.wantIDs)) || resync != tt.wantResync {
+				t.Errorf("drain() = %v, %v, want %v, %v", ids, resync, tt.wantIDs, tt.wantResync)
+			}
+		})
+	}
+}
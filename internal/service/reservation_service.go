@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// ReservationService quản lý reservation. Reservation thay đổi reserved/available-to-promise của item,
+// nên cache của item được invalidate qua inventory sau mỗi thay đổi.
+type ReservationService struct {
+	repo       *repository.ReservationRepository
+	inventory  *InventoryService
+	defaultTTL time.Duration
+}
+
+func NewReservationService(repo *repository.ReservationRepository, inventory *InventoryService, defaultTTL time.Duration) *ReservationService {
+	return &ReservationService{repo: repo, inventory: inventory, defaultTTL: defaultTTL}
+}
+
+// Reserve giữ chỗ tồn kho cho đơn hàng. Nếu ttl <= 0 thì dùng TTL mặc định từ cấu hình.
+func (s *ReservationService) Reserve(ctx context.Context, itemID, locationID, orderID string, quantity int, ttl time.Duration) (*model.Reservation, error) {
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	res, err := s.repo.Reserve(ctx, itemID, locationID, orderID, quantity, ttl)
+	if err != nil {
+		return nil, err
+	}
+	s.inventory.InvalidateCache(ctx, itemID)
+	return res, nil
+}
+
+// Confirm chốt reservation. Tồn kho được trừ qua InventoryService nên event được ghi vào outbox
+// trong cùng transaction; cache của item bị invalidate sau khi commit.
+func (s *ReservationService) Confirm(ctx context.Context, reservationID string, source model.MovementSource) (*model.Reservation, error) {
+	res, err := s.repo.Confirm(ctx, reservationID, source, s.adjustStockTx)
+	if err != nil {
+		return nil, err
+	}
+	s.inventory.InvalidateCache(ctx, res.ItemID)
+	return res, nil
+}
+
+func (s *ReservationService) adjustStockTx(ctx context.Context, tx *sql.Tx, change repository.StockChange) error {
+	_, err := s.inventory.UpdateInventoryTx(ctx, tx, change)
+	return err
+}
+
+func (s *ReservationService) Release(ctx context.Context, reservationID string) (*model.Reservation, error) {
+	res, err := s.repo.Release(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	s.inventory.InvalidateCache(ctx, res.ItemID)
+	return res, nil
+}
+
+// StartExpiryWorker định kỳ chuyển các reservation quá hạn sang expired cho tới khi ctx bị hủy.
+func (s *ReservationService) StartExpiryWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Context bị hủy, dừng reservation expiry worker")
+			return
+		case <-ticker.C:
+			itemIDs, err := s.repo.ExpireReservations(ctx)
+			if err != nil {
+				log.Printf("Lỗi expire reservation: %v", err)
+				continue
+			}
+			for _, itemID := range itemIDs {
+				s.inventory.InvalidateCache(ctx, itemID)
+			}
+			if len(itemIDs) > 0 {
+				log.Printf("Đã expire reservation của %d item", len(itemIDs))
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"inventory-service.com/m/internal/cache"
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+)
+
+// eventPayload so khớp payload outbox là InventoryUpdateEvent của lần confirm.
+type eventPayload struct{ t *testing.T }
+
+func (p eventPayload) Match(v driver.Value) bool {
+	var raw []byte
+	switch b := v.(type) {
+	case []byte:
+		raw = b
+	case string:
+		raw = []byte(b)
+	default:
+		return false
+	}
+	var event model.InventoryUpdateEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		p.t.Errorf("payload is not an InventoryUpdateEvent: %v", err)
+		return false
+	}
+	return event.EventID != "" && event.Id == "sku-1" && event.Location == "wh-1" && event.Change == -2 &&
+		event.Reason == string(model.MovementReasonReservationConfirm) && event.CorrelationID == "res-1"
+}
+
+// webhookPayload so khớp payload webhook là InventoryEvent loại update của lần confirm.
+type webhookPayload struct{ t *testing.T }
+
+func (p webhookPayload) Match(v driver.Value) bool {
+	raw, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	var event model.InventoryEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		p.t.Errorf("payload is not an InventoryEvent: %v", err)
+		return false
+	}
+	return event.EventID != "" && event.Type == model.EventTypeUpdate && event.Id == "sku-1" && event.Quantity == -2 &&
+		event.CorrelationID == "res-1"
+}
+
+func TestReservationServiceConfirmWritesOutboxEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	reservationColumns := []string{"id", "item_id", "location_id", "order_id", "quantity", "status", "expires_at", "created_at", "updated_at"}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations WHERE id = $1 FOR UPDATE")).
+		WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(append(reservationColumns, "expired")).
+			AddRow("res-1", "sku-1", "wh-1", "order-1", 2, model.ReservationPending, now.Add(time.Minute), now, now, false))
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory_reservations SET status = $1")).
+		WithArgs(model.ReservationConfirmed, "res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).
+			AddRow("res-1", "sku-1", "wh-1", "order-1", 2, model.ReservationConfirmed, now.Add(time.Minute), now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE inventory SET quantity = quantity + $1")).
+		WithArgs(-2, "sku-1", int64(0)).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "version", "oversell_policy", "backorder_limit", "lot_allocation_policy", "serialized"}).
+			AddRow(8, 2, model.OversellPolicyDeny, 0, model.LotAllocationFEFO, false))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT quantity, oversell_policy, backorder_limit")).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "oversell_policy", "backorder_limit"}).
+			AddRow(5, model.OversellPolicyDeny, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_reservations")).
+		WillReturnRows(sqlmock.NewRows([]string{"reserved"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO inventory_locations")).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM inventory_lots")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lot_number", "manufactured_at", "expires_at", "quantity"}))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO stock_movements")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, now))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs("inventory-events", "sku-1", eventPayload{t}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries")).
+		WithArgs(sqlmock.AnyArg(), model.EventTypeUpdate, webhookPayload{t}).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer redisClient.Close()
+	inventoryCache := cache.NewInventoryCache(redisClient, cache.InventoryCacheConfig{TTL: time.Minute, NegativeTTL: time.Second, LockTTL: time.Second})
+	inventory := NewInventoryService(db, repository.NewInventoryRepository(db, repository.StockAlertConfig{}), inventoryCache, "inventory-events")
+	svc := NewReservationService(repository.NewReservationRepository(db), inventory, time.Minute)
+
+	res, err := svc.Confirm(context.Background(), "res-1", model.MovementSourceGRPC)
+	if err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if res.Status != model.ReservationConfirmed {
+		t.Errorf("status = %s, want confirmed", res.Status)
+	}
+	if raw, _ := mr.Get(cache.InventoryKey("sku-1")); !strings.HasPrefix(raw, "tombstone:") {
+		t.Errorf("cache = %q, want the item invalidated after confirm", raw)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// MaxTransferLines là số item tối đa của một lệnh chuyển kho.
+const MaxTransferLines = 500
+
+// ErrInvalidTransfer được trả về khi lệnh chuyển kho hoặc lần nhận hàng không hợp lệ.
+var ErrInvalidTransfer = errors.New("invalid transfer order")
+
+// TransferService quản lý vòng đời lệnh chuyển kho: draft → shipped → partially_received → received,
+// hoặc cancelled. Mỗi bước ghi TransferEvent vào outbox trong cùng transaction với thay đổi tồn kho;
+// thay đổi on-hand ở nguồn/đích còn phát InventoryUpdateEvent như mọi thay đổi tồn kho khác.
+// Lô và hạn dùng của hàng xuất đi theo hàng qua in_transit tới on-hand của đích.
+type TransferService struct {
+	repo      *repository.TransferRepository
+	inventory *InventoryService
+	topic     string
+}
+
+// NewTransferService tạo service; topic rỗng thì không publish TransferEvent.
+func NewTransferService(repo *repository.TransferRepository, inventory *InventoryService, topic string) *TransferService {
+	return &TransferService{repo: repo, inventory: inventory, topic: topic}
+}
+
+// TransferInput là nội dung của lệnh chuyển kho mới.
+type TransferInput struct {
+	SourceLocationID      string // rỗng = location mặc định
+	DestinationLocationID string // rỗng = location mặc định
+	Note                  string
+	Lines                 []model.TransferLine // chỉ dùng ItemID và Quantity
+}
+
+// CreateTransferTx tạo lệnh chuyển kho ở trạng thái draft trong transaction tx; tồn kho chưa thay đổi.
+func (s *TransferService) CreateTransferTx(ctx context.Context, tx *sql.Tx, in TransferInput) (*model.TransferOrder, error) {
+	t := &model.TransferOrder{
+		ID:                    idUtils.NewID(),
+		SourceLocationID:      locationOrDefault(in.SourceLocationID),
+		DestinationLocationID: locationOrDefault(in.DestinationLocationID),
+		Status:                model.TransferDraft,
+		Note:                  in.Note,
+	}
+	if t.SourceLocationID == t.DestinationLocationID {
+		return nil, fmt.Errorf("%w: location nguồn và đích phải khác nhau", ErrInvalidTransfer)
+	}
+	if len(in.Lines) == 0 || len(in.Lines) > MaxTransferLines {
+		return nil, fmt.Errorf("%w: cần từ 1 đến %d item", ErrInvalidTransfer, MaxTransferLines)
+	}
+	seen := make(map[string]struct{}, len(in.Lines))
+	for _, line := range in.Lines {
+		if strings.TrimSpace(line.ItemID) == "" || line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: mỗi dòng cần item_id và quantity > 0", ErrInvalidTransfer)
+		}
+		if _, dup := seen[line.ItemID]; dup {
+			return nil, fmt.Errorf("%w: item %s bị lặp", ErrInvalidTransfer, line.ItemID)
+		}
+		seen[line.ItemID] = struct{}{}
+		t.Lines = append(t.Lines, model.TransferLine{ItemID: line.ItemID, Quantity: line.Quantity})
+	}
+	sort.Slice(t.Lines, func(i, j int) bool { return t.Lines[i].ItemID < t.Lines[j].ItemID })
+
+	if err := s.repo.InsertTransferTx(ctx, tx, t); err != nil {
+		return nil, err
+	}
+	return t, s.enqueueEventTx(ctx, tx, model.TransferEventCreated, t, nil)
+}
+
+// ShipTransferTx xuất lệnh draft trong transaction tx: trừ on-hand ở nguồn và ghi số lượng vào in_transit
+// của đích. Mỗi dòng chỉ được lấy từ available-to-promise của nguồn. Caller commit tx rồi gọi InvalidateCache.
+func (s *TransferService) ShipTransferTx(ctx context.Context, tx *sql.Tx, id string, source model.MovementSource) (*model.TransferOrder, error) {
+	t, err := s.lockTransferTx(ctx, tx, id, "ship", model.TransferDraft)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range t.Lines {
+		err := s.moveTx(ctx, tx, t, source, line.ItemID, line.Quantity, model.MovementReasonTransferShip,
+			t.SourceLocationID, model.StockStateOnHand, t.DestinationLocationID, model.StockStateInTransit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	t.Status, t.ShippedAt = model.TransferShipped, &now
+	if err := s.repo.UpdateTransferTx(ctx, tx, t); err != nil {
+		return nil, err
+	}
+	return t, s.enqueueEventTx(ctx, tx, model.TransferEventShipped, t, nil)
+}
+
+// ReceiveTransferTx ghi một lần nhận hàng của lệnh đã xuất trong transaction tx: số lượng nhận chuyển từ in_transit
+// sang on-hand của đích; phần nhận vượt số đang chờ được cộng thẳng vào on-hand. Lệnh đóng (received) khi mọi
+// dòng đã nhận đủ hoặc closeOrder = true; khi đó phần chưa nhận bị loại khỏi in_transit và mọi chênh lệch được
+// ghi vào discrepancy của dòng. Caller commit tx rồi gọi InvalidateCache.
+func (s *TransferService) ReceiveTransferTx(ctx context.Context, tx *sql.Tx, id string, receipts []model.TransferReceipt, closeOrder bool, source model.MovementSource) (*model.TransferOrder, error) {
+	if len(receipts) == 0 && !closeOrder {
+		return nil, fmt.Errorf("%w: cần ít nhất một dòng nhận hàng, hoặc close = true", ErrInvalidTransfer)
+	}
+	t, err := s.lockTransferTx(ctx, tx, id, "receive", model.TransferShipped, model.TransferPartiallyReceived)
+	if err != nil {
+		return nil, err
+	}
+	lineIndex := make(map[string]int, len(t.Lines))
+	for i, line := range t.Lines {
+		lineIndex[line.ItemID] = i
+	}
+	seen := make(map[string]struct{}, len(receipts))
+	for _, r := range receipts {
+		i, ok := lineIndex[r.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: item %s không có trong lệnh", ErrInvalidTransfer, r.ItemID)
+		}
+		if r.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity nhận phải lớn hơn 0", ErrInvalidTransfer)
+		}
+		if _, dup := seen[r.ItemID]; dup {
+			return nil, fmt.Errorf("%w: item %s bị lặp", ErrInvalidTransfer, r.ItemID)
+		}
+		seen[r.ItemID] = struct{}{}
+
+		line := &t.Lines[i]
+		fromTransit := min(r.Quantity, max(line.Quantity-line.ReceivedQuantity, 0))
+		if fromTransit > 0 {
+			err := s.moveTx(ctx, tx, t, source, r.ItemID, fromTransit, model.MovementReasonTransferReceive,
+				t.DestinationLocationID, model.StockStateInTransit, t.DestinationLocationID, model.StockStateOnHand)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if over := r.Quantity - fromTransit; over > 0 {
+			err := s.moveTx(ctx, tx, t, source, r.ItemID, over, model.MovementReasonTransferReceive,
+				"", "", t.DestinationLocationID, model.StockStateOnHand)
+			if err != nil {
+				return nil, err
+			}
+		}
+		line.ReceivedQuantity += r.Quantity
+	}
+
+	complete := true
+	for _, line := range t.Lines {
+		if line.ReceivedQuantity < line.Quantity {
+			complete = false
+		}
+	}
+	t.Status = model.TransferPartiallyReceived
+	if complete || closeOrder {
+		for i := range t.Lines {
+			line := &t.Lines[i]
+			if short := line.Quantity - line.ReceivedQuantity; short > 0 {
+				err := s.moveTx(ctx, tx, t, source, line.ItemID, short, model.MovementReasonTransferDiscrepancy,
+					t.DestinationLocationID, model.StockStateInTransit, "", "")
+				if err != nil {
+					return nil, err
+				}
+			}
+			line.Discrepancy = line.Quantity - line.ReceivedQuantity
+		}
+		now := time.Now()
+		t.Status, t.ClosedAt = model.TransferReceived, &now
+	}
+	if err := s.repo.UpdateTransferTx(ctx, tx, t); err != nil {
+		return nil, err
+	}
+	return t, s.enqueueEventTx(ctx, tx, model.TransferEventReceived, t, receipts)
+}
+
+// CancelTransferTx huỷ lệnh trong transaction tx. Lệnh draft chỉ đổi trạng thái; lệnh đã xuất nhưng chưa nhận
+// dòng nào được trả hàng từ in_transit của đích về on-hand của nguồn. Caller commit tx rồi gọi InvalidateCache.
+func (s *TransferService) CancelTransferTx(ctx context.Context, tx *sql.Tx, id string, source model.MovementSource) (*model.TransferOrder, error) {
+	t, err := s.lockTransferTx(ctx, tx, id, "cancel", model.TransferDraft, model.TransferShipped)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status == model.TransferShipped {
+		for _, line := range t.Lines {
+			err := s.moveTx(ctx, tx, t, source, line.ItemID, line.Quantity, model.MovementReasonTransferCancel,
+				t.DestinationLocationID, model.StockStateInTransit, t.SourceLocationID, model.StockStateOnHand)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	now := time.Now()
+	t.Status, t.ClosedAt = model.TransferCancelled, &now
+	if err := s.repo.UpdateTransferTx(ctx, tx, t); err != nil {
+		return nil, err
+	}
+	return t, s.enqueueEventTx(ctx, tx, model.TransferEventCancelled, t, nil)
+}
+
+// GetTransfer trả về lệnh chuyển kho theo ID.
+func (s *TransferService) GetTransfer(ctx context.Context, id string) (*model.TransferOrder, error) {
+	return s.repo.GetTransfer(ctx, id)
+}
+
+// ListTransfers trả về các lệnh chuyển kho, mới nhất trước.
+func (s *TransferService) ListTransfers(ctx context.Context, opts repository.TransferListOptions) ([]*model.TransferOrder, string, error) {
+	switch opts.Status {
+	case "", model.TransferDraft, model.TransferShipped, model.TransferPartiallyReceived, model.TransferReceived, model.TransferCancelled:
+	default:
+		return nil, "", fmt.Errorf("%w: status %q không hợp lệ", ErrInvalidTransfer, opts.Status)
+	}
+	return s.repo.ListTransfers(ctx, opts)
+}
+
+// InvalidateCache xoá cache của các item trong lệnh chuyển kho.
+func (s *TransferService) InvalidateCache(ctx context.Context, t *model.TransferOrder) {
+	for _, line := range t.Lines {
+		s.inventory.InvalidateCache(ctx, line.ItemID)
+	}
+}
+
+// lockTransferTx lock lệnh và kiểm tra trạng thái hiện tại thuộc allowed, rồi lock các item của lệnh
+// theo thứ tự ID để các lệnh song song trên cùng item không deadlock.
+func (s *TransferService) lockTransferTx(ctx context.Context, tx *sql.Tx, id, operation string, allowed ...model.TransferStatus) (*model.TransferOrder, error) {
+	t, err := s.repo.LockTransferTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	ok := false
+	for _, status := range allowed {
+		if t.Status == status {
+			ok = true
+		}
+	}
+	if !ok {
+		return nil, &repository.TransferStateError{TransferID: id, Status: t.Status, Operation: operation}
+	}
+	itemIDs := make([]string, 0, len(t.Lines))
+	for _, line := range t.Lines {
+		itemIDs = append(itemIDs, line.ItemID)
+	}
+	return t, s.inventory.repo.LockItemsTx(ctx, tx, itemIDs)
+}
+
+// moveTx chuyển quantity của item từ fromState tại fromLocation sang toState tại toLocation; movement được ghi
+// với correlation ID là ID của lệnh.
+func (s *TransferService) moveTx(ctx context.Context, tx *sql.Tx, t *model.TransferOrder, source model.MovementSource, itemID string, quantity int, reason model.MovementReason,
+	fromLocation string, fromState model.StockState, toLocation string, toState model.StockState) error {
+	if fromLocation == "" {
+		fromLocation = toLocation
+	}
+	_, err := s.inventory.moveStockTx(ctx, tx, repository.StockTransition{
+		ItemID:        itemID,
+		LocationID:    fromLocation,
+		ToLocationID:  toLocation,
+		From:          fromState,
+		To:            toState,
+		Quantity:      quantity,
+		Reason:        reason,
+		Source:        source,
+		CorrelationID: t.ID,
+	})
+	return err
+}
+
+// enqueueEventTx ghi TransferEvent của bước eventType vào outbox, key theo ID lệnh để giữ thứ tự.
+func (s *TransferService) enqueueEventTx(ctx context.Context, tx *sql.Tx, eventType model.TransferEventType, t *model.TransferOrder, received []model.TransferReceipt) error {
+	if s.topic == "" {
+		return nil
+	}
+	payload, err := json.Marshal(model.TransferEvent{
+		EventID:     idUtils.NewID(),
+		Type:        eventType,
+		TransferID:  t.ID,
+		Source:      t.SourceLocationID,
+		Destination: t.DestinationLocationID,
+		Status:      t.Status,
+		Lines:       t.Lines,
+		Received:    received,
+		DateTime:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return repository.EnqueueOutboxTx(ctx, tx, s.topic, t.ID, payload)
+}
+
+func locationOrDefault(locationID string) string {
+	if locationID == "" {
+		return model.DefaultLocationID
+	}
+	return locationID
+}
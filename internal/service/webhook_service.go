@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+// ErrInvalidWebhook được trả về khi URL hoặc danh sách loại event của subscription không hợp lệ.
+var ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+// WebhookSubscriptionInput là dữ liệu tạo/cập nhật subscription.
+// Secret rỗng: khi tạo thì sinh secret ngẫu nhiên, khi cập nhật thì giữ secret cũ (trừ khi RotateSecret).
+type WebhookSubscriptionInput struct {
+	URL          string
+	EventTypes   []model.InventoryEventType
+	Secret       string
+	Active       bool
+	RotateSecret bool
+}
+
+type WebhookService struct {
+	repo *repository.WebhookRepository
+}
+
+func NewWebhookService(repo *repository.WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo}
+}
+
+func validateWebhookInput(in WebhookSubscriptionInput) error {
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url phải là URL http(s) tuyệt đối", ErrInvalidWebhook)
+	}
+	for _, t := range in.EventTypes {
+		switch t {
+		case model.EventTypeCreate, model.EventTypeUpdate, model.EventTypeDelete:
+		default:
+			return fmt.Errorf("%w: event type %q không hợp lệ", ErrInvalidWebhook, t)
+		}
+	}
+	return nil
+}
+
+// newWebhookSecret sinh secret 256-bit dạng hex.
+func newWebhookSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Create tạo subscription mới. Secret được trả về trong kết quả, đây là lần duy nhất client thấy nó
+// (ngoài lần đổi secret).
+func (s *WebhookService) Create(ctx context.Context, in WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	if err := validateWebhookInput(in); err != nil {
+		return nil, err
+	}
+	sub := &model.WebhookSubscription{
+		ID:         idUtils.NewID(),
+		URL:        in.URL,
+		EventTypes: in.EventTypes,
+		Secret:     in.Secret,
+		Active:     in.Active,
+	}
+	if sub.EventTypes == nil {
+		sub.EventTypes = []model.InventoryEventType{}
+	}
+	if sub.Secret == "" {
+		sub.Secret = newWebhookSecret()
+	}
+	if err := s.repo.CreateWebhookSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	return s.repo.GetWebhookSubscription(ctx, id)
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return s.repo.ListWebhookSubscriptions(ctx)
+}
+
+// Update thay toàn bộ cấu hình của subscription. Secret mới (truyền vào hoặc sinh khi RotateSecret)
+// được trả về trong kết quả.
+func (s *WebhookService) Update(ctx context.Context, id string, in WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	if err := validateWebhookInput(in); err != nil {
+		return nil, err
+	}
+	sub := &model.WebhookSubscription{
+		ID:         id,
+		URL:        in.URL,
+		EventTypes: in.EventTypes,
+		Secret:     in.Secret,
+		Active:     in.Active,
+	}
+	if sub.EventTypes == nil {
+		sub.EventTypes = []model.InventoryEventType{}
+	}
+	if sub.Secret == "" && in.RotateSecret {
+		sub.Secret = newWebhookSecret()
+	}
+	if err := s.repo.UpdateWebhookSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id string) error {
+	return s.repo.DeleteWebhookSubscription(ctx, id)
+}
+
+// ListDeliveries trả về nhật ký gửi của subscription; status rỗng = mọi trạng thái.
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID string, status model.WebhookDeliveryStatus, cursor string, limit int) ([]*model.WebhookDelivery, string, error) {
+	switch status {
+	case "", model.WebhookDeliveryPending, model.WebhookDeliveryDelivered, model.WebhookDeliveryFailed:
+	default:
+		return nil, "", fmt.Errorf("%w: status %q không hợp lệ", ErrInvalidWebhook, status)
+	}
+	if _, err := s.repo.GetWebhookSubscription(ctx, subscriptionID); err != nil {
+		return nil, "", err
+	}
+	return s.repo.ListWebhookDeliveries(ctx, subscriptionID, string(status), cursor, limit)
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, subscriptionID string, id int64) (*model.WebhookDelivery, error) {
+	return s.repo.GetWebhookDelivery(ctx, subscriptionID, id)
+}
+
+// Redeliver đưa delivery (kể cả đã delivered hoặc failed) về hàng đợi để gửi lại ngay.
+func (s *WebhookService) Redeliver(ctx context.Context, subscriptionID string, id int64) (*model.WebhookDelivery, error) {
+	return s.repo.RedeliverWebhookDelivery(ctx, subscriptionID, id)
+}
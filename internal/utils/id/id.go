@@ -0,0 +1,15 @@
+package idUtils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewID sinh một ID ngẫu nhiên 128-bit dạng hex, dùng cho reservation, event, ...
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
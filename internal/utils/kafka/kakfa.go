@@ -18,3 +18,18 @@ func WriteMessageWrapper(ctx context.Context, writer *kafka.Writer, key []byte,
 		Value: value,
 	})
 }
+
+// FetchMessageWrapper bọc hàm FetchMessage của kafka.Reader; offset không được tự động commit.
+func FetchMessageWrapper(ctx context.Context, reader *kafka.Reader) (kafka.Message, error) {
+	return reader.FetchMessage(ctx)
+}
+
+// CommitMessagesWrapper bọc hàm CommitMessages của kafka.Reader.
+func CommitMessagesWrapper(ctx context.Context, reader *kafka.Reader, msgs ...kafka.Message) error {
+	return reader.CommitMessages(ctx, msgs...)
+}
+
+// WriteKafkaMessageWrapper gửi một message đầy đủ (topic, header, ...) qua kafka.Writer.
+func WriteKafkaMessageWrapper(ctx context.Context, writer *kafka.Writer, msg kafka.Message) error {
+	return writer.WriteMessages(ctx, msg)
+}
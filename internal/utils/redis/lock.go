@@ -0,0 +1,164 @@
+package redisUtils
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	idUtils "inventory-service.com/m/internal/utils/id"
+)
+
+var (
+	// ErrLockNotObtained được trả về khi lock đang được một owner khác giữ.
+	ErrLockNotObtained = errors.New("lock not obtained")
+	// ErrLockNotHeld được trả về khi release một lock đã hết hạn hoặc đã thuộc owner khác.
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// obtainScript đặt lock với owner token nếu key chưa tồn tại, rồi tăng fencing token của key.
+// Trả về fencing token mới, hoặc 0 nếu lock đang bị giữ. KEYS[1] và KEYS[2] có chung hash tag
+// (xem lockKeys) nên nằm cùng slot trên Redis Cluster.
+var obtainScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+// refreshScript gia hạn lease nếu lock vẫn thuộc owner token.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript chỉ xoá lock nếu lock vẫn thuộc owner token (compare-and-delete).
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock là một distributed lock trong Redis với owner token ngẫu nhiên và fencing token tăng dần.
+// Lease được tự động gia hạn cho tới khi Release; nếu lock bị mất (hết hạn hoặc bị owner khác lấy),
+// Context() bị hủy để công việc đang chạy dừng lại.
+type Lock struct {
+	client *redis.Client
+	key    string
+	token  string
+	fence  int64
+	ttl    time.Duration
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stop    chan struct{}
+	stopped sync.Once
+	done    chan struct{}
+}
+
+// ObtainLock lấy lock name với lease ttl. Trả về ErrLockNotObtained nếu lock đang bị giữ.
+// Context() của lock được dẫn xuất từ ctx.
+func ObtainLock(ctx context.Context, client *redis.Client, name string, ttl time.Duration) (*Lock, error) {
+	token := idUtils.NewID()
+	key, fence := lockKeys(name)
+	fenceToken, err := obtainScript.Run(ctx, client, []string{key, fence}, token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, err
+	}
+	if fenceToken == 0 {
+		return nil, ErrLockNotObtained
+	}
+
+	lockCtx, cancel := context.WithCancel(ctx)
+	l := &Lock{
+		client: client,
+		key:    key,
+		token:  token,
+		fence:  fenceToken,
+		ttl:    ttl,
+		ctx:    lockCtx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go l.renew()
+	return l, nil
+}
+
+// lockKeys trả về key của lock và key lưu fencing token, cả hai hash tag theo name ({<name>}) để
+// obtainScript chạy được trên Redis Cluster.
+// Key fence cố ý không hết hạn và không bị xoá: nếu xoá, token sẽ bắt đầu lại từ 1 và bị
+// CheckFenceTx từ chối vì nhỏ hơn token đã ghi. Mỗi name chỉ có một key nên số key bị chặn
+// bởi số resource (ví dụ số item).
+func lockKeys(name string) (key, fence string) {
+	key = "{" + name + "}"
+	return key, key + ":fence"
+}
+
+// Key trả về key Redis của lock; dùng làm resource khi ghi fencing token.
+func (l *Lock) Key() string { return l.key }
+
+// Token trả về owner token của lock.
+func (l *Lock) Token() string { return l.token }
+
+// Fence trả về fencing token của lần lấy lock này. Token lớn hơn nghĩa là lấy lock sau;
+// các thao tác ghi nên từ chối token nhỏ hơn token đã thấy.
+func (l *Lock) Fence() int64 { return l.fence }
+
+// Context bị hủy khi lock bị mất hoặc đã được release.
+func (l *Lock) Context() context.Context { return l.ctx }
+
+// Release dừng gia hạn và xoá lock nếu lock vẫn thuộc owner này.
+// Trả về ErrLockNotHeld nếu lock đã hết hạn hoặc đã thuộc owner khác.
+func (l *Lock) Release(ctx context.Context) error {
+	l.stopped.Do(func() { close(l.stop) })
+	<-l.done
+	l.cancel()
+
+	n, err := releaseScript.Run(ctx, l.client, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// renew gia hạn lease mỗi ttl/3. Lock được coi là mất nếu owner token không còn khớp,
+// hoặc không gia hạn được trong suốt một ttl.
+func (l *Lock) renew() {
+	defer close(l.done)
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	lastRenewed := time.Now()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := refreshScript.Run(l.ctx, l.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int64()
+			switch {
+			case err == nil && n == 1:
+				lastRenewed = time.Now()
+				continue
+			case err == nil:
+				log.Printf("Mất lock %s: lock đã hết hạn hoặc thuộc owner khác", l.key)
+			case time.Since(lastRenewed) < l.ttl:
+				log.Printf("Lỗi gia hạn lock %s, thử lại: %v", l.key, err)
+				continue
+			default:
+				log.Printf("Mất lock %s: không gia hạn được trong %s: %v", l.key, l.ttl, err)
+			}
+			l.cancel()
+			return
+		}
+	}
+}
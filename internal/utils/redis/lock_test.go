@@ -0,0 +1,110 @@
+package redisUtils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestClient(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return client, mr
+}
+
+func TestObtainLock(t *testing.T) {
+	client, mr := newTestClient(t)
+	ctx := context.Background()
+
+	first, err := ObtainLock(ctx, client, "lock:inventory:sku-1", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Key() != "{lock:inventory:sku-1}" || !mr.Exists("{lock:inventory:sku-1}:fence") {
+		t.Errorf("key = %s, lock and fence keys must share the {name} hash tag", first.Key())
+	}
+	if _, err := ObtainLock(ctx, client, "lock:inventory:sku-1", time.Second); !errors.Is(err, ErrLockNotObtained) {
+		t.Fatalf("second ObtainLock() error = %v, want ErrLockNotObtained", err)
+	}
+	if err := first.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if first.Context().Err() == nil {
+		t.Error("lock context must be cancelled after Release")
+	}
+
+	second, err := ObtainLock(ctx, client, "lock:inventory:sku-1", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Release(ctx)
+	if second.Fence() <= first.Fence() {
+		t.Errorf("fence = %d after %d, fencing tokens must increase", second.Fence(), first.Fence())
+	}
+	if second.Token() == first.Token() {
+		t.Error("owner tokens must differ between owners")
+	}
+}
+
+func TestLockLease(t *testing.T) {
+	const ttl = 150 * time.Millisecond
+	tests := []struct {
+		name string
+		// disturb mô phỏng sự cố sau khi lấy lock; nil = lock được giữ bình thường.
+		disturb     func(mr *miniredis.Miniredis, key string)
+		wantLost    bool
+		wantRelease error
+	}{
+		{
+			name: "lease is renewed while held",
+			disturb: func(mr *miniredis.Miniredis, key string) {
+				mr.SetTTL(key, time.Millisecond) // chỉ còn lại ít, lần gia hạn kế tiếp đặt lại ttl
+			},
+		},
+		{
+			name:        "lock taken by another owner",
+			disturb:     func(mr *miniredis.Miniredis, key string) { mr.Set(key, "other-owner") },
+			wantLost:    true,
+			wantRelease: ErrLockNotHeld,
+		},
+		{
+			name: "renewal keeps failing for a whole ttl",
+			disturb: func(mr *miniredis.Miniredis, _ string) {
+				mr.SetError("LOADING Redis is loading the dataset in memory")
+			},
+			wantLost: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mr := newTestClient(t)
+			lock, err := ObtainLock(context.Background(), client, "lock:inventory:sku-1", ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.disturb(mr, lock.Key())
+
+			select {
+			case <-lock.Context().Done():
+			case <-time.After(3 * ttl):
+			}
+			if lost := lock.Context().Err() != nil; lost != tt.wantLost {
+				t.Fatalf("lost = %v, want %v", lost, tt.wantLost)
+			}
+			if !tt.wantLost && mr.TTL(lock.Key()) != ttl {
+				t.Errorf("TTL = %s, want the lease renewed to %s", mr.TTL(lock.Key()), ttl)
+			}
+
+			mr.SetError("")
+			if err := lock.Release(context.Background()); !errors.Is(err, tt.wantRelease) {
+				t.Errorf("Release() error = %v, want %v", err, tt.wantRelease)
+			}
+		})
+	}
+}
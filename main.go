@@ -16,6 +16,9 @@ import (
 	"inventory-service.com/m/internal/db"
 	"inventory-service.com/m/internal/events"
 	grpcServer "inventory-service.com/m/internal/grpc" // Giả sử file grpc_server.go nằm trong package main của cmd/inventory
+	"inventory-service.com/m/internal/model"
+	"inventory-service.com/m/internal/repository"
+	"inventory-service.com/m/internal/service"
 )
 
 func main() {
@@ -55,8 +58,45 @@ func main() {
 	}
 	defer dlqWriter.Close()
 
+	// Outbox relay publish các event đã ghi trong transaction lên Kafka.
+	outboxWriter := events.InitKafkaWriter(cfg.KafkaBroker)
+	defer outboxWriter.Close()
+
+	// Idempotency-Key của REST và gRPC được lưu trong Postgres trong IdempotencyTTL.
+	idempotencySvc := service.NewIdempotencyService(repository.NewIdempotencyRepository(dbConn), cfg.IdempotencyTTL)
+
+	// Các thay đổi tồn kho từ HTTP và gRPC đều đi qua InventoryService: ghi outbox và invalidate cache.
+	inventoryCache := cache.NewInventoryCache(redisClient, cache.InventoryCacheConfig{
+		TTL:         cfg.CacheTTL,
+		NegativeTTL: cfg.CacheNegativeTTL,
+		Jitter:      float64(cfg.CacheTTLJitterPercent) / 100,
+		LockTTL:     cfg.CacheLockTTL,
+	})
+	// Mọi thay đổi tồn kho (HTTP, gRPC, consumer, reservation) đi qua cùng một InventoryRepository,
+	// nơi cảnh báo low_stock/out_of_stock/back_in_stock được ghi vào outbox.
+	inventoryRepo := repository.NewInventoryRepository(dbConn, repository.StockAlertConfig{
+		Topic:             cfg.StockAlertTopic,
+		HysteresisPercent: cfg.StockAlertHysteresisPercent,
+	})
+	inventorySvc := service.NewInventoryService(dbConn, inventoryRepo, inventoryCache, cfg.KafkaTopic)
+
+	// WatchInventory (gRPC) và /items/stream, /items/ws (HTTP) nhận thay đổi qua Redis pub/sub,
+	// được publish mỗi khi cache của item bị invalidate.
+	inventoryWatcher := service.NewInventoryWatcher(redisClient, inventorySvc, cfg.WatchResyncInterval)
+
+	// Webhook subscription nhận các InventoryEvent mà consumer đã xử lý.
+	webhookRepo := repository.NewWebhookRepository(dbConn)
+	webhookSvc := service.NewWebhookService(webhookRepo)
+
+	// Lệnh chuyển kho thay đổi tồn kho qua InventoryService và publish event từng bước lên TransferTopic.
+	transferSvc := service.NewTransferService(repository.NewTransferRepository(dbConn), inventorySvc, cfg.TransferTopic)
+	countSvc := service.NewCountService(repository.NewCountRepository(dbConn), inventorySvc, cfg.CycleCountApprovalThresholdPercent)
+
 	// 6. Thiết lập Gin router.
-	router := handler.SetupRouter(dbConn, redisClient, kafkaProducer)
+	router := handler.SetupRouter(dbConn, redisClient, kafkaProducer, inventorySvc, idempotencySvc, inventoryWatcher, webhookSvc, transferSvc, countSvc, handler.StreamConfig{
+		HeartbeatInterval: cfg.StreamHeartbeatInterval,
+		WriteTimeout:      cfg.StreamWriteTimeout,
+	})
 
 	// 7. Tạo HTTP server với graceful shutdown.
 	httpSrv := &http.Server{
@@ -68,15 +108,46 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 9. Khởi chạy consumer chính và DLQ consumer trong các goroutine riêng.
+	// 9. Khởi chạy consumer chính, các retry consumer và DLQ consumer trong các goroutine riêng.
+	retryPolicy := consumer.RetryPolicy{MaxRetries: make(map[model.InventoryEventType]int)}
+	for _, delay := range cfg.RetryDelays {
+		retryPolicy.Tiers = append(retryPolicy.Tiers, consumer.RetryTier{
+			Topic: consumer.RetryTopicName(cfg.KafkaTopic, delay),
+			Delay: delay,
+		})
+	}
+	for eventType, n := range cfg.RetryMaxRetries {
+		retryPolicy.MaxRetries[model.InventoryEventType(eventType)] = n
+	}
+	retryWriter := events.InitKafkaWriter(cfg.KafkaBroker)
+	defer retryWriter.Close()
+
 	workerCount := 5 // Số lượng worker cho consumer.
-	invConsumer := consumer.NewInventoryConsumer(dbConn, redisClient, kafkaReader, dlqWriter, workerCount)
+	invConsumer := consumer.NewInventoryConsumer(dbConn, inventoryRepo, redisClient, kafkaReader, dlqWriter, retryWriter, retryPolicy, workerCount)
 	go invConsumer.Start(ctx)
+	for _, tier := range retryPolicy.Tiers {
+		go invConsumer.StartRetryConsumer(ctx, events.InitKafkaReader(cfg.KafkaBroker, tier.Topic), tier)
+	}
 	go invConsumer.StartDLQConsumer(ctx, dlqReader)
+	go invConsumer.StartProcessedEventsPurger(ctx, cfg.ProcessedEventsRetention, cfg.ProcessedEventsPurgeInterval)
+
+	// Reservation service và worker expire các reservation quá TTL.
+	reservationSvc := service.NewReservationService(repository.NewReservationRepository(dbConn), inventorySvc, cfg.ReservationTTL)
+	go reservationSvc.StartExpiryWorker(ctx, cfg.ReservationSweepInterval)
+
+	outboxRelay := events.NewOutboxRelay(repository.NewOutboxRepository(dbConn), outboxWriter, cfg.OutboxBatchSize, cfg.OutboxPollInterval)
+	go outboxRelay.Start(ctx)
+
+	webhookDispatcher := events.NewWebhookDispatcher(webhookRepo, cfg.WebhookBatchSize, cfg.WebhookMaxAttempts, cfg.WebhookPollInterval, cfg.WebhookTimeout)
+	go webhookDispatcher.Start(ctx)
+
+	go idempotencySvc.StartPurgeWorker(ctx, cfg.IdempotencyPurgeInterval)
+
+	go inventoryWatcher.Start(ctx)
 
 	// 10. Khởi chạy gRPC server trên cổng cấu hình (ví dụ: ":3").
 	grpcStop := make(chan struct{})
-	go grpcServer.StartGRPCServer(dbConn, cfg.GRPCPort, grpcStop)
+	go grpcServer.StartGRPCServer(dbConn, inventorySvc, inventoryWatcher, reservationSvc, transferSvc, countSvc, idempotencySvc, cfg.GRPCPort, grpcStop)
 
 	// 11. Khởi chạy HTTP server trong goroutine riêng.
 	go func() {
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reservations_pending_expires_at;
+
+DROP INDEX IF EXISTS idx_reservations_item_status;
+
+DROP TABLE IF EXISTS inventory_reservations;
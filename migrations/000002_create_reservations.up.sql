@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS inventory_reservations (
+    id VARCHAR(64) PRIMARY KEY,
+    item_id VARCHAR(255) NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    order_id VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reservations_item_status ON inventory_reservations(item_id, status);
+CREATE INDEX idx_reservations_pending_expires_at ON inventory_reservations(expires_at) WHERE status = 'pending';
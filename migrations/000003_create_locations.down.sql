@@ -0,0 +1,7 @@
+ALTER TABLE inventory_reservations DROP COLUMN IF EXISTS location_id;
+
+DROP INDEX IF EXISTS idx_inventory_locations_location_id;
+
+DROP TABLE IF EXISTS inventory_locations;
+
+DROP TABLE IF EXISTS locations;
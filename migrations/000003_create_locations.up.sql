@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS locations (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO locations (id, name) VALUES ('default', 'Default warehouse') ON CONFLICT (id) DO NOTHING;
+
+-- Tồn kho theo từng (item, location). inventory.quantity là tổng của tất cả location.
+CREATE TABLE IF NOT EXISTS inventory_locations (
+    item_id VARCHAR(255) NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    location_id VARCHAR(64) NOT NULL REFERENCES locations(id),
+    quantity INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, location_id)
+);
+
+CREATE INDEX idx_inventory_locations_location_id ON inventory_locations(location_id);
+
+-- Dữ liệu cũ được chuyển vào location mặc định.
+INSERT INTO inventory_locations (item_id, location_id, quantity)
+SELECT id, 'default', quantity FROM inventory
+ON CONFLICT (item_id, location_id) DO NOTHING;
+
+ALTER TABLE inventory_reservations ADD COLUMN IF NOT EXISTS location_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES locations(id);
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trg_stock_movements_append_only ON stock_movements;
+
+DROP FUNCTION IF EXISTS stock_movements_append_only();
+
+DROP INDEX IF EXISTS idx_stock_movements_correlation_id;
+
+DROP INDEX IF EXISTS idx_stock_movements_item_id;
+
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,27 @@
+-- Sổ cái append-only ghi lại mọi thay đổi số lượng tồn kho.
+-- Không có foreign key tới inventory để lịch sử vẫn còn sau khi item bị xoá.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    item_id VARCHAR(255) NOT NULL,
+    location_id VARCHAR(64) NOT NULL,
+    delta INT NOT NULL,
+    balance INT NOT NULL,
+    total_balance INT NOT NULL,
+    reason VARCHAR(64) NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    correlation_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_item_id ON stock_movements(item_id, id DESC);
+CREATE INDEX idx_stock_movements_correlation_id ON stock_movements(correlation_id);
+
+CREATE OR REPLACE FUNCTION stock_movements_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_append_only
+BEFORE UPDATE OR DELETE ON stock_movements
+FOR EACH ROW EXECUTE FUNCTION stock_movements_append_only();
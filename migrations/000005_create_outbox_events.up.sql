@@ -0,0 +1,17 @@
+-- Outbox: event được ghi trong cùng transaction với thay đổi dữ liệu,
+-- sau đó relay trong internal/events publish lên Kafka (at-least-once).
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    message_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(message_key, id) WHERE status = 'pending';
+CREATE INDEX idx_outbox_events_sent_at ON outbox_events(sent_at) WHERE status = 'sent';
@@ -0,0 +1,11 @@
+-- Lưu ID của các event Kafka đã xử lý, ghi trong cùng transaction với thay đổi tồn kho
+-- để consumer bỏ qua event bị giao lại (redelivery, reprocess từ DLQ).
+CREATE TABLE IF NOT EXISTS processed_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(32) NOT NULL,
+    item_id VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Phục vụ việc dọn các processed_events đã quá thời gian giữ (xem PROCESSED_EVENTS_RETENTION).
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events(processed_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_dead_letters_status;
+
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,22 @@
+-- Lưu trữ bền vững các event bị dead-letter để ops có thể xem, sửa và redrive qua REST API.
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL DEFAULT '',
+    event_type VARCHAR(32) NOT NULL DEFAULT '',
+    item_id VARCHAR(255) NOT NULL DEFAULT '',
+    message_key VARCHAR(255) NOT NULL DEFAULT '',
+    payload BYTEA NOT NULL,
+    original_payload BYTEA NOT NULL,
+    error_chain JSONB NOT NULL DEFAULT '[]',
+    attempt_history JSONB NOT NULL DEFAULT '[]',
+    source_topic VARCHAR(255) NOT NULL,
+    source_partition INT NOT NULL,
+    source_offset BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    redrive_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (source_topic, source_partition, source_offset)
+);
+
+CREATE INDEX idx_dead_letters_status ON dead_letters(status, id DESC);
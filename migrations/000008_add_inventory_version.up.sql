@@ -0,0 +1,2 @@
+-- Version tăng sau mỗi thay đổi của item, dùng cho optimistic concurrency (ETag/If-Match).
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,15 @@
+-- Lưu fingerprint và response của các request mutation có Idempotency-Key,
+-- để request retry với cùng key được trả lại response cũ thay vì áp dụng thay đổi lần nữa.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(64) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    response_status INT NOT NULL,
+    response_body BYTEA NOT NULL,
+    response_etag VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
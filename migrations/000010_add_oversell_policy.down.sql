@@ -0,0 +1,8 @@
+ALTER TABLE inventory_locations DROP CONSTRAINT IF EXISTS inventory_locations_stock_check;
+ALTER TABLE inventory_locations DROP CONSTRAINT IF EXISTS inventory_locations_oversell_policy_check;
+ALTER TABLE inventory_locations DROP COLUMN IF EXISTS backorder_limit;
+ALTER TABLE inventory_locations DROP COLUMN IF EXISTS oversell_policy;
+
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_oversell_policy_check;
+ALTER TABLE inventory DROP COLUMN IF EXISTS backorder_limit;
+ALTER TABLE inventory DROP COLUMN IF EXISTS oversell_policy;
@@ -0,0 +1,18 @@
+-- Chính sách oversell: deny (không cho âm), backorder (cho âm tới backorder_limit), unlimited.
+-- Cột trên inventory là mặc định của item; cột trên inventory_locations là chính sách hiệu lực tại từng location.
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS oversell_policy VARCHAR(16) NOT NULL DEFAULT 'deny';
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS backorder_limit INT NOT NULL DEFAULT 0;
+ALTER TABLE inventory ADD CONSTRAINT inventory_oversell_policy_check
+    CHECK (oversell_policy IN ('deny', 'backorder', 'unlimited') AND backorder_limit >= 0);
+
+ALTER TABLE inventory_locations ADD COLUMN IF NOT EXISTS oversell_policy VARCHAR(16) NOT NULL DEFAULT 'deny';
+ALTER TABLE inventory_locations ADD COLUMN IF NOT EXISTS backorder_limit INT NOT NULL DEFAULT 0;
+ALTER TABLE inventory_locations ADD CONSTRAINT inventory_locations_oversell_policy_check
+    CHECK (oversell_policy IN ('deny', 'backorder', 'unlimited') AND backorder_limit >= 0);
+
+-- Dữ liệu cũ đang âm được chuyển sang backorder với hạn mức bằng mức âm hiện tại.
+UPDATE inventory_locations SET oversell_policy = 'backorder', backorder_limit = -quantity WHERE quantity < 0;
+
+ALTER TABLE inventory_locations ADD CONSTRAINT inventory_locations_stock_check
+    CHECK (oversell_policy = 'unlimited'
+        OR quantity >= CASE WHEN oversell_policy = 'backorder' THEN -backorder_limit ELSE 0 END);
@@ -0,0 +1,7 @@
+-- Fencing token lớn nhất đã ghi cho mỗi resource được bảo vệ bởi distributed lock.
+-- Thao tác ghi mang token nhỏ hơn (của owner cũ có lock đã hết hạn) bị từ chối.
+CREATE TABLE IF NOT EXISTS lock_fences (
+    resource VARCHAR(255) PRIMARY KEY,
+    fence BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_stock_alert_check;
+ALTER TABLE inventory DROP COLUMN IF EXISTS stock_alert_state;
+ALTER TABLE inventory DROP COLUMN IF EXISTS safety_stock;
+ALTER TABLE inventory DROP COLUMN IF EXISTS reorder_point;
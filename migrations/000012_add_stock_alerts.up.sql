@@ -0,0 +1,8 @@
+-- Ngưỡng cảnh báo tồn kho của item; reorder_point NULL = không cảnh báo.
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS reorder_point INT;
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS safety_stock INT;
+-- Trạng thái cảnh báo hiện tại (ok, low, out). Event chỉ được phát khi trạng thái đổi,
+-- và việc thoát khỏi low/out cần vượt ngưỡng một khoảng (hysteresis) để cảnh báo không bị bật tắt liên tục.
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS stock_alert_state VARCHAR(8) NOT NULL DEFAULT 'ok';
+ALTER TABLE inventory ADD CONSTRAINT inventory_stock_alert_check
+    CHECK (reorder_point >= 0 AND safety_stock >= 0 AND stock_alert_state IN ('ok', 'low', 'out'));
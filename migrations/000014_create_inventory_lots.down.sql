@@ -0,0 +1,3 @@
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_lot_allocation_policy_check;
+ALTER TABLE inventory DROP COLUMN IF EXISTS lot_allocation_policy;
+DROP TABLE IF EXISTS inventory_lots;
@@ -0,0 +1,22 @@
+-- Lô hàng của item tại từng location. Tổng quantity các lô không vượt quá tồn kho tại location;
+-- phần còn lại là hàng không theo lô. Lô hết hàng được giữ lại để tra cứu.
+CREATE TABLE IF NOT EXISTS inventory_lots (
+    id BIGSERIAL PRIMARY KEY,
+    item_id VARCHAR(255) NOT NULL,
+    location_id VARCHAR(64) NOT NULL,
+    lot_number VARCHAR(128) NOT NULL,
+    manufactured_at DATE,
+    expires_at DATE,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (item_id, location_id) REFERENCES inventory_locations(item_id, location_id) ON DELETE CASCADE,
+    UNIQUE (item_id, location_id, lot_number)
+);
+
+CREATE INDEX idx_inventory_lots_expires_at ON inventory_lots(expires_at, id) WHERE quantity > 0;
+
+-- Thứ tự lấy hàng khi giảm tồn kho: fefo (hết hạn trước xuất trước) hoặc fifo (nhập trước xuất trước).
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS lot_allocation_policy VARCHAR(8) NOT NULL DEFAULT 'fefo';
+ALTER TABLE inventory ADD CONSTRAINT inventory_lot_allocation_policy_check
+    CHECK (lot_allocation_policy IN ('fefo', 'fifo'));
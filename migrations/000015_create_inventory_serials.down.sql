@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_stock_movements_serial_number;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS serial_number;
+DROP TABLE IF EXISTS inventory_serials;
+ALTER TABLE inventory DROP COLUMN IF EXISTS serialized;
@@ -0,0 +1,21 @@
+-- Item serialized có số lượng bằng số serial ở trạng thái available; mọi thay đổi số lượng
+-- của item này phải đi kèm một serial cụ thể.
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Trạng thái hiện tại của từng serial. location_id là nơi serial đang ở (hoặc được xuất đi),
+-- order_id là đơn đang giữ/đã nhận serial. Lịch sử đầy đủ nằm trong stock_movements.
+CREATE TABLE IF NOT EXISTS inventory_serials (
+    item_id VARCHAR(255) NOT NULL REFERENCES inventory(id) ON DELETE CASCADE,
+    serial_number VARCHAR(128) NOT NULL,
+    location_id VARCHAR(64) NOT NULL REFERENCES locations(id),
+    status VARCHAR(16) NOT NULL DEFAULT 'available' CHECK (status IN ('available', 'reserved', 'shipped')),
+    order_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, serial_number)
+);
+
+CREATE INDEX idx_inventory_serials_status ON inventory_serials(item_id, status, location_id);
+
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS serial_number VARCHAR(128) NOT NULL DEFAULT '';
+CREATE INDEX idx_stock_movements_serial_number ON stock_movements(item_id, serial_number, id) WHERE serial_number <> '';
@@ -0,0 +1,5 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS stock_state;
+ALTER TABLE inventory_locations DROP CONSTRAINT IF EXISTS inventory_locations_states_check;
+ALTER TABLE inventory_locations DROP COLUMN IF EXISTS damaged;
+ALTER TABLE inventory_locations DROP COLUMN IF EXISTS quarantined;
+ALTER TABLE inventory_locations DROP COLUMN IF EXISTS in_transit;
@@ -0,0 +1,11 @@
+-- Tồn kho theo trạng thái tại từng location. quantity là on-hand (hàng tốt trong kho);
+-- in_transit là hàng đang chuyển tới location, quarantined là hàng chờ QA, damaged là hàng hỏng.
+-- reserved và available-to-promise được tính từ reservation/serial, không lưu.
+ALTER TABLE inventory_locations ADD COLUMN IF NOT EXISTS in_transit INT NOT NULL DEFAULT 0;
+ALTER TABLE inventory_locations ADD COLUMN IF NOT EXISTS quarantined INT NOT NULL DEFAULT 0;
+ALTER TABLE inventory_locations ADD COLUMN IF NOT EXISTS damaged INT NOT NULL DEFAULT 0;
+ALTER TABLE inventory_locations ADD CONSTRAINT inventory_locations_states_check
+    CHECK (in_transit >= 0 AND quarantined >= 0 AND damaged >= 0);
+
+-- Trạng thái mà delta/balance của movement áp dụng; các movement cũ đều là on_hand.
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS stock_state VARCHAR(16) NOT NULL DEFAULT 'on_hand';
@@ -0,0 +1,9 @@
+-- Lô ngoài on-hand không còn chỗ lưu khi quay lại schema cũ.
+DELETE FROM inventory_lots WHERE stock_state <> 'on_hand';
+ALTER TABLE inventory_lots DROP CONSTRAINT IF EXISTS inventory_lots_item_location_state_lot_key;
+ALTER TABLE inventory_lots ADD CONSTRAINT inventory_lots_item_id_location_id_lot_number_key
+    UNIQUE (item_id, location_id, lot_number);
+ALTER TABLE inventory_lots DROP COLUMN IF EXISTS stock_state;
+
+DROP TABLE IF EXISTS transfer_order_lines;
+DROP TABLE IF EXISTS transfer_orders;
@@ -0,0 +1,36 @@
+-- Lệnh chuyển kho giữa hai location. Khi xuất, hàng bị trừ khỏi on-hand của nguồn và nằm ở in_transit
+-- của đích cho tới khi được nhận; phần chênh lệch được ghi vào discrepancy khi lệnh đóng.
+CREATE TABLE IF NOT EXISTS transfer_orders (
+    id VARCHAR(64) PRIMARY KEY,
+    source_location_id VARCHAR(64) NOT NULL REFERENCES locations(id),
+    destination_location_id VARCHAR(64) NOT NULL REFERENCES locations(id),
+    status VARCHAR(32) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'shipped', 'partially_received', 'received', 'cancelled')),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    CHECK (source_location_id <> destination_location_id)
+);
+
+CREATE INDEX idx_transfer_orders_status ON transfer_orders(status, id);
+
+-- quantity là số lượng xuất, received_quantity là tổng đã nhận qua các lần nhận.
+-- discrepancy = quantity - received_quantity khi lệnh đóng: dương là thiếu, âm là thừa.
+CREATE TABLE IF NOT EXISTS transfer_order_lines (
+    transfer_id VARCHAR(64) NOT NULL REFERENCES transfer_orders(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    discrepancy INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (transfer_id, item_id)
+);
+
+-- Lô hàng theo từng trạng thái tồn kho: khi hàng chuyển sang in_transit, quarantined hoặc damaged (chuyển kho,
+-- cách ly), các lô bị trừ ở trạng thái nguồn được ghi lại ở trạng thái đích với cùng số lô và hạn dùng,
+-- để hàng trở về on-hand vẫn giữ lô và FEFO ở location đích vẫn thấy hạn dùng.
+ALTER TABLE inventory_lots ADD COLUMN IF NOT EXISTS stock_state VARCHAR(16) NOT NULL DEFAULT 'on_hand';
+ALTER TABLE inventory_lots DROP CONSTRAINT IF EXISTS inventory_lots_item_id_location_id_lot_number_key;
+ALTER TABLE inventory_lots ADD CONSTRAINT inventory_lots_item_location_state_lot_key
+    UNIQUE (item_id, location_id, stock_state, lot_number);
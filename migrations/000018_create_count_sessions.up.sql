@@ -0,0 +1,26 @@
+-- Phiên kiểm kê tại một location: số lượng kỳ vọng được chụp khi tạo phiên, số lượng đếm được nhập dần
+-- (kể cả qua CSV). Khi post, chênh lệch được ghi thành movement điều chỉnh thay vì ghi đè quantity.
+CREATE TABLE IF NOT EXISTS count_sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    location_id VARCHAR(64) NOT NULL REFERENCES locations(id),
+    status VARCHAR(32) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'pending_approval', 'posted', 'cancelled')),
+    note TEXT NOT NULL DEFAULT '',
+    approved_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    submitted_at TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX idx_count_sessions_status ON count_sessions(status, id);
+
+-- counted_quantity NULL = item chưa được đếm; dòng chưa đếm không được điều chỉnh khi post.
+CREATE TABLE IF NOT EXISTS count_session_lines (
+    session_id VARCHAR(64) NOT NULL REFERENCES count_sessions(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+    expected_quantity INT NOT NULL,
+    counted_quantity INT CHECK (counted_quantity >= 0),
+    counted_at TIMESTAMP,
+    PRIMARY KEY (session_id, item_id)
+);